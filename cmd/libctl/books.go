@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/utils/mappers"
+)
+
+func importBooks(a *app, fs *flag.FlagSet, args []string) error {
+	dryRun := fs.Bool("dry-run", false, "check the file and report what would be imported, without writing anything")
+	formatFlag := fs.String("format", "", "csv or marc (binary MARC 21 or MARCXML); by default guessed from the file extension")
+	parseArgs(fs, args, 1)
+
+	format := constants.ImportFormat(*formatFlag)
+	if format == "" {
+		format = constants.ImportFormatForFile(fs.Arg(0))
+	}
+	if !format.IsValid() {
+		return constants.ErrInvalidImportFormat
+	}
+
+	file, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var rows []dto.BookImportRow
+	if format == constants.ImportMARC {
+		rows, err = mappers.MapMARCToBookImportRows(file)
+	} else {
+		rows, err = mappers.MapCSVToBookImportRows(file)
+	}
+	if err != nil {
+		return err
+	}
+
+	a.connect()
+	report, err := a.books.ImportBooks(rows, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	// Only the rows that need attention are listed
+	var tableRows [][]string
+	for _, row := range report.Rows {
+		if row.Status != constants.ImportCreated {
+			tableRows = append(tableRows, []string{strconv.Itoa(row.Line), string(row.Status), row.ISBN, row.Error})
+		}
+	}
+	if err := a.out.print(report, []string{"ROW", "STATUS", "ISBN", "ERROR"}, tableRows); err != nil {
+		return err
+	}
+	if a.out.format == formatTable {
+		if report.DryRun {
+			fmt.Fprintf(a.out.w, "Dry run: %d books would be created, %d duplicates, %d invalid rows\n", report.Created, report.Duplicates, report.Invalid)
+		} else {
+			fmt.Fprintf(a.out.w, "%d books created, %d duplicates skipped, %d invalid rows, %d rows not imported\n", report.Created, report.Duplicates, report.Invalid, report.NotImported)
+		}
+	}
+
+	if report.Invalid > 0 || report.NotImported > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func exportBooks(a *app, fs *flag.FlagSet, args []string) error {
+	format := fs.String("format", string(constants.ExportCSV), "csv, jsonl, bibtex, ris, marc or marcxml")
+	query := fs.String("q", "", "only the books matching a full-text search")
+	author := fs.String("author", "", "only the books by an author")
+	tag := fs.String("tag", "", "only the books with a tag")
+	available := fs.Bool("available", false, "only the books with a copy on the shelf")
+	out := fs.String("out", "", "file to write to; standard output when left out")
+	parseArgs(fs, args, 0)
+
+	if !constants.ExportFormat(*format).IsValid() {
+		return constants.ErrInvalidExportFormat
+	}
+	filter := dto.BookFilter{Query: *query, Author: *author, Tag: *tag, AvailableOnly: *available}
+
+	a.connect()
+	if *out == "" {
+		return a.books.ExportBooks(filter, constants.ExportFormat(*format), os.Stdout)
+	}
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := a.books.ExportBooks(filter, constants.ExportFormat(*format), file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
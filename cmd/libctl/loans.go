@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+)
+
+// loanPageSize is the number of loans fetched at a time when listing them all
+const loanPageSize = 100
+
+func listOverdueLoans(a *app, fs *flag.FlagSet, args []string) error {
+	parseArgs(fs, args, 0)
+
+	a.connect()
+	var loans []dto.BorrowResponse
+	for page := 1; ; page++ {
+		borrows, total, err := a.borrows.GetBorrowRecords(dto.BorrowFilter{Status: constants.BorrowOverdue}, page, loanPageSize)
+		if err != nil {
+			return err
+		}
+		loans = append(loans, borrows...)
+		if len(borrows) < loanPageSize || int64(len(loans)) >= total {
+			break
+		}
+	}
+
+	now := time.Now()
+	rows := make([][]string, len(loans))
+	for i, loan := range loans {
+		var email, title string
+		if loan.User != nil {
+			email = loan.User.Email
+		}
+		if loan.Book != nil {
+			title = loan.Book.Title
+		}
+		daysOverdue := int(now.Sub(loan.DueDate).Hours() / 24)
+		rows[i] = []string{
+			strconv.FormatUint(uint64(loan.ID), 10), email, title, loan.Barcode,
+			loan.DueDate.Format("2006-01-02"), strconv.Itoa(daysOverdue),
+		}
+	}
+	if loans == nil {
+		loans = []dto.BorrowResponse{}
+	}
+	return a.out.print(loans, []string{"ID", "USER", "BOOK", "BARCODE", "DUE", "DAYS OVERDUE"}, rows)
+}
+
+func forceReturn(a *app, fs *flag.FlagSet, args []string) error {
+	parseArgs(fs, args, 1)
+	id, err := strconv.ParseUint(fs.Arg(0), 10, 0)
+	if err != nil || id == 0 {
+		return constants.ErrInvalidBorrowID
+	}
+
+	a.connect()
+	if err := a.borrows.ForceReturn(uint(id)); err != nil {
+		return err
+	}
+	return a.out.message(fmt.Sprintf("Loan %d returned", id))
+}
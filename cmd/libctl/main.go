@@ -0,0 +1,142 @@
+// Command libctl runs administrative tasks directly against the database,
+// through the same services as the API. It needs no account, which makes it
+// the way to create the first admin.
+//
+//	libctl [-o table|json] COMMAND [ARGS]
+//
+// Commands:
+//
+//	admin create -name NAME -email EMAIL [-password PASSWORD]
+//	admin promote EMAIL
+//	user reset-password [-password PASSWORD] EMAIL
+//	books import [-dry-run] [-format csv|marc] FILE
+//	books export [-format FORMAT] [-q QUERY] [-author NAME] [-tag TAG] [-available] [-out FILE]
+//	loans overdue
+//	loans return BORROW_ID
+//
+// A password left out is generated and printed on stderr.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"library-management/config"
+	"library-management/internal/notify"
+	"library-management/internal/policy"
+	"library-management/internal/repository"
+	"library-management/internal/services"
+
+	"github.com/joho/godotenv"
+)
+
+// app holds what the commands work with
+type app struct {
+	users   services.UserServiceInterface
+	books   services.BookServiceInterface
+	borrows services.BorrowServiceInterface
+	out     *output
+}
+
+// command is a subcommand such as "admin create"
+type command struct {
+	usage string
+	run   func(a *app, fs *flag.FlagSet, args []string) error
+}
+
+var commands = map[string]command{
+	"admin create":        {usage: "admin create -name NAME -email EMAIL [-password PASSWORD]", run: createAdmin},
+	"admin promote":       {usage: "admin promote EMAIL", run: promoteAdmin},
+	"user reset-password": {usage: "user reset-password [-password PASSWORD] EMAIL", run: resetPassword},
+	"books import":        {usage: "books import [-dry-run] [-format csv|marc] FILE", run: importBooks},
+	"books export":        {usage: "books export [-format FORMAT] [-q QUERY] [-author NAME] [-tag TAG] [-available] [-out FILE]", run: exportBooks},
+	"loans overdue":       {usage: "loans overdue", run: listOverdueLoans},
+	"loans return":        {usage: "loans return BORROW_ID", run: forceReturn},
+}
+
+// commandOrder lists the commands in the order usage shows them
+var commandOrder = []string{"admin create", "admin promote", "user reset-password", "books import", "books export", "loans overdue", "loans return"}
+
+func main() {
+	outputFlag := flag.String("o", string(formatTable), "output format: table or json")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: libctl [-o table|json] COMMAND [ARGS]\n\nCommands:")
+		for _, name := range commandOrder {
+			fmt.Fprintln(flag.CommandLine.Output(), "  "+commands[name].usage)
+		}
+		fmt.Fprintln(flag.CommandLine.Output(), "\nOptions:")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	format := outputFormat(*outputFlag)
+	if format != formatTable && format != formatJSON {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	name := flag.Arg(0) + " " + flag.Arg(1)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(flag.CommandLine.Output(), "Unknown command %q\n\n", name)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("⚠️  Warning: No .env file found, using default values.")
+	}
+
+	a := &app{out: &output{format: format, w: os.Stdout}}
+	if err := cmd.run(a, newFlagSet(cmd.usage), flag.Args()[2:]); err != nil {
+		log.Fatalf("❌ %s: %v", name, err)
+	}
+}
+
+// connect opens the database and sets up the services. Commands call it
+// once their arguments are parsed, so usage errors don't need a database.
+func (a *app) connect() {
+	cfg := config.LoadConfig()
+	db := config.ConnectDatabase()
+
+	userRepo := repository.NewUserRepository(db)
+	bookRepo := repository.NewBookRepository(db)
+	copyRepo := repository.NewBookCopyRepository(db)
+	borrowRepo := repository.NewBorrowRepository(db)
+	notifier, err := notify.New(cfg.Notify.Driver, cfg.Notify.Dir)
+	if err != nil {
+		log.Fatalf("❌ Failed to set up notifications: %v", err)
+	}
+	verification := services.NewEmailVerificationService(userRepo, repository.NewEmailVerificationRepository(db), notifier, cfg.Verification)
+	a.users = services.NewUserService(userRepo, repository.NewRoleRepository(db), borrowRepo, repository.NewAuditRepository(db), verification)
+	a.books = services.NewBookService(bookRepo, copyRepo, repository.NewAuthorRepository(db),
+		repository.NewCategoryRepository(db), repository.NewTagRepository(db))
+	a.borrows = services.NewBorrowService(borrowRepo, bookRepo, copyRepo, userRepo,
+		repository.NewHoldRepository(db), repository.NewFineRepository(db), cfg.Circulation, policy.DefaultCirculationPolicy())
+}
+
+// newFlagSet returns the flags of a command, which prints its usage line on errors
+func newFlagSet(usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(strings.Fields(usage)[0], flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: libctl "+usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses the flags of a command and checks it got n arguments
+func parseArgs(fs *flag.FlagSet, args []string, n int) {
+	fs.Parse(args)
+	if fs.NArg() != n {
+		fs.Usage()
+		os.Exit(2)
+	}
+}
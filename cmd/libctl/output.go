@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// outputFormat is how commands print their results
+type outputFormat string
+
+const (
+	formatTable outputFormat = "table"
+	formatJSON  outputFormat = "json"
+)
+
+// output prints results as an aligned table for people, or as JSON for scripts
+type output struct {
+	format outputFormat
+	w      io.Writer
+}
+
+// print writes v as indented JSON, or the header and rows as a table
+func (o *output) print(v any, header []string, rows [][]string) error {
+	if o.format == formatJSON {
+		encoder := json.NewEncoder(o.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// message prints the outcome of a command that has nothing else to show,
+// as {"message": ...} in JSON like the API does
+func (o *output) message(message string) error {
+	if o.format == formatJSON {
+		return o.print(map[string]string{"message": message}, nil, nil)
+	}
+	_, err := fmt.Fprintln(o.w, "✅ "+message)
+	return err
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/utils/handlers"
+)
+
+// passwordAlphabets are the kinds of characters a password needs, leaving
+// out the ones easily mistaken for each other
+var passwordAlphabets = []string{
+	"abcdefghijkmnopqrstuvwxyz",
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"23456789",
+	"!@#$%^&*-_=+",
+}
+
+const generatedPasswordLength = 16
+
+// operatorRole is the role libctl acts with: whoever can run it has the
+// database anyway
+const operatorRole = string(constants.Admin)
+
+// passwordInput checks a new password against the rules the API applies
+type passwordInput struct {
+	Password string `json:"password" validate:"password"`
+}
+
+func createAdmin(a *app, fs *flag.FlagSet, args []string) error {
+	name := fs.String("name", "", "full name")
+	email := fs.String("email", "", "email address to log in with")
+	password := fs.String("password", "", "password; generated when left out")
+	parseArgs(fs, args, 0)
+
+	req := dto.UserCreateRequest{Name: *name, Email: *email, Password: *password, Role: string(constants.Admin)}
+	generated := req.Password == ""
+	if generated {
+		var err error
+		if req.Password, err = generatePassword(); err != nil {
+			return err
+		}
+	}
+	if err := handlers.Validate(&req); err != nil {
+		return err
+	}
+
+	a.connect()
+	user, err := a.users.CreateUser(req, operatorRole)
+	if err != nil {
+		return err
+	}
+	if generated {
+		printPassword(req.Password)
+	}
+	return printUsers(a.out, user)
+}
+
+func promoteAdmin(a *app, fs *flag.FlagSet, args []string) error {
+	parseArgs(fs, args, 1)
+
+	a.connect()
+	user, err := a.users.GetUserByEmail(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	role := string(constants.Admin)
+	user, err = a.users.UpdateUser(user.ID, dto.UserUpdateRequest{Role: &role}, operatorRole)
+	if err != nil {
+		return err
+	}
+	return printUsers(a.out, user)
+}
+
+func resetPassword(a *app, fs *flag.FlagSet, args []string) error {
+	password := fs.String("password", "", "new password; generated when left out")
+	parseArgs(fs, args, 1)
+
+	generated := *password == ""
+	if generated {
+		var err error
+		if *password, err = generatePassword(); err != nil {
+			return err
+		}
+	}
+	req := dto.UserUpdateRequest{Password: password}
+	if err := handlers.Validate(&passwordInput{Password: *password}); err != nil {
+		return err
+	}
+
+	a.connect()
+	user, err := a.users.GetUserByEmail(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	user, err = a.users.UpdateUser(user.ID, req, operatorRole)
+	if err != nil {
+		return err
+	}
+	if generated {
+		printPassword(*password)
+	}
+	return printUsers(a.out, user)
+}
+
+// printUsers prints users as a table or JSON
+func printUsers(out *output, users ...dto.UserResponse) error {
+	rows := make([][]string, len(users))
+	for i, user := range users {
+		rows[i] = []string{strconv.FormatUint(uint64(user.ID), 10), user.Name, user.Email, user.Role, user.CreatedAt.Format("2006-01-02")}
+	}
+	var v any = users
+	if len(users) == 1 {
+		v = users[0]
+	}
+	return out.print(v, []string{"ID", "NAME", "EMAIL", "ROLE", "CREATED"}, rows)
+}
+
+// printPassword shows a generated password on stderr, apart from the output
+// scripts read
+func printPassword(password string) {
+	fmt.Fprintln(os.Stderr, "🔑 Generated password:", password)
+}
+
+// generatePassword returns a random password with each kind of character
+// the password rules ask for
+func generatePassword() (string, error) {
+	password := make([]byte, generatedPasswordLength)
+	for i := range password {
+		alphabet := passwordAlphabets[i%len(passwordAlphabets)]
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = alphabet[n.Int64()]
+	}
+	// Shuffle, so the kinds of characters don't come in a fixed order
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
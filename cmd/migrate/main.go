@@ -0,0 +1,95 @@
+// Command migrate applies, reverts and lists the schema migrations.
+//
+//	go run ./cmd/migrate up          # apply the pending migrations
+//	go run ./cmd/migrate down [N]    # revert the latest N migrations (default 1)
+//	go run ./cmd/migrate status      # list the migrations and when each was applied
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"library-management/config"
+	"library-management/internal/migrations"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: migrate up | down [N] | status")
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	steps := 1
+	switch command := flag.Arg(0); {
+	case command == "down" && flag.NArg() == 2:
+		n, err := strconv.Atoi(flag.Arg(1))
+		if err != nil || n < 1 {
+			log.Fatalf("❌ Invalid number of migrations to revert: %q", flag.Arg(1))
+		}
+		steps = n
+	case (command == "up" || command == "down" || command == "status") && flag.NArg() == 1:
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("⚠️  Warning: No .env file found, using default values.")
+	}
+
+	all, err := migrations.Migrations()
+	if err != nil {
+		log.Fatalf("❌ Failed to load migrations: %v", err)
+	}
+	migrator := migrations.NewMigrator(config.OpenDatabase(), all)
+
+	switch flag.Arg(0) {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			fmt.Printf("⬆️  Applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("❌ Migration failed: %v", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("✅ Database is up to date")
+		}
+	case "down":
+		reverted, err := migrator.Down(steps)
+		for _, migration := range reverted {
+			fmt.Printf("⬇️  Reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("❌ Migration failed: %v", err)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("✅ No migrations to revert")
+		}
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("❌ Failed to read the applied migrations: %v", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		if err := migrator.CheckVersion(); err != nil {
+			fmt.Println("⚠️ ", err)
+		}
+	}
+}
@@ -2,16 +2,103 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBSSLMode  string
-	SecretKey  string
+	DBHost        string
+	DBPort        string
+	DBUser        string
+	DBPassword    string
+	DBName        string
+	DBSSLMode     string
+	SecretKey     string
+	StorageDir    string // where uploaded files such as book covers are kept
+	Auth          AuthConfig
+	PasswordReset PasswordResetConfig
+	Verification  EmailVerificationConfig
+	Notify        NotifyConfig
+	Circulation   CirculationConfig
+	Metadata      MetadataConfig
+}
+
+// AuthConfig holds how long the tokens handed out at login last, and how
+// password guessing is held back
+type AuthConfig struct {
+	// Access tokens are checked on every request and kept short-lived
+	AccessTokenTTL time.Duration
+	// Refresh tokens get new access tokens until they expire or are revoked
+	RefreshTokenTTL time.Duration
+	// How many failed logins within LockoutDuration lock an account, and
+	// for how long
+	MaxFailedLogins int
+	LockoutDuration time.Duration
+	// How many failed logins a client IP can make within LockoutDuration
+	FailedLoginsPerIP int
+}
+
+// PasswordResetConfig holds the rules of resetting a forgotten password
+type PasswordResetConfig struct {
+	// How long a reset link works
+	TokenTTL time.Duration
+	// How many reset emails an account gets per hour at most
+	MaxEmailsPerHour int
+	// How many reset requests a client IP can make per hour
+	RequestsPerHour int
+	// Page of the frontend the link in the email opens, with the token
+	// appended as ?token=. The email only carries the token when unset.
+	URL string
+}
+
+// EmailVerificationConfig holds the rules of verifying the email of a new account
+type EmailVerificationConfig struct {
+	// How long a verification link works
+	TokenTTL time.Duration
+	// How many verification emails an account gets per hour at most
+	MaxEmailsPerHour int
+	// Page of the frontend the link in the email opens, with the token
+	// appended as ?token=. The email only carries the token when unset.
+	URL string
+}
+
+// NotifyConfig holds where messages to users go
+type NotifyConfig struct {
+	// "log" writes them to the log, "file" to files in Dir
+	Driver string
+	Dir    string
+}
+
+// CirculationConfig holds the library's lending rules
+type CirculationConfig struct {
+	// How long a copy is set aside for a member once their hold is ready
+	HoldPickupWindow time.Duration
+	// How far a renewal pushes out the due date
+	RenewalPeriod time.Duration
+	// How many times a single loan can be renewed
+	MaxRenewals int
+	// How long after the due date a loan can still be renewed
+	RenewalGracePeriod time.Duration
+	// Overdue fine accrued per day late, in cents
+	FinePerDay int64
+	// Maximum fine a single loan can accrue, in cents
+	MaxFinePerLoan int64
+	// Unpaid balance, in cents, above which a member can't borrow
+	FineBlockThreshold int64
+	// Whether members have to verify their email before borrowing
+	RequireVerifiedEmail bool
+}
+
+// MetadataConfig holds the settings of the bibliographic catalog books are
+// looked up in by ISBN
+type MetadataConfig struct {
+	// Base URL of a catalog serving the Open Library Books API
+	ProviderURL string
+	// How long to wait for the catalog before giving up
+	Timeout time.Duration
+	// How long lookups are remembered, and how many at most
+	CacheTTL  time.Duration
+	CacheSize int
 }
 
 func LoadConfig() *Config {
@@ -23,5 +110,75 @@ func LoadConfig() *Config {
 		DBName:     os.Getenv("DB_NAME"),
 		DBSSLMode:  os.Getenv("DB_SSLMODE"),
 		SecretKey:  os.Getenv("SECRET_KEY"),
+		StorageDir: getEnv("STORAGE_DIR", "data"),
+		Auth: AuthConfig{
+			AccessTokenTTL:    time.Duration(getEnvInt("ACCESS_TOKEN_MINUTES", 15)) * time.Minute,
+			RefreshTokenTTL:   getEnvDays("REFRESH_TOKEN_DAYS", 30),
+			MaxFailedLogins:   getEnvInt("LOGIN_MAX_FAILURES", 5),
+			LockoutDuration:   time.Duration(getEnvInt("LOGIN_LOCKOUT_MINUTES", 15)) * time.Minute,
+			FailedLoginsPerIP: getEnvInt("LOGIN_FAILURES_PER_IP", 20),
+		},
+		PasswordReset: PasswordResetConfig{
+			TokenTTL:         time.Duration(getEnvInt("PASSWORD_RESET_MINUTES", 60)) * time.Minute,
+			MaxEmailsPerHour: getEnvInt("PASSWORD_RESET_EMAILS_PER_HOUR", 3),
+			RequestsPerHour:  getEnvInt("PASSWORD_RESET_REQUESTS_PER_HOUR", 10),
+			URL:              os.Getenv("PASSWORD_RESET_URL"),
+		},
+		Verification: EmailVerificationConfig{
+			TokenTTL:         time.Duration(getEnvInt("EMAIL_VERIFICATION_HOURS", 48)) * time.Hour,
+			MaxEmailsPerHour: getEnvInt("EMAIL_VERIFICATION_EMAILS_PER_HOUR", 3),
+			URL:              os.Getenv("EMAIL_VERIFICATION_URL"),
+		},
+		Notify: NotifyConfig{
+			Driver: getEnv("NOTIFIER", "log"),
+			Dir:    getEnv("NOTIFY_DIR", "data/mail"),
+		},
+		Circulation: CirculationConfig{
+			HoldPickupWindow:     getEnvDays("HOLD_PICKUP_DAYS", 3),
+			RenewalPeriod:        getEnvDays("RENEWAL_DAYS", 14),
+			MaxRenewals:          getEnvInt("MAX_RENEWALS", 2),
+			RenewalGracePeriod:   getEnvDays("RENEWAL_GRACE_DAYS", 3),
+			FinePerDay:           int64(getEnvInt("FINE_PER_DAY_CENTS", 25)),
+			MaxFinePerLoan:       int64(getEnvInt("MAX_FINE_PER_LOAN_CENTS", 1000)),
+			FineBlockThreshold:   int64(getEnvInt("FINE_BLOCK_THRESHOLD_CENTS", 500)),
+			RequireVerifiedEmail: getEnvBool("REQUIRE_VERIFIED_EMAIL", true),
+		},
+		Metadata: MetadataConfig{
+			ProviderURL: getEnv("METADATA_PROVIDER_URL", "https://openlibrary.org"),
+			Timeout:     time.Duration(getEnvInt("METADATA_TIMEOUT_SECONDS", 5)) * time.Second,
+			CacheTTL:    time.Duration(getEnvInt("METADATA_CACHE_HOURS", 24)) * time.Hour,
+			CacheSize:   getEnvInt("METADATA_CACHE_SIZE", 1000),
+		},
 	}
 }
+
+// getEnv reads an environment variable, falling back to def when unset
+func getEnv(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// getEnvInt reads an integer environment variable, falling back to def when unset or invalid
+func getEnvInt(key string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// getEnvBool reads a boolean environment variable, falling back to def when unset or invalid
+func getEnvBool(key string, def bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// getEnvDays reads a number of days from the environment as a duration
+func getEnvDays(key string, def int) time.Duration {
+	return time.Duration(getEnvInt(key, def)) * 24 * time.Hour
+}
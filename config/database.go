@@ -1,19 +1,20 @@
 package config
 
 import (
-	"fmt"
 	"log"
 	"os"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
-	"library-management/internal/models"
+	"library-management/internal/migrations"
 )
 
 var DB *gorm.DB
 
-func ConnectDatabase() *gorm.DB {
+// OpenDatabase connects to the database without checking its schema, for
+// the migrate command
+func OpenDatabase() *gorm.DB {
 	// Construct DSN from environment variables
 	dsn := "host=" + os.Getenv("DB_HOST") +
 		" user=" + os.Getenv("DB_USER") +
@@ -28,19 +29,25 @@ func ConnectDatabase() *gorm.DB {
 		log.Fatalf("❌ Failed to connect to database: %v", err)
 	}
 
-	fmt.Println("✅ Database connected successfully")
+	// On stderr, to keep the output of commands such as libctl clean
+	log.Println("✅ Database connected successfully")
+
+	return database
+}
 
-	// **Run Migrations**
-	err = database.AutoMigrate(
-		&models.User{},
-		&models.Book{},
-		&models.Borrow{},
-	)
+// ConnectDatabase connects to the database and makes sure its schema is at
+// the version this build expects. The schema is changed with the migrate
+// command, never on startup.
+func ConnectDatabase() *gorm.DB {
+	database := OpenDatabase()
+
+	all, err := migrations.Migrations()
 	if err != nil {
-		log.Fatal("❌ Failed to migrate database:", err)
+		log.Fatalf("❌ Failed to load migrations: %v", err)
+	}
+	if err := migrations.NewMigrator(database, all).CheckVersion(); err != nil {
+		log.Fatalf("❌ Refusing to start: %v", err)
 	}
-
-	fmt.Println("✅ Database migrated successfully")
 
 	DB = database
 
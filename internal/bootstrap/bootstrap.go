@@ -3,39 +3,116 @@ package bootstrap
 import (
 	"library-management/config"
 	"library-management/internal/handlers"
+	"library-management/internal/metadata"
+	"library-management/internal/notify"
+	"library-management/internal/policy"
+	"library-management/internal/ratelimit"
 	"library-management/internal/repository"
 	"library-management/internal/routes"
 	"library-management/internal/services"
+	"library-management/internal/storage"
+	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Initialize and return Gin router
 func SetupServer() *gin.Engine {
+	cfg := config.LoadConfig()
 	db := config.ConnectDatabase()
 	r := gin.Default()
 
 	// Initialize dependencies
+	roleRepo := repository.NewRoleRepository(db)
+	roleService := services.NewRoleService(roleRepo)
+	roleHandler := handlers.NewRoleHandler(roleService)
+
+	notifier, err := notify.New(cfg.Notify.Driver, cfg.Notify.Dir)
+	if err != nil {
+		log.Fatalf("❌ Failed to set up notifications: %v", err)
+	}
+
 	userRepo := repository.NewUserRepository(db)
-	userService := services.NewUserService(userRepo)
+	verificationService := services.NewEmailVerificationService(userRepo, repository.NewEmailVerificationRepository(db), notifier, cfg.Verification)
+	verificationHandler := handlers.NewEmailVerificationHandler(verificationService)
+
+	borrowRepo := repository.NewBorrowRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	userService := services.NewUserService(userRepo, roleRepo, borrowRepo, auditRepo, verificationService)
 	userHandler := handlers.NewUserHandler(userService)
 
-	authService := services.NewAuthService(userRepo)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	authService := services.NewAuthService(userRepo, refreshTokenRepo, auditRepo, verificationService, cfg.Auth)
 	authHandler := handlers.NewAuthHandler(authService)
+	loginLimiter := ratelimit.NewLimiter(cfg.Auth.FailedLoginsPerIP, cfg.Auth.LockoutDuration)
+
+	resetService := services.NewPasswordResetService(userRepo, repository.NewPasswordResetRepository(db), notifier, cfg.PasswordReset)
+	resetHandler := handlers.NewPasswordResetHandler(resetService)
+	resetLimiter := ratelimit.NewLimiter(cfg.PasswordReset.RequestsPerHour, time.Hour)
 
 	bookRepo := repository.NewBookRepository(db)
-	bookService := services.NewBookService(bookRepo)
+	copyRepo := repository.NewBookCopyRepository(db)
+	authorRepo := repository.NewAuthorRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	bookService := services.NewBookService(bookRepo, copyRepo, authorRepo, categoryRepo, tagRepo)
 	bookHandler := handlers.NewBookHandler(bookService)
 
-	borrowRepo := repository.NewBorrowRepository(db)
-	borrowService := services.NewBorrowService(borrowRepo, bookRepo, userRepo)
+	store, err := storage.NewLocalStorage(cfg.StorageDir)
+	if err != nil {
+		log.Fatalf("❌ Failed to set up file storage: %v", err)
+	}
+	coverService := services.NewBookCoverService(bookRepo, store)
+	coverHandler := handlers.NewBookCoverHandler(coverService)
+
+	metadataProvider := metadata.NewCachingProvider(
+		metadata.NewOpenLibraryClient(cfg.Metadata.ProviderURL, cfg.Metadata.Timeout),
+		cfg.Metadata.CacheTTL,
+		cfg.Metadata.CacheSize,
+	)
+	lookupService := services.NewBookLookupService(bookRepo, metadataProvider)
+	lookupHandler := handlers.NewBookLookupHandler(lookupService)
+
+	authorService := services.NewAuthorService(authorRepo, bookRepo)
+	authorHandler := handlers.NewAuthorHandler(authorService)
+
+	categoryService := services.NewCategoryService(categoryRepo)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+
+	tagService := services.NewTagService(tagRepo)
+	tagHandler := handlers.NewTagHandler(tagService)
+
+	holdRepo := repository.NewHoldRepository(db)
+	holdService := services.NewHoldService(holdRepo, borrowRepo, bookRepo, copyRepo, cfg.Circulation)
+	holdHandler := handlers.NewHoldHandler(holdService)
+
+	copyService := services.NewBookCopyService(copyRepo, bookRepo, holdRepo, cfg.Circulation)
+	copyHandler := handlers.NewBookCopyHandler(copyService)
+
+	fineRepo := repository.NewFineRepository(db)
+	fineService := services.NewFineService(fineRepo)
+	fineHandler := handlers.NewFineHandler(fineService)
+
+	borrowService := services.NewBorrowService(borrowRepo, bookRepo, copyRepo, userRepo, holdRepo, fineRepo, cfg.Circulation, policy.DefaultCirculationPolicy())
 	borrowHandler := handlers.NewBorrowHandler(borrowService)
 
 	// Register routes
-	routes.SetupUserRoutes(r, userHandler)
-	routes.SetupAuthRoutes(r, authHandler)
-	routes.SetupBookRoutes(r, bookHandler)
-	routes.SetupBorrowRoutes(r, borrowHandler)
+	routes.SetupUserRoutes(r, userHandler, authService, roleService)
+	routes.SetupAuthRoutes(r, authHandler, loginLimiter, auditRepo)
+	routes.SetupPasswordResetRoutes(r, resetHandler, resetLimiter)
+	routes.SetupEmailVerificationRoutes(r, verificationHandler, authService)
+	routes.SetupRoleRoutes(r, roleHandler, authService, roleService)
+	routes.SetupBookRoutes(r, bookHandler, authService, roleService)
+	routes.SetupBookLookupRoutes(r, lookupHandler, authService, roleService)
+	routes.SetupBookCoverRoutes(r, coverHandler, authService, roleService)
+	routes.SetupBookCopyRoutes(r, copyHandler, authService, roleService)
+	routes.SetupAuthorRoutes(r, authorHandler, authService, roleService)
+	routes.SetupCategoryRoutes(r, categoryHandler, authService, roleService)
+	routes.SetupTagRoutes(r, tagHandler, authService, roleService)
+	routes.SetupBorrowRoutes(r, borrowHandler, authService, roleService)
+	routes.SetupHoldRoutes(r, holdHandler, authService, roleService)
+	routes.SetupFineRoutes(r, fineHandler, authService, roleService)
 
 	return r
 }
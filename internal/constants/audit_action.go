@@ -0,0 +1,10 @@
+package constants
+
+// AuditAction names a security event recorded in the audit log
+type AuditAction string
+
+const (
+	AuditAccountLocked   AuditAction = "account_locked"   // too many failed logins
+	AuditAccountUnlocked AuditAction = "account_unlocked" // by a staff member
+	AuditIPBlocked       AuditAction = "ip_blocked"       // too many failed logins from one client IP
+)
@@ -0,0 +1,21 @@
+package constants
+
+// BookSortField lists the fields book listings can be sorted by
+type BookSortField string
+
+const (
+	SortByRelevance   BookSortField = "relevance" // search results only
+	SortByTitle       BookSortField = "title"
+	SortByAuthor      BookSortField = "author"
+	SortByPublishedAt BookSortField = "published_at"
+	SortByCreatedAt   BookSortField = "created_at"
+)
+
+// IsValid reports whether the field is one of the known sort fields
+func (f BookSortField) IsValid() bool {
+	switch f {
+	case SortByRelevance, SortByTitle, SortByAuthor, SortByPublishedAt, SortByCreatedAt:
+		return true
+	}
+	return false
+}
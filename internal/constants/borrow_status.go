@@ -0,0 +1,19 @@
+package constants
+
+// BorrowStatus defines the lifecycle states used to filter borrow records
+type BorrowStatus string
+
+const (
+	BorrowActive   BorrowStatus = "active"
+	BorrowReturned BorrowStatus = "returned"
+	BorrowOverdue  BorrowStatus = "overdue"
+)
+
+// IsValid reports whether the status is one of the known borrow statuses
+func (s BorrowStatus) IsValid() bool {
+	switch s {
+	case BorrowActive, BorrowReturned, BorrowOverdue:
+		return true
+	}
+	return false
+}
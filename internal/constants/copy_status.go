@@ -0,0 +1,39 @@
+package constants
+
+// CopyStatus defines where a physical copy of a book currently is
+type CopyStatus string
+
+const (
+	CopyAvailable CopyStatus = "available" // on the shelf, can be borrowed
+	CopyOnLoan    CopyStatus = "on_loan"
+	CopyOnHold    CopyStatus = "on_hold" // set aside for a member's ready hold
+	CopyLost      CopyStatus = "lost"
+	CopyDamaged   CopyStatus = "damaged"
+	CopyInRepair  CopyStatus = "in_repair"
+	CopyWithdrawn CopyStatus = "withdrawn"
+)
+
+// IsValid reports whether the status is one of the known copy statuses
+func (s CopyStatus) IsValid() bool {
+	switch s {
+	case CopyAvailable, CopyOnLoan, CopyOnHold, CopyLost, CopyDamaged, CopyInRepair, CopyWithdrawn:
+		return true
+	}
+	return false
+}
+
+// InCirculation reports whether the copy is out with, or set aside for, a member.
+// Only checkouts, returns and holds move a copy in and out of these statuses.
+func (s CopyStatus) InCirculation() bool {
+	return s == CopyOnLoan || s == CopyOnHold
+}
+
+// CopyCondition describes the physical state of a copy
+type CopyCondition string
+
+const (
+	ConditionNew  CopyCondition = "new"
+	ConditionGood CopyCondition = "good"
+	ConditionFair CopyCondition = "fair"
+	ConditionPoor CopyCondition = "poor"
+)
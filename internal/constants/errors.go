@@ -4,33 +4,183 @@ import "errors"
 
 // Authentication Errors
 var (
-	ErrInvalidCredentials    = errors.New("invalid email or password")
-	ErrUnauthorized          = errors.New("unauthorized access")
-	ErrForbidden             = errors.New("forbidden: insufficient permissions")
-	ErrMissingAuthHeader     = errors.New("authorization header missing")
-	ErrInvalidTokenFormat    = errors.New("invalid token format")
-	ErrInvalidOrExpiredToken = errors.New("invalid or expired token")
-	ErrInvalidSigningMethod  = errors.New("unexpected signing method")
+	ErrInvalidCredentials       = errors.New("invalid email or password")
+	ErrUnauthorized             = errors.New("unauthorized access")
+	ErrForbidden                = errors.New("forbidden: insufficient permissions")
+	ErrMissingAuthHeader        = errors.New("authorization header missing")
+	ErrInvalidTokenFormat       = errors.New("invalid token format")
+	ErrInvalidOrExpiredToken    = errors.New("invalid or expired token")
+	ErrInvalidSigningMethod     = errors.New("unexpected signing method")
+	ErrTokenRevoked             = errors.New("token has been revoked, log in again")
+	ErrInvalidRefreshToken      = errors.New("invalid or expired refresh token")
+	ErrInvalidResetToken        = errors.New("invalid or expired password reset token")
+	ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+	ErrTooManyRequests          = errors.New("too many requests, try again later")
 )
 
 // User Errors
 var (
-	ErrInvalidUserID = errors.New("invalid user id")
-	ErrUserNotFound  = errors.New("user not found")
-	ErrEmailTaken    = errors.New("email is already registered")
+	ErrInvalidUserID        = errors.New("invalid user id")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrEmailTaken           = errors.New("email is already registered")
+	ErrInvalidRole          = errors.New("role must be one of the defined roles")
+	ErrRoleAssignment       = errors.New("forbidden: only admins and role managers can assign roles")
+	ErrPrivilegedUser       = errors.New("forbidden: only admins and role managers can change admins and role managers")
+	ErrWrongPassword        = errors.New("current password is incorrect")
+	ErrOpenLoans            = errors.New("return all borrowed books before deleting the account")
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+)
+
+// Role Errors
+var (
+	ErrInvalidRoleID     = errors.New("invalid role id")
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleExists        = errors.New("a role with this name already exists")
+	ErrRoleInUse         = errors.New("role is assigned to users, give them another role first")
+	ErrBuiltInRole       = errors.New("the admin and member roles can't be deleted")
+	ErrAdminPermissions  = errors.New("the admin role always has every permission")
+	ErrUnknownPermission = errors.New("unknown permission")
 )
 
 // Book Errors
 var (
-	ErrInvalidBookID = errors.New("invalid book id")
-	ErrBookNotFound  = errors.New("book not found")
-	ErrISBNExists    = errors.New("isbn is already registered")
+	ErrInvalidBookID   = errors.New("invalid book id")
+	ErrBookNotFound    = errors.New("book not found")
+	ErrISBNExists      = errors.New("isbn is already registered")
+	ErrInvalidISBN     = errors.New("isbn must be a valid ISBN-10 or ISBN-13")
+	ErrInvalidSort     = errors.New("sort must be one of title, author, published_at, created_at (or relevance when searching), optionally prefixed with - for descending order")
+	ErrInvalidDate     = errors.New("dates must be formatted as YYYY-MM-DD")
+	ErrInvalidItemType = errors.New("item_type must be one of book, magazine, dvd, reference")
+)
+
+// Author Errors
+var (
+	ErrInvalidAuthorID = errors.New("invalid author id")
+	ErrAuthorNotFound  = errors.New("author not found")
+	ErrAuthorExists    = errors.New("an author with this name already exists")
+	ErrAuthorHasBooks  = errors.New("author is credited on books, remove them from the books first")
+	ErrAuthorRequired  = errors.New("at least one author is required")
+)
+
+// Category Errors
+var (
+	ErrInvalidCategoryID = errors.New("invalid category id")
+	ErrCategoryNotFound  = errors.New("category not found")
+	ErrCategoryExists    = errors.New("a category with this name already exists under the same parent")
+	ErrCategoryCycle     = errors.New("a category can't be moved under itself or one of its subcategories")
+	ErrCategoryInUse     = errors.New("category has subcategories or books, move them first")
+)
+
+// Tag Errors
+var (
+	ErrInvalidTagID    = errors.New("invalid tag id")
+	ErrTagNotFound     = errors.New("tag not found")
+	ErrTagExists       = errors.New("a tag with this name already exists")
+	ErrMergeIntoItself = errors.New("a tag can't be merged into itself")
+)
+
+// Copy Errors
+var (
+	ErrInvalidCopyID     = errors.New("invalid copy id")
+	ErrCopyNotFound      = errors.New("copy not found")
+	ErrBarcodeExists     = errors.New("barcode is already registered")
+	ErrCopyInCirculation = errors.New("copy is on loan or set aside for a hold")
+	ErrCopyStatusChanged = errors.New("copy status changed in the meantime, please retry")
+	ErrInvalidCopyStatus = errors.New("status must be one of available, lost, damaged, in_repair, withdrawn")
 )
 
 // Borrow Errors
 var (
-	ErrBorrowNotFound   = errors.New("borrow not found")
-	ErrBookNotAvailable = errors.New("book is not available for borrowing")
+	ErrInvalidBorrowID     = errors.New("invalid borrow id")
+	ErrRenewalLimitReached = errors.New("renewal limit reached for this loan")
+	ErrLoanTooOverdue      = errors.New("loan is too far overdue to be renewed")
+	ErrBookOnHold          = errors.New("book is on hold for another member")
+	ErrOutstandingFines    = errors.New("unpaid fines exceed the borrowing limit")
+	ErrItemNotLoanable     = errors.New("this item can't be borrowed")
+	ErrLoanLimitReached    = errors.New("maximum number of concurrent loans reached")
+	ErrDueDateOverride     = errors.New("forbidden: only staff can choose the due date")
+	ErrInvalidDueDate      = errors.New("due date must be in the future")
+	ErrBorrowNotFound      = errors.New("borrow not found")
+	ErrBookNotAvailable    = errors.New("book is not available for borrowing")
+	ErrEmailNotVerified    = errors.New("forbidden: verify your email before borrowing")
+	ErrAlreadyReturned     = errors.New("book has already been returned")
+	ErrLoanChanged         = errors.New("loan changed in the meantime, please retry")
+	ErrInvalidStatus       = errors.New("status must be one of active, returned, overdue")
+)
+
+// Hold Errors
+var (
+	ErrInvalidHoldID     = errors.New("invalid hold id")
+	ErrHoldNotFound      = errors.New("hold not found")
+	ErrHoldExists        = errors.New("you already have an active hold on this book")
+	ErrHoldNotActive     = errors.New("hold is no longer active")
+	ErrBookAvailable     = errors.New("book has available copies, borrow it instead")
+	ErrAlreadyBorrowed   = errors.New("you already have this book on loan")
+	ErrInvalidHoldStatus = errors.New("status must be one of pending, ready, fulfilled, cancelled, expired")
+)
+
+// Fine Errors
+var (
+	ErrInvalidFineID         = errors.New("invalid fine id")
+	ErrFineNotFound          = errors.New("fine not found")
+	ErrFineSettled           = errors.New("fine is already settled")
+	ErrPaymentExceedsBalance = errors.New("payment exceeds the outstanding balance of the fine")
+	ErrInvalidFineStatus     = errors.New("status must be one of unpaid, paid, waived")
+)
+
+// Import Errors
+var (
+	ErrImportFileRequired    = errors.New("upload the file to import in the \"file\" form field")
+	ErrInvalidImportFormat   = errors.New("format must be one of csv, marc")
+	ErrInvalidExportFormat   = errors.New("format must be one of csv, jsonl, bibtex, ris, marc, marcxml")
+	ErrImportFileTooLarge    = errors.New("file is too large, split it into files of at most 10 MB")
+	ErrInvalidCSV            = errors.New("file is not valid CSV")
+	ErrImportColumnMissing   = errors.New("file is missing a required column")
+	ErrUnknownImportColumn   = errors.New("file has an unknown column")
+	ErrDuplicateImportColumn = errors.New("file has the same column twice")
+	ErrImportFileEmpty       = errors.New("file has no books to import")
+	ErrImportFieldCount      = errors.New("row doesn't have the same number of fields as the header")
+	ErrISBNRepeated          = errors.New("isbn is on an earlier row of the file")
+	ErrImportInterrupted     = errors.New("the import stopped here because of a server error, import the file again to add the remaining books")
+)
+
+// MARC Errors
+var (
+	ErrInvalidMARC       = errors.New("file is not valid MARC 21 or MARCXML")
+	ErrMARCRecordTooLong = errors.New("record is too long for MARC 21")
+)
+
+// Cover Errors
+var (
+	ErrCoverFileRequired    = errors.New("upload the cover image in the \"file\" form field")
+	ErrCoverTooLarge        = errors.New("cover image is too large, it must be at most 5 MB")
+	ErrUnsupportedCoverType = errors.New("cover image must be a JPEG, PNG or GIF")
+	ErrInvalidCoverImage    = errors.New("cover image can't be read or is larger than 4000x4000 pixels")
+	ErrCoverNotFound        = errors.New("book has no cover")
+)
+
+// Metadata Errors
+var (
+	ErrMetadataNotFound    = errors.New("no bibliographic record found for this isbn")
+	ErrMetadataUnavailable = errors.New("the bibliographic catalog is unavailable, try again later or enter the book by hand")
+)
+
+// Storage Errors
+var (
+	ErrFileNotFound      = errors.New("file not found")
+	ErrInvalidStorageKey = errors.New("invalid storage key")
+)
+
+// Notification Errors
+var (
+	ErrUnknownNotifier = errors.New("unknown notifier, use \"log\" or \"file\"")
+)
+
+// Migration Errors
+var (
+	ErrInvalidMigration     = errors.New("invalid migration")
+	ErrSchemaOutdated       = errors.New("database schema is out of date, run the pending migrations with \"migrate up\"")
+	ErrUnknownSchemaVersion = errors.New("database schema version is newer than this build knows, deploy the matching build or revert with its \"migrate down\"")
 )
 
 // Validation Errors
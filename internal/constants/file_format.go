@@ -0,0 +1,49 @@
+package constants
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ImportFormat defines the file formats books can be imported from
+type ImportFormat string
+
+const (
+	ImportCSV  ImportFormat = "csv"
+	ImportMARC ImportFormat = "marc" // binary MARC 21 or MARCXML, told apart by the content
+)
+
+// IsValid reports whether the format is one of the known import formats
+func (f ImportFormat) IsValid() bool {
+	return f == ImportCSV || f == ImportMARC
+}
+
+// ImportFormatForFile guesses the format of a file from its extension
+func ImportFormatForFile(path string) ImportFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mrc", ".marc", ".xml":
+		return ImportMARC
+	}
+	return ImportCSV
+}
+
+// ExportFormat defines the file formats the catalog can be exported in
+type ExportFormat string
+
+const (
+	ExportCSV     ExportFormat = "csv"
+	ExportJSONL   ExportFormat = "jsonl" // JSON Lines, one book per line
+	ExportBibTeX  ExportFormat = "bibtex"
+	ExportRIS     ExportFormat = "ris"
+	ExportMARC    ExportFormat = "marc"
+	ExportMARCXML ExportFormat = "marcxml"
+)
+
+// IsValid reports whether the format is one of the known export formats
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case ExportCSV, ExportJSONL, ExportBibTeX, ExportRIS, ExportMARC, ExportMARCXML:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package constants
+
+// FineStatus defines the states of an overdue fine
+type FineStatus string
+
+const (
+	FineUnpaid FineStatus = "unpaid"
+	FinePaid   FineStatus = "paid"
+	FineWaived FineStatus = "waived"
+)
+
+// IsValid reports whether the status is one of the known fine statuses
+func (s FineStatus) IsValid() bool {
+	switch s {
+	case FineUnpaid, FinePaid, FineWaived:
+		return true
+	}
+	return false
+}
+
+// FineTransactionType defines the kinds of entries in the fines ledger
+type FineTransactionType string
+
+const (
+	FinePayment FineTransactionType = "payment"
+	FineWaiver  FineTransactionType = "waiver"
+)
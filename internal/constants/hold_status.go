@@ -0,0 +1,26 @@
+package constants
+
+// HoldStatus defines the lifecycle of a hold in a book's queue
+type HoldStatus string
+
+const (
+	HoldPending   HoldStatus = "pending"   // waiting in the queue
+	HoldReady     HoldStatus = "ready"     // a copy is set aside for pickup
+	HoldFulfilled HoldStatus = "fulfilled" // the member borrowed the book
+	HoldCancelled HoldStatus = "cancelled"
+	HoldExpired   HoldStatus = "expired" // the pickup window passed
+)
+
+// IsValid reports whether the status is one of the known hold statuses
+func (s HoldStatus) IsValid() bool {
+	switch s {
+	case HoldPending, HoldReady, HoldFulfilled, HoldCancelled, HoldExpired:
+		return true
+	}
+	return false
+}
+
+// IsActive reports whether the hold still holds a place in the queue
+func (s HoldStatus) IsActive() bool {
+	return s == HoldPending || s == HoldReady
+}
@@ -0,0 +1,12 @@
+package constants
+
+// ImportStatus tells what a catalog import did, or would do in a dry run,
+// with a row of the file
+type ImportStatus string
+
+const (
+	ImportCreated       ImportStatus = "created"
+	ImportDuplicateISBN ImportStatus = "duplicate_isbn" // the book is in the catalog already, the row is skipped
+	ImportInvalid       ImportStatus = "invalid"
+	ImportNotImported   ImportStatus = "not_imported" // valid, but left out because the import stopped
+)
@@ -0,0 +1,20 @@
+package constants
+
+// ItemType defines the kinds of items in the catalog. It drives the loan rules.
+type ItemType string
+
+const (
+	ItemBook      ItemType = "book"
+	ItemMagazine  ItemType = "magazine"
+	ItemDVD       ItemType = "dvd"
+	ItemReference ItemType = "reference"
+)
+
+// IsValid reports whether the type is one of the known item types
+func (t ItemType) IsValid() bool {
+	switch t {
+	case ItemBook, ItemMagazine, ItemDVD, ItemReference:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package constants
+
+// Permission is an action a role can be allowed, as "resource:action"
+type Permission string
+
+const (
+	PermUsersRead     Permission = "users:read"
+	PermUsersWrite    Permission = "users:write"
+	PermRolesManage   Permission = "roles:manage"
+	PermBooksWrite    Permission = "books:write"
+	PermBooksExport   Permission = "books:export"
+	PermCopiesRead    Permission = "copies:read"
+	PermCopiesWrite   Permission = "copies:write"
+	PermLoansRead     Permission = "loans:read"
+	PermLoansCheckout Permission = "loans:checkout"
+	PermLoansCheckin  Permission = "loans:checkin"
+	PermFinesRead     Permission = "fines:read"
+	PermFinesManage   Permission = "fines:manage"
+)
+
+// Permissions lists every permission with what it allows, in the order
+// they are shown
+var Permissions = []struct {
+	Permission  Permission
+	Description string
+}{
+	{PermUsersRead, "See users and their details"},
+	{PermUsersWrite, "Create, update and delete users"},
+	{PermRolesManage, "Define roles and the permissions they grant"},
+	{PermBooksWrite, "Edit the catalog: books, authors, categories, tags, covers and imports"},
+	{PermBooksExport, "Export the catalog"},
+	{PermCopiesRead, "Look up copies by barcode"},
+	{PermCopiesWrite, "Add, update and remove copies"},
+	{PermLoansRead, "See every loan and hold queue"},
+	{PermLoansCheckout, "Lend items to members"},
+	{PermLoansCheckin, "Check returned items in"},
+	{PermFinesRead, "See every fine"},
+	{PermFinesManage, "Record fine payments and waive fines"},
+}
+
+// IsValid reports whether the permission is one of the known permissions
+func (p Permission) IsValid() bool {
+	for _, known := range Permissions {
+		if known.Permission == p {
+			return true
+		}
+	}
+	return false
+}
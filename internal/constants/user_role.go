@@ -1,9 +1,25 @@
 package constants
 
-// UserRole defines allowed user roles
+// UserRole names a role. Roles are defined in the database with the
+// permissions they grant; these are the built-in ones the code refers to.
 type UserRole string
 
 const (
-	Admin  UserRole = "admin"
+	// Admin holds every permission, including ones added later
+	Admin UserRole = "admin"
+	// Member is the role of self-registered users
 	Member UserRole = "member"
+	// Librarian and Cataloguer are the staff roles seeded by migration 3.
+	// Unlike the built-in roles, they can be changed or deleted.
+	Librarian  UserRole = "librarian"
+	Cataloguer UserRole = "cataloguer"
 )
+
+// SeededRoles are the roles every database starts with
+var SeededRoles = []UserRole{Admin, Member, Librarian, Cataloguer}
+
+// IsBuiltIn reports whether the role is one the code depends on, which
+// can't be deleted
+func (r UserRole) IsBuiltIn() bool {
+	return r == Admin || r == Member
+}
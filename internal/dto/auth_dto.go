@@ -12,3 +12,44 @@ type UserRegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,password"`
 }
+
+// RefreshRequest represents the input for getting a new access token.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// LogoutRequest represents the input for logging out. The refresh token's
+// session ends; with All, every session of the user does.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	All          bool   `json:"all,omitempty"`
+}
+
+// AuthResponse is returned on registration, login and refresh.
+type AuthResponse struct {
+	// Access token for the Authorization header
+	Token string `json:"token"`
+	// Seconds until the access token expires
+	ExpiresIn int `json:"expires_in"`
+	// Exchanged at /auth/refresh for new tokens; each can be used once
+	RefreshToken string       `json:"refresh_token"`
+	User         UserResponse `json:"user"`
+}
+
+// ForgotPasswordRequest represents the input for asking for a password reset link.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest represents the input for setting a new password with
+// the token of a reset link.
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,password"`
+}
+
+// VerifyEmailRequest represents the input for verifying an email with the
+// token sent to it.
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// AuthorCreateRequest represents the input for author creation.
+type AuthorCreateRequest struct {
+	Name string `json:"name" validate:"required,max=150"`
+	Bio  string `json:"bio,omitempty"`
+}
+
+// AuthorUpdateRequest represents the input for author update.
+type AuthorUpdateRequest struct {
+	Name *string `json:"name,omitempty" validate:"omitempty,min=1,max=150"`
+	Bio  *string `json:"bio,omitempty"`
+}
+
+// AuthorResponse represents the output for author-related endpoints.
+type AuthorResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Bio  string `json:"bio,omitempty"`
+}
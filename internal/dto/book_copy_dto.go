@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// BookCopyCreateRequest represents the input for adding a physical copy of a book.
+type BookCopyCreateRequest struct {
+	Barcode    string     `json:"barcode" validate:"required,max=50"`
+	Location   string     `json:"location" validate:"omitempty,max=100"`
+	Condition  string     `json:"condition" validate:"omitempty,oneof=new good fair poor"`
+	AcquiredAt *time.Time `json:"acquired_at,omitempty"` // defaults to now
+}
+
+// BookCopyUpdateRequest represents the input for updating a copy.
+// Loans and holds move copies in and out of circulation, so the status can
+// only be set to one of the shelf statuses here.
+type BookCopyUpdateRequest struct {
+	Barcode    *string    `json:"barcode,omitempty" validate:"omitempty,min=1,max=50"`
+	Location   *string    `json:"location,omitempty" validate:"omitempty,max=100"`
+	Condition  *string    `json:"condition,omitempty" validate:"omitempty,oneof=new good fair poor"`
+	AcquiredAt *time.Time `json:"acquired_at,omitempty"`
+	Status     *string    `json:"status,omitempty" validate:"omitempty,oneof=available lost damaged in_repair withdrawn"`
+}
+
+// BookCopyResponse represents the output for copy-related endpoints.
+type BookCopyResponse struct {
+	ID         uint          `json:"id"`
+	BookID     uint          `json:"book_id"`
+	Barcode    string        `json:"barcode"`
+	Location   string        `json:"location"`
+	Condition  string        `json:"condition"`
+	AcquiredAt time.Time     `json:"acquired_at"`
+	Status     string        `json:"status"`
+	Book       *BookResponse `json:"book,omitempty"`
+}
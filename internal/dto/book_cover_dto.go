@@ -0,0 +1,18 @@
+package dto
+
+import "io"
+
+// BookCoverResponse holds the URLs of a book's cover image and of its
+// thumbnail. They change with every new cover, so they can be cached.
+type BookCoverResponse struct {
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// CoverFile is a cover image opened for serving. Version identifies the
+// cover the image belongs to. The caller closes Content.
+type CoverFile struct {
+	Content     io.ReadSeekCloser
+	ContentType string
+	Version     string
+}
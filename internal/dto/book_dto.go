@@ -1,23 +1,40 @@
 package dto
 
-import "time"
+import (
+	"library-management/internal/constants"
+	"time"
+)
 
-// BookCreateRequest represents the input for book creation.
+// BookCreateRequest represents the input for book creation. Authors are
+// names in credit order, matched ignoring case and created if they don't
+// exist yet; so are tags. Author is the credit text older clients send
+// instead, split into authors. The ISBN is stored as a bare ISBN-13.
 type BookCreateRequest struct {
-	Title           string    `json:"title" validate:"required"`
-	Author          string    `json:"author" validate:"required"`
-	ISBN            string    `json:"isbn" validate:"required"`
-	CopiesAvailable int       `json:"copies_available" validate:"required,min=0"`
-	PublishedAt     time.Time `json:"published_at" validate:"required"`
+	Title       string    `json:"title" validate:"required"`
+	Authors     []string  `json:"authors" validate:"required_without=Author,omitempty,min=1,dive,required,max=150"`
+	Author      string    `json:"author,omitempty" validate:"excluded_with=Authors"`
+	ISBN        string    `json:"isbn" validate:"required,isbn"`
+	PublishedAt time.Time `json:"published_at" validate:"required"`
+	ItemType    string    `json:"item_type" validate:"omitempty,oneof=book magazine dvd reference"`
+	CategoryIDs []uint    `json:"category_ids,omitempty" validate:"omitempty,dive,gt=0"`
+	Tags        []string  `json:"tags,omitempty" validate:"omitempty,dive,required,max=50"`
+	// Physical copies to register along with the book
+	Copies []BookCopyCreateRequest `json:"copies,omitempty" validate:"omitempty,dive"`
 }
 
-// BookUpdateRequest represents the input for book update.
+// BookUpdateRequest represents the input for book update. Authors,
+// CategoryIDs and Tags replace the book's current ones when set; an empty
+// list removes all categories or tags. Author replaces the authors with
+// the ones in a credit text, as older clients send it.
 type BookUpdateRequest struct {
-	Title           *string    `json:"title,omitempty"`
-	Author          *string    `json:"author,omitempty"`
-	ISBN            *string    `json:"isbn,omitempty"`
-	CopiesAvailable *int       `json:"copies_available,omitempty" validate:"omitempty,min=0"`
-	PublishedAt     *time.Time `json:"published_at,omitempty"`
+	Title       *string    `json:"title,omitempty"`
+	Authors     []string   `json:"authors,omitempty" validate:"omitempty,min=1,dive,required,max=150"`
+	Author      *string    `json:"author,omitempty" validate:"omitempty,excluded_with=Authors"`
+	ISBN        *string    `json:"isbn,omitempty" validate:"omitempty,isbn"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	ItemType    *string    `json:"item_type,omitempty" validate:"omitempty,oneof=book magazine dvd reference"`
+	CategoryIDs []uint     `json:"category_ids,omitempty" validate:"omitempty,dive,gt=0"`
+	Tags        []string   `json:"tags,omitempty" validate:"omitempty,dive,required,max=50"`
 }
 
 // BookResponse represents the output for book-related endpoints.
@@ -28,4 +45,52 @@ type BookResponse struct {
 	ISBN            string    `json:"isbn"`
 	CopiesAvailable int       `json:"copies_available"`
 	PublishedAt     time.Time `json:"published_at"`
+	ItemType        string    `json:"item_type"`
+	// The authors in credit order; "author" has their names joined for display
+	Authors    []AuthorResponse   `json:"authors"`
+	Categories []CategoryResponse `json:"categories"`
+	Tags       []TagResponse      `json:"tags"`
+	// Only set when the copies were loaded, e.g. right after creation
+	Copies []BookCopyResponse `json:"copies,omitempty"`
+	// Only set when the book has a cover image
+	Cover *BookCoverResponse `json:"cover,omitempty"`
+	// Only set for search results
+	Highlights *BookHighlights `json:"highlights,omitempty"`
+}
+
+// BookHighlights holds the fields of a search hit as HTML, with the matched
+// terms wrapped in <mark> tags and everything else escaped.
+type BookHighlights struct {
+	Title  string `json:"title,omitempty"`
+	Author string `json:"author,omitempty"`
+	ISBN   string `json:"isbn,omitempty"`
+}
+
+// BookFilter narrows down and orders book listings. Zero values mean "no filter".
+type BookFilter struct {
+	Query    string // full-text search over title and author
+	Author   string // author name, ignoring case
+	AuthorID uint
+	// CategoryID matches books filed under the category or any of its subcategories
+	CategoryID uint
+	Tag        string // tag name, ignoring case
+	ItemType   constants.ItemType
+	// Published date range, from inclusive and until exclusive
+	PublishedFrom  *time.Time
+	PublishedUntil *time.Time
+	// AvailableOnly restricts the listing to books with a copy on the shelf
+	AvailableOnly bool
+
+	Sort     constants.BookSortField
+	SortDesc bool
+}
+
+// BookFacetValue is one entry of a facet: how many of the matching books
+// have this value, and how many of those are available.
+type BookFacetValue struct {
+	// Set for facets over entities, e.g. the author's ID to filter by
+	ID        uint   `json:"id,omitempty"`
+	Value     string `json:"value"`
+	Count     int64  `json:"count"`
+	Available int64  `json:"available"`
 }
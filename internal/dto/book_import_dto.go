@@ -0,0 +1,35 @@
+package dto
+
+import "library-management/internal/constants"
+
+// BookImportRow is a book read from an import file. Line is its line in a
+// CSV file, or its record number in a MARC file. Err is set when the row
+// couldn't be turned into a request at all, e.g. because of a malformed
+// date; the request is then left incomplete.
+type BookImportRow struct {
+	Line int
+	Book BookCreateRequest
+	Err  error
+}
+
+// BookImportResult reports what the import did with a row of the file
+type BookImportResult struct {
+	Line   int                    `json:"line"`
+	ISBN   string                 `json:"isbn,omitempty"`
+	Title  string                 `json:"title,omitempty"`
+	Status constants.ImportStatus `json:"status"`
+	BookID uint                   `json:"book_id,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+// BookImportReport represents the output of a catalog import. In a dry
+// run, created counts the books that would be created.
+type BookImportReport struct {
+	DryRun      bool               `json:"dry_run"`
+	Total       int                `json:"total"`
+	Created     int                `json:"created"`
+	Duplicates  int                `json:"duplicates"`
+	Invalid     int                `json:"invalid"`
+	NotImported int                `json:"not_imported"`
+	Rows        []BookImportResult `json:"rows"`
+}
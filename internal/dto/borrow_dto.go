@@ -1,11 +1,15 @@
 package dto
 
-import "time"
+import (
+	"library-management/internal/constants"
+	"time"
+)
 
 type BorrowCreateRequest struct {
 	// UserID  uint      `json:"user_id" validate:"required"`
-	BookID  uint      `json:"book_id" validate:"required"`
-	DueDate time.Time `json:"due_date" validate:"required"`
+	BookID uint `json:"book_id" validate:"required"`
+	// Overrides the due date computed by the circulation policy (staff only)
+	DueDate *time.Time `json:"due_date,omitempty"`
 }
 
 type ReturnRequest struct {
@@ -14,10 +18,33 @@ type ReturnRequest struct {
 }
 
 type BorrowResponse struct {
-	ID      uint          `json:"id"`
-	UserID  uint          `json:"user_id,omitempty"`
-	BookID  uint          `json:"book_id,omitempty"`
-	DueDate time.Time     `json:"due_date"`
-	User    *UserResponse `json:"user,omitempty"` // Include user details
-	Book    *BookResponse `json:"book,omitempty"` // Include book details
+	ID         uint       `json:"id"`
+	UserID     uint       `json:"user_id,omitempty"`
+	BookID     uint       `json:"book_id,omitempty"`
+	CopyID     *uint      `json:"copy_id,omitempty"`
+	Barcode    string     `json:"barcode,omitempty"`
+	BorrowedAt time.Time  `json:"borrowed_at"`
+	DueDate    time.Time  `json:"due_date"`
+	Returned   bool       `json:"returned"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+	ReturnedBy *uint      `json:"returned_by,omitempty"`
+	Overdue    bool       `json:"overdue"`
+	// Renewal history of the loan
+	RenewalCount int               `json:"renewal_count"`
+	Renewals     []RenewalResponse `json:"renewals,omitempty"`
+	User         *UserResponse     `json:"user,omitempty"` // Include user details
+	Book         *BookResponse     `json:"book,omitempty"` // Include book details
+}
+
+type RenewalResponse struct {
+	RenewedAt       time.Time `json:"renewed_at"`
+	PreviousDueDate time.Time `json:"previous_due_date"`
+	NewDueDate      time.Time `json:"new_due_date"`
+}
+
+// BorrowFilter narrows down borrow record listings. Zero values mean "no filter".
+type BorrowFilter struct {
+	Status constants.BorrowStatus
+	UserID uint
+	BookID uint
 }
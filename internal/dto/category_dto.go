@@ -0,0 +1,24 @@
+package dto
+
+// CategoryCreateRequest represents the input for category creation.
+// Categories without a parent are top-level subjects.
+type CategoryCreateRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	ParentID *uint  `json:"parent_id,omitempty"`
+}
+
+// CategoryUpdateRequest represents the input for category update. A
+// parent_id of 0 moves the category to the top level.
+type CategoryUpdateRequest struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	ParentID *uint   `json:"parent_id,omitempty"`
+}
+
+// CategoryResponse represents the output for category-related endpoints.
+type CategoryResponse struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	ParentID *uint  `json:"parent_id"`
+	// Only set when listing the category tree
+	Children []CategoryResponse `json:"children,omitempty"`
+}
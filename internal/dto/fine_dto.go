@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"library-management/internal/constants"
+	"time"
+)
+
+// FinePaymentRequest represents the input for recording a payment against a fine.
+type FinePaymentRequest struct {
+	Amount int64  `json:"amount" validate:"required,min=1"` // in cents
+	Note   string `json:"note" validate:"max=255"`
+}
+
+// FineWaiveRequest represents the input for waiving what is left of a fine.
+type FineWaiveRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+// FineResponse represents the output for fine-related endpoints. Amounts are in cents.
+type FineResponse struct {
+	ID           uint                      `json:"id"`
+	UserID       uint                      `json:"user_id"`
+	BorrowID     uint                      `json:"borrow_id"`
+	DaysOverdue  int                       `json:"days_overdue"`
+	Amount       int64                     `json:"amount"`
+	AmountPaid   int64                     `json:"amount_paid"`
+	AmountWaived int64                     `json:"amount_waived"`
+	Balance      int64                     `json:"balance"`
+	Status       string                    `json:"status"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	Transactions []FineTransactionResponse `json:"transactions,omitempty"`
+	User         *UserResponse             `json:"user,omitempty"`
+}
+
+type FineTransactionResponse struct {
+	ID         uint      `json:"id"`
+	Type       string    `json:"type"`
+	Amount     int64     `json:"amount"`
+	RecordedBy uint      `json:"recorded_by"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// FineFilter narrows down fine listings. Zero values mean "no filter".
+type FineFilter struct {
+	Status constants.FineStatus
+	UserID uint
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"library-management/internal/constants"
+	"time"
+)
+
+// HoldCreateRequest represents the input for placing a hold.
+type HoldCreateRequest struct {
+	BookID uint `json:"book_id" validate:"required"`
+}
+
+// HoldResponse represents the output for hold-related endpoints.
+type HoldResponse struct {
+	ID        uint          `json:"id"`
+	UserID    uint          `json:"user_id,omitempty"`
+	BookID    uint          `json:"book_id,omitempty"`
+	Status    string        `json:"status"`
+	CopyID    *uint         `json:"copy_id,omitempty"`  // the copy waiting for pickup
+	Position  int64         `json:"position,omitempty"` // place in the queue while pending
+	PlacedAt  time.Time     `json:"placed_at"`
+	ReadyAt   *time.Time    `json:"ready_at,omitempty"`
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"`
+	ClosedAt  *time.Time    `json:"closed_at,omitempty"`
+	User      *UserResponse `json:"user,omitempty"`
+	Book      *BookResponse `json:"book,omitempty"`
+}
+
+// HoldFilter narrows down hold listings. Zero values mean "no filter".
+type HoldFilter struct {
+	Status constants.HoldStatus
+	UserID uint
+	BookID uint
+	// ActiveOnly restricts the listing to pending and ready holds
+	ActiveOnly bool
+}
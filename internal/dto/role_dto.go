@@ -0,0 +1,30 @@
+package dto
+
+// RoleCreateRequest represents the input for defining a new role.
+type RoleCreateRequest struct {
+	Name        string   `json:"name" validate:"required,max=20"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
+
+// RoleUpdateRequest represents the input for changing a role. The
+// permissions given replace the ones the role had.
+type RoleUpdateRequest struct {
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=255"`
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
+
+// RoleResponse represents the output for role-related endpoints.
+type RoleResponse struct {
+	ID          uint     `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	BuiltIn     bool     `json:"built_in"`
+	Permissions []string `json:"permissions"`
+}
+
+// PermissionResponse describes a permission roles can grant.
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
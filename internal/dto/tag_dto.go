@@ -0,0 +1,20 @@
+package dto
+
+// TagRequest represents the input for creating or renaming a tag.
+type TagRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// TagMergeRequest represents the input for merging duplicate tags into one.
+type TagMergeRequest struct {
+	SourceIDs []uint `json:"source_ids" validate:"required,min=1,dive,gt=0"`
+	TargetID  uint   `json:"target_id" validate:"required"`
+}
+
+// TagResponse represents the output for tag-related endpoints.
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	// Only set when listing tags
+	BookCount *int64 `json:"book_count,omitempty"`
+}
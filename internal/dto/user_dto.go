@@ -7,7 +7,7 @@ type UserCreateRequest struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,password"`
-	Role     string `json:"role" validate:"omitempty,oneof=admin member"`
+	Role     string `json:"role" validate:"omitempty,max=20"`
 }
 
 // UserUpdateRequest represents the input for user update.
@@ -15,13 +15,31 @@ type UserUpdateRequest struct {
 	Name     *string `json:"name,omitempty"`
 	Email    *string `json:"email,omitempty" validate:"email"`
 	Password *string `json:"password,omitempty" validate:"password"`
-	Role     *string `json:"role,omitempty" validate:"oneof=admin member"`
+	Role     *string `json:"role,omitempty" validate:"omitempty,max=20"`
+}
+
+// ProfileUpdateRequest represents the changes users can make to their own
+// account. Changing the email needs the current password.
+type ProfileUpdateRequest struct {
+	Name            *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Email           *string `json:"email,omitempty" validate:"omitempty,email"`
+	CurrentPassword string  `json:"current_password,omitempty" validate:"required_with=Email"`
+}
+
+// PasswordChangeRequest represents the input for users changing their own password.
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,password"`
 }
 
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	// When the user verified their email; left out until they do
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// Until when the user can't log in after too many failed logins
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
 }
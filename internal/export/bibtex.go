@@ -0,0 +1,95 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// bibtexEscaper escapes the characters LaTeX gives a meaning to
+var bibtexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// bibtexWriter writes books as BibTeX entries
+type bibtexWriter struct {
+	w io.Writer
+}
+
+func newBibTeXWriter(w io.Writer) *bibtexWriter {
+	return &bibtexWriter{w: w}
+}
+
+func (bw *bibtexWriter) Write(book *models.Book) error {
+	// BibTeX has no entry types for magazines or videos
+	entryType := "book"
+	if itemType := constants.ItemType(book.ItemType); itemType == constants.ItemMagazine || itemType == constants.ItemDVD {
+		entryType = "misc"
+	}
+
+	var entry strings.Builder
+	fmt.Fprintf(&entry, "@%s{%s,\n", entryType, bibtexKey(book))
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&entry, "  %s = {%s},\n", name, bibtexEscaper.Replace(value))
+		}
+	}
+	field("author", strings.Join(authorNames(book), " and "))
+	field("title", book.Title)
+	if !book.PublishedAt.IsZero() {
+		field("year", strconv.Itoa(book.PublishedAt.Year()))
+	}
+	field("isbn", book.ISBN)
+	tags := make([]string, len(book.Tags))
+	for i, tag := range book.Tags {
+		tags[i] = tag.Name
+	}
+	field("keywords", strings.Join(tags, ", "))
+	entry.WriteString("}\n\n")
+
+	_, err := io.WriteString(bw.w, entry.String())
+	return err
+}
+
+func (bw *bibtexWriter) Close() error {
+	return nil
+}
+
+// bibtexKey returns the citation key of a book: the first author's surname
+// and the year, e.g. "donovan2015", then the book ID to keep keys unique and
+// the same from one export to the next
+func bibtexKey(book *models.Book) string {
+	var key strings.Builder
+	if names := authorNames(book); len(names) > 0 {
+		surname := names[0][strings.LastIndex(names[0], " ")+1:]
+		// Keys are kept to ASCII letters, "Márquez" becomes "marquez"
+		for _, r := range norm.NFD.String(strings.ToLower(surname)) {
+			if r < unicode.MaxASCII && unicode.IsLetter(r) {
+				key.WriteRune(r)
+			}
+		}
+	}
+	if key.Len() == 0 {
+		key.WriteString("book")
+	}
+	if !book.PublishedAt.IsZero() {
+		key.WriteString(strconv.Itoa(book.PublishedAt.Year()))
+	}
+	fmt.Fprintf(&key, "-%d", book.ID)
+	return key.String()
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"library-management/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvColumns are the columns of a CSV export. They are the ones a CSV
+// import reads, so an exported file can be imported into another catalog.
+var csvColumns = []string{"title", "authors", "isbn", "published_at", "item_type", "category_ids", "tags"}
+
+// csvWriter writes books as the rows of a CSV file
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(csvColumns); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(book *models.Book) error {
+	categoryIDs := make([]string, len(book.Categories))
+	for i, category := range book.Categories {
+		categoryIDs[i] = strconv.FormatUint(uint64(category.ID), 10)
+	}
+	tags := make([]string, len(book.Tags))
+	for i, tag := range book.Tags {
+		tags[i] = tag.Name
+	}
+
+	// Lists are separated by semicolons, as author names can have commas
+	return cw.w.Write([]string{
+		book.Title,
+		strings.Join(authorNames(book), "; "),
+		book.ISBN,
+		book.PublishedAt.Format(time.DateOnly),
+		book.ItemType,
+		strings.Join(categoryIDs, ";"),
+		strings.Join(tags, "; "),
+	})
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
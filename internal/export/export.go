@@ -0,0 +1,71 @@
+// Package export writes books to files other tools can read: spreadsheets,
+// other library systems and reference managers. Books are written one at a
+// time, so an export never has to hold the whole catalog.
+package export
+
+import (
+	"io"
+	"library-management/internal/constants"
+	"library-management/internal/marc"
+	"library-management/internal/models"
+	"strings"
+)
+
+// Writer writes books to an export file. Close finishes the file but
+// doesn't close the underlying writer.
+type Writer interface {
+	Write(book *models.Book) error
+	Close() error
+}
+
+// NewWriter returns a Writer of books in the format
+func NewWriter(format constants.ExportFormat, w io.Writer) (Writer, error) {
+	switch format {
+	case constants.ExportCSV:
+		return newCSVWriter(w)
+	case constants.ExportJSONL:
+		return newJSONLWriter(w), nil
+	case constants.ExportBibTeX:
+		return newBibTeXWriter(w), nil
+	case constants.ExportRIS:
+		return newRISWriter(w), nil
+	case constants.ExportMARC:
+		return &marcWriter{records: marc.NewBinaryWriter(w)}, nil
+	case constants.ExportMARCXML:
+		return &marcWriter{records: marc.NewXMLWriter(w)}, nil
+	}
+	return nil, constants.ErrInvalidExportFormat
+}
+
+// marcWriter writes books as MARC 21 bibliographic records
+type marcWriter struct {
+	records marc.Writer
+}
+
+func (mw *marcWriter) Write(book *models.Book) error {
+	return mw.records.Write(marc.RecordFromBook(book))
+}
+
+func (mw *marcWriter) Close() error {
+	return mw.records.Close()
+}
+
+// authorNames returns the names of the book's authors in credit order
+func authorNames(book *models.Book) []string {
+	names := make([]string, len(book.BookAuthors))
+	for i, bookAuthor := range book.BookAuthors {
+		names[i] = bookAuthor.Author.Name
+	}
+	return names
+}
+
+// invertName puts the surname of a name in direct order first, as
+// reference managers expect: "Brian W. Kernighan" becomes "Kernighan, Brian W.".
+// The surname is taken to be the last word.
+func invertName(name string) string {
+	i := strings.LastIndex(name, " ")
+	if i < 0 {
+		return name
+	}
+	return name[i+1:] + ", " + name[:i]
+}
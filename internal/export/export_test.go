@@ -0,0 +1,142 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/utils/mappers"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func testBooks() []models.Book {
+	goBook := models.Book{
+		Title:       "The Go Programming Language",
+		ISBN:        "9780134190440",
+		PublishedAt: time.Date(2015, 10, 26, 0, 0, 0, 0, time.UTC),
+		ItemType:    string(constants.ItemBook),
+		BookAuthors: []models.BookAuthor{
+			{Author: models.Author{Name: "Alan A. A. Donovan"}},
+			{Author: models.Author{Name: "Brian W. Kernighan"}},
+		},
+		Categories: []models.Category{{Model: gorm.Model{ID: 3}}},
+		Tags:       []models.Tag{{Name: "go"}, {Name: "programming"}},
+	}
+	goBook.ID = 1
+
+	novel := models.Book{
+		Title:       "Cien años de soledad & 100% more",
+		ISBN:        "9780307474728",
+		PublishedAt: time.Date(1967, 5, 30, 0, 0, 0, 0, time.UTC),
+		ItemType:    string(constants.ItemDVD),
+		BookAuthors: []models.BookAuthor{{Author: models.Author{Name: "Gabriel García Márquez"}}},
+	}
+	novel.ID = 2
+	return []models.Book{goBook, novel}
+}
+
+func writeBooks(t *testing.T, format constants.ExportFormat) string {
+	var buf bytes.Buffer
+	writer, err := NewWriter(format, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	books := testBooks()
+	for i := range books {
+		if err := writer.Write(&books[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCSVWriter_CanBeImported(t *testing.T) {
+	output := writeBooks(t, constants.ExportCSV)
+
+	rows, err := mappers.MapCSVToBookImportRows(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	first := rows[0].Book
+	if first.Title != "The Go Programming Language" || strings.Join(first.Authors, "|") != "Alan A. A. Donovan|Brian W. Kernighan" ||
+		len(first.CategoryIDs) != 1 || first.CategoryIDs[0] != 3 || len(first.Tags) != 2 || !first.PublishedAt.Equal(time.Date(2015, 10, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected row %+v", first)
+	}
+}
+
+func TestJSONLWriter(t *testing.T) {
+	output := writeBooks(t, constants.ExportJSONL)
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	var book dto.BookResponse
+	if err := json.Unmarshal([]byte(lines[1]), &book); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if book.ID != 2 || len(book.Authors) != 1 || book.Authors[0].Name != "Gabriel García Márquez" {
+		t.Errorf("unexpected book %+v", book)
+	}
+}
+
+func TestBibTeXWriter(t *testing.T) {
+	output := writeBooks(t, constants.ExportBibTeX)
+
+	expected := "@book{donovan2015-1,\n" +
+		"  author = {Alan A. A. Donovan and Brian W. Kernighan},\n" +
+		"  title = {The Go Programming Language},\n" +
+		"  year = {2015},\n" +
+		"  isbn = {9780134190440},\n" +
+		"  keywords = {go, programming},\n" +
+		"}\n\n" +
+		"@misc{marquez1967-2,\n" +
+		"  author = {Gabriel García Márquez},\n" +
+		"  title = {Cien años de soledad \\& 100\\% more},\n" +
+		"  year = {1967},\n" +
+		"  isbn = {9780307474728},\n" +
+		"}\n\n"
+	if output != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output)
+	}
+}
+
+func TestRISWriter(t *testing.T) {
+	output := writeBooks(t, constants.ExportRIS)
+
+	expected := "TY  - BOOK\r\n" +
+		"AU  - Donovan, Alan A. A.\r\n" +
+		"AU  - Kernighan, Brian W.\r\n" +
+		"TI  - The Go Programming Language\r\n" +
+		"PY  - 2015\r\n" +
+		"SN  - 9780134190440\r\n" +
+		"KW  - go\r\n" +
+		"KW  - programming\r\n" +
+		"ID  - 1\r\n" +
+		"ER  - \r\n\r\n"
+	if !strings.HasPrefix(output, expected) {
+		t.Errorf("expected output to start with:\n%s\ngot:\n%s", expected, output)
+	}
+	if !strings.Contains(output, "TY  - VIDEO\r\nAU  - Márquez, Gabriel García\r\n") {
+		t.Errorf("unexpected second reference:\n%s", output)
+	}
+}
+
+func TestNewWriter_InvalidFormat(t *testing.T) {
+	_, err := NewWriter("pdf", &bytes.Buffer{})
+	if !errors.Is(err, constants.ErrInvalidExportFormat) {
+		t.Errorf("expected %v, got %v", constants.ErrInvalidExportFormat, err)
+	}
+}
@@ -0,0 +1,26 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"library-management/internal/models"
+	"library-management/internal/utils/mappers"
+)
+
+// jsonlWriter writes books as JSON Lines, one book per line in the same
+// shape as the API returns it
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	return &jsonlWriter{encoder: json.NewEncoder(w)}
+}
+
+func (jw *jsonlWriter) Write(book *models.Book) error {
+	return jw.encoder.Encode(mappers.MapBookToResponse(book))
+}
+
+func (jw *jsonlWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+	"strconv"
+	"strings"
+)
+
+// risTypes maps item types to RIS reference types
+var risTypes = map[constants.ItemType]string{
+	constants.ItemBook:      "BOOK",
+	constants.ItemMagazine:  "MGZN",
+	constants.ItemDVD:       "VIDEO",
+	constants.ItemReference: "BOOK",
+}
+
+// risWriter writes books as RIS references
+type risWriter struct {
+	w io.Writer
+}
+
+func newRISWriter(w io.Writer) *risWriter {
+	return &risWriter{w: w}
+}
+
+func (rw *risWriter) Write(book *models.Book) error {
+	referenceType, ok := risTypes[constants.ItemType(book.ItemType)]
+	if !ok {
+		referenceType = "GEN"
+	}
+
+	var reference strings.Builder
+	// Tags are two letters, two spaces, a dash and a space; lines end with CRLF
+	line := func(tag, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			fmt.Fprintf(&reference, "%s  - %s\r\n", tag, strings.Join(strings.Fields(value), " "))
+		}
+	}
+	line("TY", referenceType)
+	for _, name := range authorNames(book) {
+		line("AU", invertName(name))
+	}
+	line("TI", book.Title)
+	if !book.PublishedAt.IsZero() {
+		line("PY", strconv.Itoa(book.PublishedAt.Year()))
+	}
+	line("SN", book.ISBN)
+	for _, tag := range book.Tags {
+		line("KW", tag.Name)
+	}
+	line("ID", strconv.FormatUint(uint64(book.ID), 10))
+	reference.WriteString("ER  - \r\n\r\n")
+
+	_, err := io.WriteString(rw.w, reference.String())
+	return err
+}
+
+func (rw *risWriter) Close() error {
+	return nil
+}
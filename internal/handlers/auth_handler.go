@@ -25,12 +25,12 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		handlers.RespondWithError(c, http.StatusBadRequest, err)
 		return
 	}
-	token, user, err := h.Service.Register(req)
+	response, err := h.Service.Register(req)
 	if err != nil {
 		error_handlers.HandleAuthError(c, err)
 		return
 	}
-	handlers.RespondWithSuccess(c, http.StatusCreated, map[string]interface{}{"token": token, "user": user})
+	handlers.RespondWithSuccess(c, http.StatusCreated, response)
 }
 
 // Login User
@@ -42,11 +42,44 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, user, err := h.Service.Login(req)
+	response, err := h.Service.Login(req, c.ClientIP())
 	if err != nil {
 		error_handlers.HandleAuthError(c, err)
 		return
 	}
 
-	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"token": token, "user": user})
+	handlers.RespondWithSuccess(c, http.StatusOK, response)
+}
+
+// Refresh exchanges a refresh token for new tokens
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	response, err := h.Service.Refresh(req)
+	if err != nil {
+		error_handlers.HandleAuthError(c, err)
+		return
+	}
+
+	handlers.RespondWithSuccess(c, http.StatusOK, response)
+}
+
+// Logout ends the session of a refresh token, or all of the user's sessions
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.Service.Logout(req); err != nil {
+		error_handlers.HandleAuthError(c, err)
+		return
+	}
+
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"message": "Logged out successfully"})
 }
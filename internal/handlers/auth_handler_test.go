@@ -36,7 +36,7 @@ func TestRegisterHandler(t *testing.T) {
 	}
 
 	// Mock the service call
-	mockService.On("Register", mock.Anything).Return("mockToken", expectedUser, nil)
+	mockService.On("Register", mock.Anything).Return(dto.AuthResponse{Token: "mockToken", RefreshToken: "mockRefreshToken", User: expectedUser}, nil)
 	handler.Register(c)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
@@ -54,7 +54,7 @@ func TestRegisterHandler_EmailTaken(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
 
-	mockService.On("Register", mock.Anything).Return("", dto.UserResponse{}, constants.ErrEmailTaken)
+	mockService.On("Register", mock.Anything).Return(dto.AuthResponse{}, constants.ErrEmailTaken)
 	handler.Register(c)
 
 	assert.Equal(t, http.StatusConflict, w.Code)
@@ -80,7 +80,7 @@ func TestLoginHandler(t *testing.T) {
 		Role:  "member",
 	}
 
-	mockService.On("Login", mock.Anything).Return("mockToken", expectedUser, nil)
+	mockService.On("Login", mock.Anything, mock.Anything).Return(dto.AuthResponse{Token: "mockToken", RefreshToken: "mockRefreshToken", User: expectedUser}, nil)
 	handler.Login(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -98,9 +98,27 @@ func TestLoginHandler_InvalidCredentials(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
 
-	mockService.On("Login", mock.Anything).Return("", dto.UserResponse{}, constants.ErrInvalidCredentials)
+	mockService.On("Login", mock.Anything, mock.Anything).Return(dto.AuthResponse{}, constants.ErrInvalidCredentials)
 	handler.Login(c)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	mockService.AssertExpectations(t)
 }
+
+func TestRefreshHandler_InvalidToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(mocks.AuthServiceInterface)
+	handler := handlers.NewAuthHandler(mockService)
+	reqBody := `{"refresh_token": "used"}`
+	req := httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	mockService.On("Refresh", dto.RefreshRequest{RefreshToken: "used"}).Return(dto.AuthResponse{}, constants.ErrInvalidRefreshToken)
+	handler.Refresh(c)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthorHandler struct {
+	Service services.AuthorServiceInterface
+}
+
+func NewAuthorHandler(service services.AuthorServiceInterface) *AuthorHandler {
+	return &AuthorHandler{Service: service}
+}
+
+// Create a new author
+func (h *AuthorHandler) CreateAuthor(c *gin.Context) {
+	var req dto.AuthorCreateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	createdAuthor, err := h.Service.CreateAuthor(req)
+	if err != nil {
+		error_handlers.HandleAuthorError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusCreated, createdAuthor)
+}
+
+// Get all authors, optionally filtered by name with the "name" query parameter
+func (h *AuthorHandler) GetAllAuthors(c *gin.Context) {
+	// Default values
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	// Fetch paginated authors
+	authors, total, err := h.Service.GetAllAuthors(c.Query("name"), page, limit)
+	if err != nil {
+		error_handlers.HandleAuthorError(c, err)
+		return
+	}
+
+	// Respond with pagination metadata
+	response := map[string]interface{}{
+		"rows":  authors,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+
+	handlers.RespondWithSuccess(c, http.StatusOK, response)
+}
+
+// Get Author by ID
+func (h *AuthorHandler) GetAuthor(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidAuthorID)
+		return
+	}
+
+	author, err := h.Service.GetAuthor(uint(id))
+	if err != nil {
+		error_handlers.HandleAuthorError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, author)
+}
+
+// Get the books of an author
+func (h *AuthorHandler) GetAuthorBooks(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidAuthorID)
+		return
+	}
+
+	// Default values
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	// Fetch paginated books
+	books, total, err := h.Service.GetAuthorBooks(uint(id), page, limit)
+	if err != nil {
+		error_handlers.HandleAuthorError(c, err)
+		return
+	}
+
+	// Respond with pagination metadata
+	response := map[string]interface{}{
+		"rows":  books,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+
+	handlers.RespondWithSuccess(c, http.StatusOK, response)
+}
+
+// Update Author
+func (h *AuthorHandler) UpdateAuthor(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidAuthorID)
+		return
+	}
+
+	var req dto.AuthorUpdateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	author, err := h.Service.UpdateAuthor(uint(id), req)
+	if err != nil {
+		error_handlers.HandleAuthorError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, author)
+}
+
+// Delete Author
+func (h *AuthorHandler) DeleteAuthor(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidAuthorID)
+		return
+	}
+
+	err = h.Service.DeleteAuthor(uint(id))
+	if err != nil {
+		error_handlers.HandleAuthorError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": id})
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookCopyHandler struct {
+	Service services.BookCopyServiceInterface
+}
+
+func NewBookCopyHandler(service services.BookCopyServiceInterface) *BookCopyHandler {
+	return &BookCopyHandler{Service: service}
+}
+
+// AddCopy registers a new physical copy of a book
+func (h *BookCopyHandler) AddCopy(c *gin.Context) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidBookID)
+		return
+	}
+
+	var req dto.BookCopyCreateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	bookCopy, err := h.Service.AddCopy(uint(bookID), req)
+	if err != nil {
+		error_handlers.HandleBookCopyError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusCreated, bookCopy)
+}
+
+// GetBookCopies lists the copies of a book
+func (h *BookCopyHandler) GetBookCopies(c *gin.Context) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidBookID)
+		return
+	}
+
+	copies, err := h.Service.GetBookCopies(uint(bookID))
+	if err != nil {
+		error_handlers.HandleBookCopyError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, copies)
+}
+
+// GetCopyByBarcode looks up a copy by the barcode on its label
+func (h *BookCopyHandler) GetCopyByBarcode(c *gin.Context) {
+	bookCopy, err := h.Service.GetCopyByBarcode(c.Param("barcode"))
+	if err != nil {
+		error_handlers.HandleBookCopyError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, bookCopy)
+}
+
+// UpdateCopy updates a copy's details or shelf status
+func (h *BookCopyHandler) UpdateCopy(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidCopyID)
+		return
+	}
+
+	var req dto.BookCopyUpdateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	bookCopy, err := h.Service.UpdateCopy(uint(id), req)
+	if err != nil {
+		error_handlers.HandleBookCopyError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, bookCopy)
+}
+
+// DeleteCopy removes a copy
+func (h *BookCopyHandler) DeleteCopy(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidCopyID)
+		return
+	}
+
+	err = h.Service.DeleteCopy(uint(id))
+	if err != nil {
+		error_handlers.HandleBookCopyError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": id})
+}
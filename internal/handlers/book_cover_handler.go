@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCoverSize caps the size of cover images
+const maxCoverSize = 5 << 20
+
+// maxCoverFormOverhead leaves room in upload requests for the multipart
+// headers and boundaries around the image
+const maxCoverFormOverhead = 64 << 10
+
+// Covers requested with their current version can be cached for good, as a
+// new cover gets a new version. Others have to be revalidated.
+const (
+	versionedCoverCacheControl = "public, max-age=31536000, immutable"
+	coverCacheControl          = "public, no-cache"
+)
+
+type BookCoverHandler struct {
+	Service services.BookCoverServiceInterface
+}
+
+func NewBookCoverHandler(service services.BookCoverServiceInterface) *BookCoverHandler {
+	return &BookCoverHandler{Service: service}
+}
+
+// UploadCover sets the cover of a book from a JPEG, PNG or GIF image sent
+// in the "file" form field
+func (h *BookCoverHandler) UploadCover(c *gin.Context) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidBookID)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxCoverSize+maxCoverFormOverhead)
+	fileHeader, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) || (err == nil && fileHeader.Size > maxCoverSize) {
+		error_handlers.HandleBookCoverError(c, constants.ErrCoverTooLarge)
+		return
+	}
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrCoverFileRequired)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		error_handlers.HandleBookCoverError(c, err)
+		return
+	}
+	defer file.Close()
+
+	book, err := h.Service.UploadCover(uint(bookID), file)
+	if err != nil {
+		error_handlers.HandleBookCoverError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, book)
+}
+
+// GetCover serves the cover image of a book
+func (h *BookCoverHandler) GetCover(c *gin.Context) {
+	h.serveCover(c, false)
+}
+
+// GetCoverThumbnail serves the thumbnail of a book's cover
+func (h *BookCoverHandler) GetCoverThumbnail(c *gin.Context) {
+	h.serveCover(c, true)
+}
+
+// DeleteCover removes the cover of a book
+func (h *BookCoverHandler) DeleteCover(c *gin.Context) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidBookID)
+		return
+	}
+
+	if err := h.Service.DeleteCover(uint(bookID)); err != nil {
+		error_handlers.HandleBookCoverError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": bookID})
+}
+
+// serveCover writes a cover image with its caching headers. Conditional
+// requests are answered with 304 Not Modified when the image is unchanged.
+func (h *BookCoverHandler) serveCover(c *gin.Context, thumbnail bool) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidBookID)
+		return
+	}
+
+	file, err := h.Service.OpenCover(uint(bookID), thumbnail)
+	if err != nil {
+		error_handlers.HandleBookCoverError(c, err)
+		return
+	}
+	defer file.Content.Close()
+
+	etag := file.Version
+	if thumbnail {
+		etag += "-thumbnail"
+	}
+	c.Header("ETag", `"`+etag+`"`)
+	if c.Query("v") == file.Version {
+		c.Header("Cache-Control", versionedCoverCacheControl)
+	} else {
+		c.Header("Cache-Control", coverCacheControl)
+	}
+	c.Header("Content-Type", file.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, "", time.Time{}, file.Content)
+}
@@ -1,17 +1,38 @@
 package handlers
 
 import (
+	"errors"
 	"library-management/internal/constants"
 	"library-management/internal/dto"
 	"library-management/internal/services"
 	"library-management/internal/utils/error_handlers"
 	"library-management/internal/utils/handlers"
+	"library-management/internal/utils/mappers"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// maxImportFileSize caps the size of catalog import files
+const maxImportFileSize = 10 << 20
+
+// exportContentTypes gives the media type and file extension of each export format
+var exportContentTypes = map[constants.ExportFormat]struct {
+	mediaType string
+	extension string
+}{
+	constants.ExportCSV:     {mediaType: "text/csv; charset=utf-8", extension: "csv"},
+	constants.ExportJSONL:   {mediaType: "application/jsonl; charset=utf-8", extension: "jsonl"},
+	constants.ExportBibTeX:  {mediaType: "application/x-bibtex; charset=utf-8", extension: "bib"},
+	constants.ExportRIS:     {mediaType: "application/x-research-info-systems", extension: "ris"},
+	constants.ExportMARC:    {mediaType: "application/marc", extension: "mrc"},
+	constants.ExportMARCXML: {mediaType: "application/marcxml+xml", extension: "xml"},
+}
+
 type BookHandler struct {
 	Service services.BookServiceInterface
 }
@@ -36,25 +57,44 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 	handlers.RespondWithSuccess(c, http.StatusCreated, createdBook)
 }
 
-// Get all books
+// Get all books, or search them with the "q" query parameter
 func (h *BookHandler) GetAllBooks(c *gin.Context) {
 	// Default values
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	// Fetch paginated books
-	books, total, err := h.Service.GetAllBooks(page, limit, nil)
+	filter, err := parseBookFilter(c)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Fetch paginated books, ranked by relevance when searching
+	var books []dto.BookResponse
+	var total int64
+	if filter.Query != "" {
+		books, total, err = h.Service.SearchBooks(filter, page, limit)
+	} else {
+		books, total, err = h.Service.GetAllBooks(filter, page, limit, nil)
+	}
+	if err != nil {
+		error_handlers.HandleBookError(c, err)
+		return
+	}
+
+	facets, err := h.Service.GetBookFacets(filter)
 	if err != nil {
 		error_handlers.HandleBookError(c, err)
 		return
 	}
 
-	// Respond with pagination metadata
+	// Respond with pagination metadata and the facet counts
 	response := map[string]interface{}{
-		"rows":  books,
-		"total": total,
-		"page":  page,
-		"limit": limit,
+		"rows":   books,
+		"total":  total,
+		"page":   page,
+		"limit":  limit,
+		"facets": facets,
 	}
 
 	handlers.RespondWithSuccess(c, http.StatusOK, response)
@@ -116,3 +156,137 @@ func (h *BookHandler) DeleteBook(c *gin.Context) {
 	}
 	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": id})
 }
+
+// Import books from a CSV or MARC file, or with dry_run=true only report
+// what importing it would do
+func (h *BookHandler) ImportBooks(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+	format := constants.ImportFormat(c.DefaultQuery("format", string(constants.ImportCSV)))
+	if !format.IsValid() {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidImportFormat)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	fileHeader, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		handlers.RespondWithError(c, http.StatusRequestEntityTooLarge, constants.ErrImportFileTooLarge)
+		return
+	}
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrImportFileRequired)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		error_handlers.HandleBookError(c, err)
+		return
+	}
+	defer file.Close()
+
+	var rows []dto.BookImportRow
+	if format == constants.ImportMARC {
+		rows, err = mappers.MapMARCToBookImportRows(file)
+	} else {
+		rows, err = mappers.MapCSVToBookImportRows(file)
+	}
+	if err != nil {
+		error_handlers.HandleBookError(c, err)
+		return
+	}
+
+	report, err := h.Service.ImportBooks(rows, dryRun)
+	if err != nil {
+		error_handlers.HandleBookError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, report)
+}
+
+// Export the books matching the listing filters as a file
+func (h *BookHandler) ExportBooks(c *gin.Context) {
+	format := constants.ExportFormat(c.Query("format"))
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidExportFormat)
+		return
+	}
+	filter, err := parseBookFilter(c)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.Header("Content-Type", contentType.mediaType)
+	c.Header("Content-Disposition", `attachment; filename="catalog.`+contentType.extension+`"`)
+	err = h.Service.ExportBooks(filter, format, c.Writer)
+	if err == nil {
+		return
+	}
+	// Once the file has started, all that can be done is to cut it short
+	if c.Writer.Written() {
+		log.Printf("⚠️ Catalog export failed: %v", err)
+		c.Abort()
+		return
+	}
+	c.Header("Content-Type", "")
+	c.Header("Content-Disposition", "")
+	error_handlers.HandleBookError(c, err)
+}
+
+// parseBookFilter reads the optional filter and sort query parameters of book listings
+func parseBookFilter(c *gin.Context) (dto.BookFilter, error) {
+	filter := dto.BookFilter{
+		Query:    strings.TrimSpace(c.Query("q")),
+		Author:   strings.TrimSpace(c.Query("author")),
+		Tag:      strings.TrimSpace(c.Query("tag")),
+		ItemType: constants.ItemType(c.Query("item_type")),
+	}
+	if filter.ItemType != "" && !filter.ItemType.IsValid() {
+		return dto.BookFilter{}, constants.ErrInvalidItemType
+	}
+	if authorID := c.Query("author_id"); authorID != "" {
+		id, err := strconv.ParseUint(authorID, 10, 0)
+		if err != nil {
+			return dto.BookFilter{}, constants.ErrInvalidAuthorID
+		}
+		filter.AuthorID = uint(id)
+	}
+	if categoryID := c.Query("category_id"); categoryID != "" {
+		id, err := strconv.ParseUint(categoryID, 10, 0)
+		if err != nil {
+			return dto.BookFilter{}, constants.ErrInvalidCategoryID
+		}
+		filter.CategoryID = uint(id)
+	}
+	filter.AvailableOnly, _ = strconv.ParseBool(c.Query("available"))
+
+	// Both ends of the date range are inclusive
+	if from := c.Query("published_from"); from != "" {
+		date, err := time.Parse(time.DateOnly, from)
+		if err != nil {
+			return dto.BookFilter{}, constants.ErrInvalidDate
+		}
+		filter.PublishedFrom = &date
+	}
+	if to := c.Query("published_to"); to != "" {
+		date, err := time.Parse(time.DateOnly, to)
+		if err != nil {
+			return dto.BookFilter{}, constants.ErrInvalidDate
+		}
+		until := date.AddDate(0, 0, 1)
+		filter.PublishedUntil = &until
+	}
+
+	// e.g. "title" or "-published_at" for descending order
+	if sort := c.Query("sort"); sort != "" {
+		field := strings.TrimPrefix(sort, "-")
+		filter.Sort = constants.BookSortField(field)
+		filter.SortDesc = field != sort
+		if !filter.Sort.IsValid() || (filter.Sort == constants.SortByRelevance && filter.Query == "") {
+			return dto.BookFilter{}, constants.ErrInvalidSort
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookLookupHandler struct {
+	Service services.BookLookupServiceInterface
+}
+
+func NewBookLookupHandler(service services.BookLookupServiceInterface) *BookLookupHandler {
+	return &BookLookupHandler{Service: service}
+}
+
+// LookupBook fills in a book creation request from the bibliographic
+// catalog, given the book's ISBN
+func (h *BookLookupHandler) LookupBook(c *gin.Context) {
+	req, err := h.Service.LookupISBN(c.Request.Context(), c.Query("isbn"))
+	if err != nil {
+		if errors.Is(err, constants.ErrMetadataUnavailable) {
+			log.Printf("⚠️ Book lookup failed: %v", err)
+		}
+		error_handlers.HandleBookLookupError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, req)
+}
@@ -28,13 +28,16 @@ func (h *BorrowHandler) BorrowBook(c *gin.Context) {
 	// Ensure userID is valid
 	userIDUint := userID.(uint)
 
+	// The role decides the loan terms
+	role := c.GetString("role")
+
 	var req dto.BorrowCreateRequest
 	if err := handlers.BindAndValidate(c, &req); err != nil {
 		handlers.RespondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
-	err := h.Service.BorrowBook(req, userIDUint)
+	err := h.Service.BorrowBook(req, userIDUint, role)
 	if err != nil {
 		error_handlers.HandleBorrowError(c, err)
 		return
@@ -64,12 +67,100 @@ func (h *BorrowHandler) ReturnBook(c *gin.Context) {
 	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"message": "Book returned successfully"})
 }
 
-// GetBorrowRecords retrieves all borrow records with pagination
+// CheckOut lends a book to the user in the path, for staff at the desk
+func (h *BorrowHandler) CheckOut(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidUserID)
+		return
+	}
+
+	var req dto.BorrowCreateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Service.CheckOut(req, uint(userID))
+	if err != nil {
+		error_handlers.HandleBorrowError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusCreated, map[string]interface{}{"message": "Book checked out successfully"})
+}
+
+// CheckIn closes any user's loan, for staff taking an item back at the desk
+func (h *BorrowHandler) CheckIn(c *gin.Context) {
+	// Get the staff member's ID from JWT token
+	staffID, _ := c.Get("user_id")
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidBorrowID)
+		return
+	}
+
+	err = h.Service.CheckIn(uint(id), staffID.(uint))
+	if err != nil {
+		error_handlers.HandleBorrowError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"message": "Book checked in successfully"})
+}
+
+// RenewBorrow extends the due date of one of the logged-in user's loans
+func (h *BorrowHandler) RenewBorrow(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	// Ensure userID is valid
+	userIDUint := userID.(uint)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidBorrowID)
+		return
+	}
+
+	borrow, err := h.Service.RenewBorrow(uint(id), userIDUint)
+	if err != nil {
+		error_handlers.HandleBorrowError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, borrow)
+}
+
+// GetBorrowRecords retrieves the loan history of all users with pagination.
+// It can be narrowed down by status, user_id and book_id.
 func (h *BorrowHandler) GetBorrowRecords(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	records, total, err := h.Service.GetBorrowRecords(page, limit)
+	status, err := parseBorrowStatus(c)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	filter := dto.BorrowFilter{Status: status}
+
+	if userIDParam := c.Query("user_id"); userIDParam != "" {
+		userID, err := strconv.Atoi(userIDParam)
+		if err != nil {
+			handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidUserID)
+			return
+		}
+		filter.UserID = uint(userID)
+	}
+	if bookIDParam := c.Query("book_id"); bookIDParam != "" {
+		bookID, err := strconv.Atoi(bookIDParam)
+		if err != nil {
+			handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidBookID)
+			return
+		}
+		filter.BookID = uint(bookID)
+	}
+
+	records, total, err := h.Service.GetBorrowRecords(filter, page, limit)
 	if err != nil {
 		error_handlers.HandleBorrowError(c, err)
 		return
@@ -96,7 +187,13 @@ func (h *BorrowHandler) GetUserBorrows(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	borrows, total, err := h.Service.GetUserBorrows(uint(userID), page, limit)
+	status, err := parseBorrowStatus(c)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	borrows, total, err := h.Service.GetUserBorrows(uint(userID), status, page, limit)
 	if err != nil {
 		error_handlers.HandleBorrowError(c, err)
 		return
@@ -124,8 +221,14 @@ func (h *BorrowHandler) GetMyBorrows(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
+	status, err := parseBorrowStatus(c)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
 	// Fetch user's borrow records
-	borrows, total, err := h.Service.GetUserBorrows(userIDUint, page, limit)
+	borrows, total, err := h.Service.GetUserBorrows(userIDUint, status, page, limit)
 	if err != nil {
 		error_handlers.HandleBorrowError(c, err)
 		return
@@ -140,3 +243,12 @@ func (h *BorrowHandler) GetMyBorrows(c *gin.Context) {
 	}
 	handlers.RespondWithSuccess(c, http.StatusOK, response)
 }
+
+// parseBorrowStatus reads the optional "status" query parameter
+func parseBorrowStatus(c *gin.Context) (constants.BorrowStatus, error) {
+	status := constants.BorrowStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		return "", constants.ErrInvalidStatus
+	}
+	return status, nil
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandler struct {
+	Service services.CategoryServiceInterface
+}
+
+func NewCategoryHandler(service services.CategoryServiceInterface) *CategoryHandler {
+	return &CategoryHandler{Service: service}
+}
+
+// Create a new category
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req dto.CategoryCreateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	createdCategory, err := h.Service.CreateCategory(req)
+	if err != nil {
+		error_handlers.HandleCategoryError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusCreated, createdCategory)
+}
+
+// Get the whole category tree
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	categories, err := h.Service.GetCategoryTree()
+	if err != nil {
+		error_handlers.HandleCategoryError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, categories)
+}
+
+// Get Category by ID, with its subcategories
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidCategoryID)
+		return
+	}
+
+	category, err := h.Service.GetCategory(uint(id))
+	if err != nil {
+		error_handlers.HandleCategoryError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, category)
+}
+
+// Update Category
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidCategoryID)
+		return
+	}
+
+	var req dto.CategoryUpdateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	category, err := h.Service.UpdateCategory(uint(id), req)
+	if err != nil {
+		error_handlers.HandleCategoryError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, category)
+}
+
+// Delete Category
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidCategoryID)
+		return
+	}
+
+	err = h.Service.DeleteCategory(uint(id))
+	if err != nil {
+		error_handlers.HandleCategoryError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": id})
+}
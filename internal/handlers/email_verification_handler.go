@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"library-management/internal/dto"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailVerificationHandler struct {
+	Service services.EmailVerificationServiceInterface
+}
+
+func NewEmailVerificationHandler(service services.EmailVerificationServiceInterface) *EmailVerificationHandler {
+	return &EmailVerificationHandler{Service: service}
+}
+
+// Verify an email with the token sent to it
+func (h *EmailVerificationHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err := h.Service.VerifyEmail(req)
+	if err != nil {
+		error_handlers.HandleAuthError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"message": "Email verified successfully"})
+}
+
+// Send the logged-in user a new verification link
+func (h *EmailVerificationHandler) ResendVerification(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	err := h.Service.SendVerification(userID.(uint))
+	if err != nil {
+		error_handlers.HandleUserError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusAccepted, map[string]interface{}{"message": "Verification email sent"})
+}
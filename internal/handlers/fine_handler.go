@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FineHandler struct {
+	Service services.FineServiceInterface
+}
+
+func NewFineHandler(service services.FineServiceInterface) *FineHandler {
+	return &FineHandler{Service: service}
+}
+
+// GetMyFines retrieves the fines and outstanding balance of the logged-in user
+func (h *FineHandler) GetMyFines(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	// Ensure userID is valid
+	userIDUint := userID.(uint)
+
+	h.respondWithUserFines(c, userIDUint)
+}
+
+// GetUserFines retrieves the fines and outstanding balance of a specific user
+func (h *FineHandler) GetUserFines(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidUserID)
+		return
+	}
+
+	h.respondWithUserFines(c, uint(userID))
+}
+
+// GetFines retrieves the fines of all users, optionally filtered by user_id and status
+func (h *FineHandler) GetFines(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	status, err := parseFineStatus(c)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	filter := dto.FineFilter{Status: status}
+
+	if userIDParam := c.Query("user_id"); userIDParam != "" {
+		userID, err := strconv.Atoi(userIDParam)
+		if err != nil {
+			handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidUserID)
+			return
+		}
+		filter.UserID = uint(userID)
+	}
+
+	fines, total, err := h.Service.GetFines(filter, page, limit)
+	if err != nil {
+		error_handlers.HandleFineError(c, err)
+		return
+	}
+
+	// Respond with pagination metadata
+	response := map[string]interface{}{
+		"rows":  fines,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, response)
+}
+
+// RecordPayment records a payment against a fine
+func (h *FineHandler) RecordPayment(c *gin.Context) {
+	// Get the admin's user ID from JWT token
+	userID, _ := c.Get("user_id")
+	userIDUint := userID.(uint)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidFineID)
+		return
+	}
+
+	var req dto.FinePaymentRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	fine, err := h.Service.RecordPayment(uint(id), userIDUint, req)
+	if err != nil {
+		error_handlers.HandleFineError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, fine)
+}
+
+// WaiveFine waives the outstanding balance of a fine
+func (h *FineHandler) WaiveFine(c *gin.Context) {
+	// Get the admin's user ID from JWT token
+	userID, _ := c.Get("user_id")
+	userIDUint := userID.(uint)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidFineID)
+		return
+	}
+
+	var req dto.FineWaiveRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	fine, err := h.Service.WaiveFine(uint(id), userIDUint, req)
+	if err != nil {
+		error_handlers.HandleFineError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, fine)
+}
+
+func (h *FineHandler) respondWithUserFines(c *gin.Context, userID uint) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	status, err := parseFineStatus(c)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	fines, total, err := h.Service.GetFines(dto.FineFilter{UserID: userID, Status: status}, page, limit)
+	if err != nil {
+		error_handlers.HandleFineError(c, err)
+		return
+	}
+	balance, err := h.Service.GetBalance(userID)
+	if err != nil {
+		error_handlers.HandleFineError(c, err)
+		return
+	}
+
+	// Respond with pagination metadata and the outstanding balance
+	response := map[string]interface{}{
+		"rows":    fines,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+		"balance": balance,
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, response)
+}
+
+// parseFineStatus reads the optional "status" query parameter
+func parseFineStatus(c *gin.Context) (constants.FineStatus, error) {
+	status := constants.FineStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		return "", constants.ErrInvalidFineStatus
+	}
+	return status, nil
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HoldHandler struct {
+	Service services.HoldServiceInterface
+}
+
+func NewHoldHandler(service services.HoldServiceInterface) *HoldHandler {
+	return &HoldHandler{Service: service}
+}
+
+// PlaceHold puts the logged-in user in the queue for a book
+func (h *HoldHandler) PlaceHold(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	// Ensure userID is valid
+	userIDUint := userID.(uint)
+
+	var req dto.HoldCreateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	hold, err := h.Service.PlaceHold(req, userIDUint)
+	if err != nil {
+		error_handlers.HandleHoldError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusCreated, hold)
+}
+
+// GetMyHolds retrieves the holds of the logged-in user
+func (h *HoldHandler) GetMyHolds(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	// Ensure userID is valid
+	userIDUint := userID.(uint)
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	status := constants.HoldStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidHoldStatus)
+		return
+	}
+
+	holds, total, err := h.Service.GetUserHolds(userIDUint, status, page, limit)
+	if err != nil {
+		error_handlers.HandleHoldError(c, err)
+		return
+	}
+
+	// Respond with pagination metadata
+	response := map[string]interface{}{
+		"rows":  holds,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, response)
+}
+
+// GetBookQueue retrieves the hold queue of a book
+func (h *HoldHandler) GetBookQueue(c *gin.Context) {
+	bookID, err := strconv.Atoi(c.Param("book_id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidBookID)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	holds, total, err := h.Service.GetBookQueue(uint(bookID), page, limit)
+	if err != nil {
+		error_handlers.HandleHoldError(c, err)
+		return
+	}
+
+	// Respond with pagination metadata
+	response := map[string]interface{}{
+		"rows":  holds,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, response)
+}
+
+// CancelHold cancels one of the logged-in user's holds
+func (h *HoldHandler) CancelHold(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	// Ensure userID is valid
+	userIDUint := userID.(uint)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidHoldID)
+		return
+	}
+
+	err = h.Service.CancelHold(uint(id), userIDUint)
+	if err != nil {
+		error_handlers.HandleHoldError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": id})
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"library-management/internal/dto"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordResetHandler struct {
+	Service services.PasswordResetServiceInterface
+}
+
+func NewPasswordResetHandler(service services.PasswordResetServiceInterface) *PasswordResetHandler {
+	return &PasswordResetHandler{Service: service}
+}
+
+// Email a password reset link. The response is the same whether or not
+// the email is registered.
+func (h *PasswordResetHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err := h.Service.ForgotPassword(req)
+	if err != nil {
+		error_handlers.HandleAuthError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusAccepted, map[string]interface{}{"message": "If the email is registered, a password reset link has been sent to it"})
+}
+
+// Set a new password with the token of a reset link
+func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err := h.Service.ResetPassword(req)
+	if err != nil {
+		error_handlers.HandleAuthError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"message": "Password reset, please log in"})
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	Service services.RoleServiceInterface
+}
+
+func NewRoleHandler(service services.RoleServiceInterface) *RoleHandler {
+	return &RoleHandler{Service: service}
+}
+
+// Define a new role
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req dto.RoleCreateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	role, err := h.Service.CreateRole(req)
+	if err != nil {
+		error_handlers.HandleRoleError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusCreated, role)
+}
+
+// Get all roles with their permissions
+func (h *RoleHandler) GetAllRoles(c *gin.Context) {
+	roles, err := h.Service.GetAllRoles()
+	if err != nil {
+		error_handlers.HandleRoleError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, roles)
+}
+
+// Get the permissions roles can grant
+func (h *RoleHandler) GetPermissions(c *gin.Context) {
+	handlers.RespondWithSuccess(c, http.StatusOK, h.Service.ListPermissions())
+}
+
+// Get Role by ID
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidRoleID)
+		return
+	}
+
+	role, err := h.Service.GetRole(uint(id))
+	if err != nil {
+		error_handlers.HandleRoleError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, role)
+}
+
+// Update the description and permissions of a role
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidRoleID)
+		return
+	}
+
+	var req dto.RoleUpdateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	role, err := h.Service.UpdateRole(uint(id), req)
+	if err != nil {
+		error_handlers.HandleRoleError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, role)
+}
+
+// Delete Role
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidRoleID)
+		return
+	}
+
+	err = h.Service.DeleteRole(uint(id))
+	if err != nil {
+		error_handlers.HandleRoleError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": id})
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/services"
+	"library-management/internal/utils/error_handlers"
+	"library-management/internal/utils/handlers"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	Service services.TagServiceInterface
+}
+
+func NewTagHandler(service services.TagServiceInterface) *TagHandler {
+	return &TagHandler{Service: service}
+}
+
+// Create a new tag
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req dto.TagRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	createdTag, err := h.Service.CreateTag(req)
+	if err != nil {
+		error_handlers.HandleTagError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusCreated, createdTag)
+}
+
+// Get all tags, optionally filtered by name with the "name" query parameter
+func (h *TagHandler) GetAllTags(c *gin.Context) {
+	// Default values
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	// Fetch paginated tags
+	tags, total, err := h.Service.GetAllTags(c.Query("name"), page, limit)
+	if err != nil {
+		error_handlers.HandleTagError(c, err)
+		return
+	}
+
+	// Respond with pagination metadata
+	response := map[string]interface{}{
+		"rows":  tags,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+
+	handlers.RespondWithSuccess(c, http.StatusOK, response)
+}
+
+// Rename Tag
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidTagID)
+		return
+	}
+
+	var req dto.TagRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tag, err := h.Service.UpdateTag(uint(id), req)
+	if err != nil {
+		error_handlers.HandleTagError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, tag)
+}
+
+// Merge duplicate tags into one
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	var req dto.TagMergeRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tag, err := h.Service.MergeTags(req)
+	if err != nil {
+		error_handlers.HandleTagError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, tag)
+}
+
+// Delete Tag
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidTagID)
+		return
+	}
+
+	err = h.Service.DeleteTag(uint(id))
+	if err != nil {
+		error_handlers.HandleTagError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": id})
+}
@@ -29,7 +29,8 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	createdUser, err := h.Service.CreateUser(req)
+	// The role decides whether the staff member can assign roles
+	createdUser, err := h.Service.CreateUser(req, c.GetString("role"))
 
 	if err != nil {
 		error_handlers.HandleUserError(c, err)
@@ -94,7 +95,8 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.Service.UpdateUser(uint(id), req)
+	// The role decides whether the staff member can assign roles
+	user, err := h.Service.UpdateUser(uint(id), req, c.GetString("role"))
 	if err != nil {
 		error_handlers.HandleUserError(c, err)
 		return
@@ -112,10 +114,94 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	err = h.Service.DeleteUser(uint(id))
+	err = h.Service.DeleteUser(uint(id), c.GetString("role"))
 	if err != nil {
 		error_handlers.HandleUserError(c, err)
 		return
 	}
 	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": id})
 }
+
+// UnlockUser lifts the lockout of a user after too many failed logins
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, constants.ErrInvalidUserID)
+		return
+	}
+
+	// Get staff ID from JWT token
+	staffID, _ := c.Get("user_id")
+
+	user, err := h.Service.UnlockUser(uint(id), staffID.(uint), c.GetString("role"), c.ClientIP())
+	if err != nil {
+		error_handlers.HandleUserError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, user)
+}
+
+// GetMe returns the logged-in user's own account
+func (h *UserHandler) GetMe(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	user, err := h.Service.GetUser(userID.(uint), []string{})
+	if err != nil {
+		error_handlers.HandleUserError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, user)
+}
+
+// UpdateMe changes the name or email of the logged-in user
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	var req dto.ProfileUpdateRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.Service.UpdateProfile(userID.(uint), req)
+	if err != nil {
+		error_handlers.HandleUserError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, user)
+}
+
+// ChangeMyPassword changes the logged-in user's password
+func (h *UserHandler) ChangeMyPassword(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	var req dto.PasswordChangeRequest
+	if err := handlers.BindAndValidate(c, &req); err != nil {
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err := h.Service.ChangePassword(userID.(uint), req)
+	if err != nil {
+		error_handlers.HandleUserError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"message": "Password changed, please log in again"})
+}
+
+// DeleteMe deletes the logged-in user's account
+func (h *UserHandler) DeleteMe(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, _ := c.Get("user_id")
+
+	err := h.Service.DeleteAccount(userID.(uint))
+	if err != nil {
+		error_handlers.HandleUserError(c, err)
+		return
+	}
+	handlers.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{"id": userID})
+}
@@ -0,0 +1,191 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"library-management/internal/constants"
+)
+
+// Structure of an ISO 2709 record: a leader, a directory with one entry per
+// field, then the fields, each one ended by a field terminator
+const (
+	leaderLength         = 24
+	directoryEntryLength = 12
+	maxRecordLength      = 99999
+	maxFieldLength       = 9999
+	fieldTerminator      = 0x1E
+	recordTerminator     = 0x1D
+	subfieldDelimiter    = 0x1F
+)
+
+// ReadBinary reads the records of a MARC 21 file in ISO 2709 format. Records
+// are read as UTF-8; MARC-8 records only come through intact when they are
+// plain ASCII.
+func ReadBinary(r io.Reader) ([]Record, error) {
+	reader := bufio.NewReader(r)
+	var records []Record
+	for n := 1; ; n++ {
+		// Some tools put line breaks between records
+		if err := skipSpace(reader); errors.Is(err, io.EOF) {
+			return records, nil
+		}
+
+		leader := make([]byte, leaderLength)
+		if _, err := io.ReadFull(reader, leader); err != nil {
+			return nil, fmt.Errorf("%w: record %d is cut short", constants.ErrInvalidMARC, n)
+		}
+		length, ok := parseNumber(leader[0:5])
+		if !ok || length <= leaderLength {
+			return nil, fmt.Errorf("%w: record %d has an invalid length", constants.ErrInvalidMARC, n)
+		}
+		data := make([]byte, length)
+		copy(data, leader)
+		if _, err := io.ReadFull(reader, data[leaderLength:]); err != nil {
+			return nil, fmt.Errorf("%w: record %d is cut short", constants.ErrInvalidMARC, n)
+		}
+
+		record, err := parseBinaryRecord(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d %v", constants.ErrInvalidMARC, n, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// parseBinaryRecord splits a record into its fields using its directory
+func parseBinaryRecord(data []byte) (Record, error) {
+	if data[len(data)-1] != recordTerminator {
+		return Record{}, errors.New("doesn't end with a record terminator")
+	}
+	base, ok := parseNumber(data[12:17])
+	if !ok || base <= leaderLength || base > len(data) || data[base-1] != fieldTerminator {
+		return Record{}, errors.New("has an invalid base address")
+	}
+	directory := data[leaderLength : base-1]
+	if len(directory)%directoryEntryLength != 0 {
+		return Record{}, errors.New("has an invalid directory")
+	}
+
+	record := Record{Leader: string(data[:leaderLength])}
+	for i := 0; i < len(directory); i += directoryEntryLength {
+		entry := directory[i : i+directoryEntryLength]
+		tag := string(entry[0:3])
+		length, ok1 := parseNumber(entry[3:7])
+		start, ok2 := parseNumber(entry[7:12])
+		if !ok1 || !ok2 || length < 1 || base+start+length > len(data) {
+			return Record{}, fmt.Errorf("has an invalid directory entry for field %s", tag)
+		}
+		field := bytes.TrimSuffix(data[base+start:base+start+length], []byte{fieldTerminator})
+
+		if isControlTag(tag) {
+			record.ControlFields = append(record.ControlFields, ControlField{Tag: tag, Value: string(field)})
+			continue
+		}
+		if len(field) < 2 {
+			return Record{}, fmt.Errorf("has no indicators in field %s", tag)
+		}
+		dataField := DataField{Tag: tag, Ind1: field[0], Ind2: field[1]}
+		// Anything before the first delimiter isn't a subfield
+		for _, subfield := range bytes.Split(field[2:], []byte{subfieldDelimiter})[1:] {
+			if len(subfield) > 0 {
+				dataField.Subfields = append(dataField.Subfields, Subfield{Code: subfield[0], Value: string(subfield[1:])})
+			}
+		}
+		record.DataFields = append(record.DataFields, dataField)
+	}
+	return record, nil
+}
+
+// parseNumber reads a number of the leader or directory, which is written
+// with digits only
+func parseNumber(digits []byte) (int, bool) {
+	n := 0
+	for _, b := range digits {
+		if b < '0' || b > '9' {
+			return 0, false
+		}
+		n = n*10 + int(b-'0')
+	}
+	return n, len(digits) > 0
+}
+
+// skipSpace advances the reader to the next byte that isn't white space
+func skipSpace(reader *bufio.Reader) error {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != '\n' && b != '\r' && b != ' ' && b != '\t' {
+			return reader.UnreadByte()
+		}
+	}
+}
+
+// binaryWriter writes records in ISO 2709 format
+type binaryWriter struct {
+	w io.Writer
+}
+
+// NewBinaryWriter returns a Writer of MARC 21 records in ISO 2709 format
+func NewBinaryWriter(w io.Writer) Writer {
+	return &binaryWriter{w: w}
+}
+
+func (bw *binaryWriter) Write(record Record) error {
+	var directory, fields bytes.Buffer
+	addField := func(tag string, data []byte) error {
+		if len(data)+1 > maxFieldLength {
+			return fmt.Errorf("%w: field %s", constants.ErrMARCRecordTooLong, tag)
+		}
+		fmt.Fprintf(&directory, "%3.3s%04d%05d", tag, len(data)+1, fields.Len())
+		fields.Write(data)
+		fields.WriteByte(fieldTerminator)
+		return nil
+	}
+
+	for _, field := range record.ControlFields {
+		if err := addField(field.Tag, []byte(field.Value)); err != nil {
+			return err
+		}
+	}
+	for _, field := range record.DataFields {
+		data := []byte{field.Ind1, field.Ind2}
+		for _, subfield := range field.Subfields {
+			data = append(data, subfieldDelimiter, subfield.Code)
+			data = append(data, subfield.Value...)
+		}
+		if err := addField(field.Tag, data); err != nil {
+			return err
+		}
+	}
+	directory.WriteByte(fieldTerminator)
+
+	base := leaderLength + directory.Len()
+	length := base + fields.Len() + 1
+	if length > maxRecordLength {
+		return constants.ErrMARCRecordTooLong
+	}
+
+	leader := []byte(fmt.Sprintf("%-24.24s", record.Leader))
+	copy(leader[0:5], fmt.Sprintf("%05d", length))
+	copy(leader[12:17], fmt.Sprintf("%05d", base))
+	// Indicator and subfield code lengths, and the shape of directory entries
+	leader[10], leader[11] = '2', '2'
+	copy(leader[20:24], "4500")
+
+	buf := make([]byte, 0, length)
+	buf = append(buf, leader...)
+	buf = append(buf, directory.Bytes()...)
+	buf = append(buf, fields.Bytes()...)
+	buf = append(buf, recordTerminator)
+	_, err := bw.w.Write(buf)
+	return err
+}
+
+func (bw *binaryWriter) Close() error {
+	return nil
+}
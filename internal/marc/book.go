@@ -0,0 +1,234 @@
+package marc
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/models"
+	"library-management/internal/utils/validation"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Fields of a bibliographic record that make up a book
+const (
+	tagControlNumber = "001"
+	tagFixedData     = "008"
+	tagISBN          = "020"
+	tagMainAuthor    = "100"
+	tagTitle         = "245"
+	tagPublication   = "260"
+	tagProduction    = "264" // publication when its second indicator is 1
+	tagIndexTerm     = "653"
+	tagAddedAuthor   = "700"
+)
+
+// yearPattern finds the year in a publication date like "c2015." or "[2015]"
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+// BookFromRecord reads the book a bibliographic record describes: its title
+// from 245, its authors from 100 and 700, its ISBN from 020, its publication
+// year from 264 or 260 (or else from 008), its tags from 653 and its item
+// type from the leader. The authors are not looked up, only named. Whatever
+// the record lacks is left empty for validation to report.
+func BookFromRecord(record Record) *models.Book {
+	book := &models.Book{
+		Title:    recordTitle(record),
+		ISBN:     recordISBN(record),
+		ItemType: string(recordItemType(record)),
+	}
+
+	var names []string
+	for _, tag := range []string{tagMainAuthor, tagAddedAuthor} {
+		for _, field := range record.Fields(tag) {
+			if name := personalName(field); name != "" {
+				book.BookAuthors = append(book.BookAuthors, models.BookAuthor{
+					Position: len(book.BookAuthors),
+					Author:   models.Author{Name: name},
+				})
+				names = append(names, name)
+			}
+		}
+	}
+	book.Author = strings.Join(names, ", ")
+
+	if year := recordYear(record); year > 0 {
+		book.PublishedAt = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	for _, field := range record.Fields(tagIndexTerm) {
+		for _, subfield := range field.Subfields {
+			if subfield.Code == 'a' && strings.TrimSpace(subfield.Value) != "" {
+				book.Tags = append(book.Tags, models.Tag{Name: strings.TrimSpace(subfield.Value)})
+			}
+		}
+	}
+	return book
+}
+
+// RecordFromBook describes a book as a bibliographic record. Names are
+// written in direct order, as they are stored, and the publication date is
+// reduced to its year.
+func RecordFromBook(book *models.Book) Record {
+	itemType := constants.ItemType(book.ItemType)
+	record := Record{Leader: recordLeader(itemType)}
+	record.AddControlField(tagControlNumber, strconv.FormatUint(uint64(book.ID), 10))
+	record.AddControlField(tagFixedData, fixedData(book))
+
+	record.AddDataField(tagISBN, ' ', ' ', "a", book.ISBN)
+	for i, bookAuthor := range book.BookAuthors {
+		tag := tagAddedAuthor
+		if i == 0 {
+			tag = tagMainAuthor
+		}
+		record.AddDataField(tag, '0', ' ', "a", bookAuthor.Author.Name)
+	}
+
+	// Only added entries when the book has no main author
+	ind1 := byte('0')
+	if len(book.BookAuthors) > 0 {
+		ind1 = '1'
+	}
+	record.AddDataField(tagTitle, ind1, '0', "a", book.Title)
+	if !book.PublishedAt.IsZero() {
+		record.AddDataField(tagProduction, ' ', '1', "c", strconv.Itoa(book.PublishedAt.Year()))
+	}
+	for _, tag := range book.Tags {
+		record.AddDataField(tagIndexTerm, ' ', ' ', "a", tag.Name)
+	}
+	return record
+}
+
+// recordTitle joins the title proper and the remainder of the title,
+// without the punctuation cataloguers put before the next subfield
+func recordTitle(record Record) string {
+	fields := record.Fields(tagTitle)
+	if len(fields) == 0 {
+		return ""
+	}
+	title := trimPunctuation(fields[0].Subfield('a'))
+	if remainder := trimPunctuation(fields[0].Subfield('b')); remainder != "" {
+		title += ": " + remainder
+	}
+	return title
+}
+
+// recordISBN returns the first valid ISBN of the record as an ISBN-13. A
+// record with only invalid ones keeps the first, for validation to reject.
+func recordISBN(record Record) string {
+	var first string
+	for _, field := range record.Fields(tagISBN) {
+		// e.g. "0134190440 (paperback)"
+		values := strings.Fields(field.Subfield('a'))
+		if len(values) == 0 {
+			continue
+		}
+		if isbn, err := validation.NormalizeISBN(values[0]); err == nil {
+			return isbn
+		}
+		if first == "" {
+			first = values[0]
+		}
+	}
+	return first
+}
+
+// recordYear returns the year the book was published, or 0 if unknown
+func recordYear(record Record) int {
+	var dates []string
+	for _, field := range record.Fields(tagProduction) {
+		if field.Ind2 == '1' {
+			dates = append(dates, field.Subfield('c'))
+		}
+	}
+	for _, field := range record.Fields(tagPublication) {
+		dates = append(dates, field.Subfield('c'))
+	}
+	// Date 1 of the fixed-length data elements
+	if fixed := record.ControlField(tagFixedData); len(fixed) >= 11 {
+		dates = append(dates, fixed[7:11])
+	}
+
+	for _, date := range dates {
+		if year, err := strconv.Atoi(yearPattern.FindString(date)); err == nil && year > 0 {
+			return year
+		}
+	}
+	return 0
+}
+
+// recordItemType tells the item type from the type of record and the
+// bibliographic level in the leader
+func recordItemType(record Record) constants.ItemType {
+	switch {
+	case len(record.Leader) > 6 && record.Leader[6] == 'g':
+		return constants.ItemDVD
+	case len(record.Leader) > 7 && record.Leader[7] == 's':
+		return constants.ItemMagazine
+	}
+	return constants.ItemBook
+}
+
+// recordLeader returns the leader of a new record for the item type. The
+// lengths and base address are filled in when the record is written.
+func recordLeader(itemType constants.ItemType) string {
+	leader := []byte("00000nam a2200000 i 4500")
+	switch itemType {
+	case constants.ItemDVD:
+		leader[6] = 'g'
+	case constants.ItemMagazine:
+		leader[7] = 's'
+	}
+	return string(leader)
+}
+
+// fixedData returns the 008 field of a book: the date the record was
+// entered and the year of publication, with the rest left undefined
+func fixedData(book *models.Book) string {
+	data := []byte(strings.Repeat(" ", 40))
+	entered := book.CreatedAt
+	if entered.IsZero() {
+		entered = time.Now()
+	}
+	copy(data[0:6], entered.Format("060102"))
+	if !book.PublishedAt.IsZero() {
+		data[6] = 's'
+		copy(data[7:11], book.PublishedAt.Format("2006"))
+	}
+	copy(data[35:38], "und")
+	data[39] = 'd'
+	return string(data)
+}
+
+// personalName returns the name in the $a of an author field in direct
+// order: "Kernighan, Brian W." becomes "Brian W. Kernighan"
+func personalName(field DataField) string {
+	name := trimName(field.Subfield('a'))
+	// A first indicator of 1 means the surname comes first
+	if field.Ind1 == '1' {
+		surname, forenames, found := strings.Cut(name, ", ")
+		if found && !strings.Contains(forenames, ",") {
+			name = forenames + " " + surname
+		}
+	}
+	return name
+}
+
+// trimPunctuation removes the punctuation ending a title subfield
+func trimPunctuation(value string) string {
+	return strings.TrimRight(strings.TrimSpace(value), " /:;,=.")
+}
+
+// trimName removes the punctuation ending a name, keeping the period of
+// an initial or of an abbreviation like "Jr."
+func trimName(value string) string {
+	name := strings.TrimRight(strings.TrimSpace(value), " ,")
+	if strings.HasSuffix(name, ".") {
+		lastWord := name[strings.LastIndexAny(name, " ,")+1 : len(name)-1]
+		if utf8.RuneCountInString(lastWord) > 2 {
+			name = strings.TrimSuffix(name, ".")
+		}
+	}
+	return name
+}
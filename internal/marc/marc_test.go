@@ -0,0 +1,223 @@
+package marc
+
+import (
+	"bytes"
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleXML = `<?xml version="1.0" encoding="UTF-8"?>
+<collection xmlns="http://www.loc.gov/MARC21/slim">
+  <record>
+    <leader>01142cam  2200301 i 4500</leader>
+    <controlfield tag="001">  2015950709</controlfield>
+    <controlfield tag="008">150812s2016    nyua     b    001 0 eng d</controlfield>
+    <datafield tag="020" ind1=" " ind2=" ">
+      <subfield code="a">9780134190440 (pbk. : alk. paper)</subfield>
+    </datafield>
+    <datafield tag="100" ind1="1" ind2=" ">
+      <subfield code="a">Donovan, Alan A. A.,</subfield>
+      <subfield code="e">author.</subfield>
+    </datafield>
+    <datafield tag="245" ind1="1" ind2="4">
+      <subfield code="a">The Go programming language /</subfield>
+      <subfield code="c">Alan A.A. Donovan, Brian W. Kernighan.</subfield>
+    </datafield>
+    <datafield tag="264" ind1=" " ind2="1">
+      <subfield code="a">New York :</subfield>
+      <subfield code="b">Addison-Wesley,</subfield>
+      <subfield code="c">[2015]</subfield>
+    </datafield>
+    <datafield tag="653" ind1=" " ind2=" ">
+      <subfield code="a">Go</subfield>
+    </datafield>
+    <datafield tag="700" ind1="1" ind2=" ">
+      <subfield code="a">Kernighan, Brian W.,</subfield>
+      <subfield code="e">author.</subfield>
+    </datafield>
+  </record>
+</collection>`
+
+func TestBookFromRecord(t *testing.T) {
+	records, err := Read(strings.NewReader(sampleXML))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+
+	book := BookFromRecord(records[0])
+
+	if book.Title != "The Go programming language" {
+		t.Errorf("unexpected title %q", book.Title)
+	}
+	if book.ISBN != "9780134190440" {
+		t.Errorf("unexpected isbn %q", book.ISBN)
+	}
+	if book.Author != "Alan A. A. Donovan, Brian W. Kernighan" {
+		t.Errorf("unexpected authors %q", book.Author)
+	}
+	if !book.PublishedAt.Equal(time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected publication date %v", book.PublishedAt)
+	}
+	if book.ItemType != string(constants.ItemBook) {
+		t.Errorf("unexpected item type %q", book.ItemType)
+	}
+	if len(book.Tags) != 1 || book.Tags[0].Name != "Go" {
+		t.Errorf("unexpected tags %+v", book.Tags)
+	}
+}
+
+func TestBookFromRecord_Fallbacks(t *testing.T) {
+	record := Record{Leader: "00000nas a2200000 a 4500"}
+	record.AddControlField("008", "990101c19979999nyumr p       0   a0eng d")
+	record.AddDataField("020", ' ', ' ', "a", "123")
+	record.AddDataField("020", ' ', ' ', "a", "0-13-419044-0")
+	record.AddDataField("245", '0', '0', "a", "Linux journal :", "b", "the premier magazine of the Linux community.")
+	record.AddDataField("260", ' ', ' ', "c", "c1997-")
+
+	book := BookFromRecord(record)
+
+	if book.Title != "Linux journal: the premier magazine of the Linux community" {
+		t.Errorf("unexpected title %q", book.Title)
+	}
+	if book.ISBN != "9780134190440" {
+		t.Errorf("unexpected isbn %q", book.ISBN)
+	}
+	if book.PublishedAt.Year() != 1997 {
+		t.Errorf("unexpected publication date %v", book.PublishedAt)
+	}
+	if book.ItemType != string(constants.ItemMagazine) || len(book.BookAuthors) != 0 {
+		t.Errorf("unexpected item type %q or authors %+v", book.ItemType, book.BookAuthors)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	book := &models.Book{
+		Title:       "Cien años de soledad",
+		ISBN:        "9780307474728",
+		PublishedAt: time.Date(1967, 5, 30, 0, 0, 0, 0, time.UTC),
+		ItemType:    string(constants.ItemBook),
+		BookAuthors: []models.BookAuthor{{Author: models.Author{Name: "Gabriel García Márquez"}}},
+		Tags:        []models.Tag{{Name: "novel"}},
+	}
+	book.ID = 42
+
+	var buf bytes.Buffer
+	writer := NewBinaryWriter(&buf)
+	for i := 0; i < 2; i++ {
+		if err := writer.Write(RecordFromBook(book)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].ControlField("001") != "42" {
+		t.Errorf("unexpected control number %q", records[0].ControlField("001"))
+	}
+
+	read := BookFromRecord(records[1])
+	if read.Title != book.Title || read.ISBN != book.ISBN || read.Author != "Gabriel García Márquez" || read.PublishedAt.Year() != 1967 {
+		t.Errorf("book changed on the way: %+v", read)
+	}
+	if !reflect.DeepEqual(read.Tags, book.Tags) {
+		t.Errorf("expected tags %+v, got %+v", book.Tags, read.Tags)
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	records, err := ReadXML(strings.NewReader(sampleXML))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	writer := NewXMLWriter(&buf)
+	if err := writer.Write(records[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	written, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(written, records) {
+		t.Errorf("expected %+v, got %+v", records, written)
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "Empty", input: "  \n"},
+		{name: "Not a length", input: "hello, this is not a MARC record"},
+		{name: "Cut short", input: "00100nam a2200037 i 4500"},
+		{name: "No records in XML", input: `<collection xmlns="http://www.loc.gov/MARC21/slim"></collection>`},
+		{name: "Broken XML", input: `<collection><record><leader>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tc.input))
+			if !errors.Is(err, constants.ErrInvalidMARC) {
+				t.Errorf("Test case %s failed: expected %v, got %v", tc.name, constants.ErrInvalidMARC, err)
+			}
+		})
+	}
+}
+
+func TestRead_InvalidDirectoryEntry(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewBinaryWriter(&buf)
+	if err := writer.Write(RecordFromBook(&models.Book{Title: "Dune", ItemType: string(constants.ItemBook)})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	record := buf.String()
+
+	// The first directory entry follows the leader: a tag, a length of 4
+	// digits and a start of 5 digits
+	testCases := []struct {
+		name   string
+		offset int
+		value  string
+	}{
+		{name: "Start before the field data", offset: 31, value: "-0099"},
+		{name: "Start in the directory", offset: 31, value: "-0030"},
+		{name: "Signed start", offset: 31, value: "+0000"},
+		{name: "Signed length", offset: 27, value: "+010"},
+		{name: "Spaces in the start", offset: 31, value: "    0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := record[:tc.offset] + tc.value + record[tc.offset+len(tc.value):]
+			_, err := Read(strings.NewReader(input))
+			if !errors.Is(err, constants.ErrInvalidMARC) {
+				t.Errorf("Test case %s failed: expected %v, got %v", tc.name, constants.ErrInvalidMARC, err)
+			}
+		})
+	}
+}
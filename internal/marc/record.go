@@ -0,0 +1,85 @@
+// Package marc reads and writes bibliographic records in MARC 21, both in
+// the binary ISO 2709 exchange format and as MARCXML, and converts them to
+// and from books.
+package marc
+
+// Record is a MARC 21 record: a leader, then control fields (tags 001 to
+// 009) and data fields, in the order they appear in the record
+type Record struct {
+	Leader        string
+	ControlFields []ControlField
+	DataFields    []DataField
+}
+
+// ControlField holds fixed-length data, e.g. the control number in 001
+type ControlField struct {
+	Tag   string
+	Value string
+}
+
+// DataField holds variable data split into subfields, e.g. the title in 245
+type DataField struct {
+	Tag       string
+	Ind1      byte
+	Ind2      byte
+	Subfields []Subfield
+}
+
+// Subfield is a value of a data field, identified by a single character code
+type Subfield struct {
+	Code  byte
+	Value string
+}
+
+// ControlField returns the value of the first control field with the tag
+func (r *Record) ControlField(tag string) string {
+	for _, field := range r.ControlFields {
+		if field.Tag == tag {
+			return field.Value
+		}
+	}
+	return ""
+}
+
+// Fields returns the data fields with the tag
+func (r *Record) Fields(tag string) []DataField {
+	var fields []DataField
+	for _, field := range r.DataFields {
+		if field.Tag == tag {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// AddControlField appends a control field to the record
+func (r *Record) AddControlField(tag, value string) {
+	r.ControlFields = append(r.ControlFields, ControlField{Tag: tag, Value: value})
+}
+
+// AddDataField appends a data field to the record. Subfields are given as
+// code and value pairs, e.g. "a", "Title"; empty values are left out.
+func (r *Record) AddDataField(tag string, ind1, ind2 byte, subfields ...string) {
+	field := DataField{Tag: tag, Ind1: ind1, Ind2: ind2}
+	for i := 0; i+1 < len(subfields); i += 2 {
+		if subfields[i+1] != "" {
+			field.Subfields = append(field.Subfields, Subfield{Code: subfields[i][0], Value: subfields[i+1]})
+		}
+	}
+	r.DataFields = append(r.DataFields, field)
+}
+
+// Subfield returns the value of the first subfield with the code
+func (f DataField) Subfield(code byte) string {
+	for _, subfield := range f.Subfields {
+		if subfield.Code == code {
+			return subfield.Value
+		}
+	}
+	return ""
+}
+
+// isControlTag reports whether fields with the tag are control fields
+func isControlTag(tag string) bool {
+	return len(tag) == 3 && tag[0] == '0' && tag[1] == '0'
+}
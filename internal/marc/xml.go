@@ -0,0 +1,169 @@
+package marc
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"library-management/internal/constants"
+)
+
+// Namespace is the XML namespace of MARCXML
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+type xmlRecord struct {
+	XMLName       xml.Name          `xml:"record"`
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ReadXML reads the records of a MARCXML document, either a collection of
+// records or a single record
+func ReadXML(r io.Reader) ([]Record, error) {
+	decoder := xml.NewDecoder(r)
+	var records []Record
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", constants.ErrInvalidMARC, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		var x xmlRecord
+		if err := decoder.DecodeElement(&x, &start); err != nil {
+			return nil, fmt.Errorf("%w: record %d: %v", constants.ErrInvalidMARC, len(records)+1, err)
+		}
+		records = append(records, fromXMLRecord(x))
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: no records found", constants.ErrInvalidMARC)
+	}
+	return records, nil
+}
+
+// Read reads the records of a MARC 21 file, telling MARCXML from the binary
+// format by its first character
+func Read(r io.Reader) ([]Record, error) {
+	reader := bufio.NewReader(r)
+	if err := skipSpace(reader); err != nil {
+		return nil, fmt.Errorf("%w: no records found", constants.ErrInvalidMARC)
+	}
+	first, _ := reader.Peek(1)
+	// XML documents may start with a byte order mark
+	if first[0] == '<' || first[0] == 0xEF {
+		return ReadXML(reader)
+	}
+	return ReadBinary(reader)
+}
+
+func fromXMLRecord(x xmlRecord) Record {
+	record := Record{Leader: x.Leader}
+	for _, field := range x.ControlFields {
+		record.ControlFields = append(record.ControlFields, ControlField{Tag: field.Tag, Value: field.Value})
+	}
+	for _, field := range x.DataFields {
+		dataField := DataField{Tag: field.Tag, Ind1: indicator(field.Ind1), Ind2: indicator(field.Ind2)}
+		for _, subfield := range field.Subfields {
+			if subfield.Code != "" {
+				dataField.Subfields = append(dataField.Subfields, Subfield{Code: subfield.Code[0], Value: subfield.Value})
+			}
+		}
+		record.DataFields = append(record.DataFields, dataField)
+	}
+	return record
+}
+
+func toXMLRecord(record Record) xmlRecord {
+	x := xmlRecord{Leader: record.Leader}
+	for _, field := range record.ControlFields {
+		x.ControlFields = append(x.ControlFields, xmlControlField{Tag: field.Tag, Value: field.Value})
+	}
+	for _, field := range record.DataFields {
+		dataField := xmlDataField{Tag: field.Tag, Ind1: string(field.Ind1), Ind2: string(field.Ind2)}
+		for _, subfield := range field.Subfields {
+			dataField.Subfields = append(dataField.Subfields, xmlSubfield{Code: string(subfield.Code), Value: subfield.Value})
+		}
+		x.DataFields = append(x.DataFields, dataField)
+	}
+	return x
+}
+
+// indicator returns the indicator in an attribute, blank when there is none
+func indicator(value string) byte {
+	if value == "" {
+		return ' '
+	}
+	return value[0]
+}
+
+// Writer writes records to a file, one at a time. Close finishes the file
+// but doesn't close the underlying writer.
+type Writer interface {
+	Write(record Record) error
+	Close() error
+}
+
+// xmlWriter writes records as a MARCXML collection
+type xmlWriter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+	started bool
+}
+
+// NewXMLWriter returns a Writer of MARCXML records, wrapped in a collection
+func NewXMLWriter(w io.Writer) Writer {
+	return &xmlWriter{w: w, encoder: xml.NewEncoder(w)}
+}
+
+func (xw *xmlWriter) start() error {
+	if xw.started {
+		return nil
+	}
+	xw.started = true
+	_, err := io.WriteString(xw.w, xml.Header+`<collection xmlns="`+Namespace+`">`+"\n")
+	return err
+}
+
+func (xw *xmlWriter) Write(record Record) error {
+	if err := xw.start(); err != nil {
+		return err
+	}
+	if err := xw.encoder.Encode(toXMLRecord(record)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(xw.w, "\n")
+	return err
+}
+
+func (xw *xmlWriter) Close() error {
+	if err := xw.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(xw.w, "</collection>\n")
+	return err
+}
@@ -0,0 +1,96 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"library-management/internal/constants"
+	"sync"
+	"time"
+)
+
+// CachingProvider remembers the lookups of another provider for a while,
+// including the ISBNs it has no record of. Failed lookups aren't cached.
+type CachingProvider struct {
+	Provider MetadataProvider
+	TTL      time.Duration
+	// Maximum number of ISBNs remembered at once
+	Size int
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	book      *Book // nil when the provider has no record of the ISBN
+	expiresAt time.Time
+}
+
+// NewCachingProvider caches the lookups of provider for ttl, remembering up
+// to size ISBNs
+func NewCachingProvider(provider MetadataProvider, ttl time.Duration, size int) MetadataProvider {
+	return &CachingProvider{
+		Provider: provider,
+		TTL:      ttl,
+		Size:     size,
+		entries:  make(map[string]cacheEntry),
+	}
+}
+
+func (p *CachingProvider) LookupISBN(ctx context.Context, isbn string) (*Book, error) {
+	if entry, ok := p.get(isbn); ok {
+		if entry.book == nil {
+			return nil, constants.ErrMetadataNotFound
+		}
+		return copyBook(entry.book), nil
+	}
+
+	book, err := p.Provider.LookupISBN(ctx, isbn)
+	switch {
+	case err == nil:
+		p.put(isbn, copyBook(book))
+		return book, nil
+	case errors.Is(err, constants.ErrMetadataNotFound):
+		p.put(isbn, nil)
+	}
+	return nil, err
+}
+
+func (p *CachingProvider) get(isbn string) (cacheEntry, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.entries[isbn]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// put remembers a lookup. When the cache is full, expired entries are
+// dropped first and then, if need be, the one closest to expiring.
+func (p *CachingProvider) put(isbn string, book *Book) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	if _, ok := p.entries[isbn]; !ok && len(p.entries) >= p.Size {
+		var oldest string
+		for key, entry := range p.entries {
+			if now.After(entry.expiresAt) {
+				delete(p.entries, key)
+			} else if oldest == "" || entry.expiresAt.Before(p.entries[oldest].expiresAt) {
+				oldest = key
+			}
+		}
+		if len(p.entries) >= p.Size {
+			delete(p.entries, oldest)
+		}
+	}
+	p.entries[isbn] = cacheEntry{book: book, expiresAt: now.Add(p.TTL)}
+}
+
+// copyBook copies a book so callers can't change the cached one
+func copyBook(book *Book) *Book {
+	copied := *book
+	copied.Authors = append([]string(nil), book.Authors...)
+	copied.Subjects = append([]string(nil), book.Subjects...)
+	return &copied
+}
@@ -0,0 +1,190 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"library-management/internal/constants"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const goBookResponse = `{
+  "ISBN:9780134190440": {
+    "title": "The Go Programming Language",
+    "authors": [
+      {"url": "https://openlibrary.org/authors/OL7352637A", "name": "Alan A. A. Donovan"},
+      {"url": "https://openlibrary.org/authors/OL218224A", "name": "Brian W. Kernighan"}
+    ],
+    "publish_date": "Oct 26, 2015",
+    "subjects": [{"name": "Go (Computer program language)"}, {"name": " "}]
+  }
+}`
+
+// fakeOpenLibrary serves the Books API from a map of bibkeys to responses,
+// counting the requests it gets
+func fakeOpenLibrary(t *testing.T, responses map[string]string, requests *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		query := r.URL.Query()
+		if r.URL.Path != "/api/books" || query.Get("format") != "json" || query.Get("jscmd") != "data" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		response, ok := responses[query.Get("bibkeys")]
+		if !ok {
+			response = "{}"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenLibraryClient_LookupISBN(t *testing.T) {
+	var requests atomic.Int32
+	server := fakeOpenLibrary(t, map[string]string{"ISBN:9780134190440": goBookResponse}, &requests)
+	client := NewOpenLibraryClient(server.URL+"/", time.Second)
+
+	book, err := client.LookupISBN(context.Background(), "9780134190440")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &Book{
+		Title:       "The Go Programming Language",
+		Authors:     []string{"Alan A. A. Donovan", "Brian W. Kernighan"},
+		PublishedAt: time.Date(2015, 10, 26, 0, 0, 0, 0, time.UTC),
+		Subjects:    []string{"Go (Computer program language)"},
+	}
+	if !reflect.DeepEqual(book, expected) {
+		t.Errorf("expected %+v, got %+v", expected, book)
+	}
+}
+
+func TestOpenLibraryClient_NotFound(t *testing.T) {
+	var requests atomic.Int32
+	server := fakeOpenLibrary(t, nil, &requests)
+	client := NewOpenLibraryClient(server.URL, time.Second)
+
+	_, err := client.LookupISBN(context.Background(), "9780134190440")
+
+	if !errors.Is(err, constants.ErrMetadataNotFound) {
+		t.Errorf("expected %v, got %v", constants.ErrMetadataNotFound, err)
+	}
+}
+
+func TestOpenLibraryClient_Unavailable(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{name: "Server error", handler: func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+		}},
+		{name: "Not JSON", handler: func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("<html>"))
+		}},
+		{name: "Timeout", handler: func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			client := NewOpenLibraryClient(server.URL, 50*time.Millisecond)
+
+			_, err := client.LookupISBN(context.Background(), "9780134190440")
+
+			if !errors.Is(err, constants.ErrMetadataUnavailable) {
+				t.Errorf("Test case %s failed: expected %v, got %v", tc.name, constants.ErrMetadataUnavailable, err)
+			}
+		})
+	}
+}
+
+func TestParsePublishDate(t *testing.T) {
+	testCases := map[string]time.Time{
+		"October 26, 2015": time.Date(2015, 10, 26, 0, 0, 0, 0, time.UTC),
+		"Nov 2003":         time.Date(2003, 11, 1, 0, 0, 0, 0, time.UTC),
+		"1997-05-01":       time.Date(1997, 5, 1, 0, 0, 0, 0, time.UTC),
+		"c1967":            time.Date(1967, 1, 1, 0, 0, 0, 0, time.UTC),
+		"1st ed. 2001":     time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+		"":                 {},
+		"unknown":          {},
+	}
+	for date, expected := range testCases {
+		if got := parsePublishDate(date); !got.Equal(expected) {
+			t.Errorf("parsePublishDate(%q): expected %v, got %v", date, expected, got)
+		}
+	}
+}
+
+func TestCachingProvider(t *testing.T) {
+	var requests atomic.Int32
+	server := fakeOpenLibrary(t, map[string]string{"ISBN:9780134190440": goBookResponse}, &requests)
+	provider := NewCachingProvider(NewOpenLibraryClient(server.URL, time.Second), time.Hour, 10)
+
+	for i := 0; i < 3; i++ {
+		book, err := provider.LookupISBN(context.Background(), "9780134190440")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Changing a result doesn't change the cached one
+		book.Authors[0] = "Someone Else"
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := provider.LookupISBN(context.Background(), "9780307474728"); !errors.Is(err, constants.ErrMetadataNotFound) {
+			t.Fatalf("expected %v, got %v", constants.ErrMetadataNotFound, err)
+		}
+	}
+
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", requests.Load())
+	}
+	book, _ := provider.LookupISBN(context.Background(), "9780134190440")
+	if book.Authors[0] != "Alan A. A. Donovan" {
+		t.Errorf("cached book was changed: %+v", book)
+	}
+}
+
+func TestCachingProvider_EvictsWhenFull(t *testing.T) {
+	var requests atomic.Int32
+	server := fakeOpenLibrary(t, nil, &requests)
+	provider := NewCachingProvider(NewOpenLibraryClient(server.URL, time.Second), time.Hour, 2)
+
+	for _, isbn := range []string{"9780000000001", "9780000000002", "9780000000003"} {
+		provider.LookupISBN(context.Background(), isbn)
+	}
+
+	if entries := len(provider.(*CachingProvider).entries); entries != 2 {
+		t.Errorf("expected 2 cached entries, got %d", entries)
+	}
+}
+
+func TestCachingProvider_DoesNotCacheFailures(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	provider := NewCachingProvider(NewOpenLibraryClient(server.URL, time.Second), time.Hour, 10)
+
+	for i := 0; i < 2; i++ {
+		if _, err := provider.LookupISBN(context.Background(), "9780134190440"); !errors.Is(err, constants.ErrMetadataUnavailable) {
+			t.Fatalf("expected %v, got %v", constants.ErrMetadataUnavailable, err)
+		}
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", requests.Load())
+	}
+}
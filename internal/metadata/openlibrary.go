@@ -0,0 +1,125 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"library-management/internal/constants"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxResponseSize caps the size of the provider's responses we read
+const maxResponseSize = 1 << 20
+
+// publishDateLayouts are the forms Open Library publication dates come in,
+// e.g. "October 26, 2015", "Oct 26, 2015" or "2015-10-26"
+var publishDateLayouts = []string{
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"January 2006",
+	"Jan 2006",
+	"2006-01-02",
+	"2006-01",
+}
+
+// yearPattern finds the year in publication dates in any other form, e.g. "c1997"
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+// OpenLibraryClient looks up books with the Books API of Open Library, or
+// of a catalog serving the same API
+type OpenLibraryClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewOpenLibraryClient returns a client for the Books API at baseURL, e.g.
+// "https://openlibrary.org", giving up on requests after timeout
+func NewOpenLibraryClient(baseURL string, timeout time.Duration) MetadataProvider {
+	return &OpenLibraryClient{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// openLibraryBook is a book as the Books API describes it with jscmd=data
+type openLibraryBook struct {
+	Title       string `json:"title"`
+	Subtitle    string `json:"subtitle"`
+	PublishDate string `json:"publish_date"`
+	Authors     []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Subjects []struct {
+		Name string `json:"name"`
+	} `json:"subjects"`
+}
+
+func (c *OpenLibraryClient) LookupISBN(ctx context.Context, isbn string) (*Book, error) {
+	bibkey := "ISBN:" + isbn
+	query := url.Values{"bibkeys": {bibkey}, "format": {"json"}, "jscmd": {"data"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", constants.ErrMetadataUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", constants.ErrMetadataUnavailable, resp.Status)
+	}
+
+	// The response maps each bibkey found to its book, and is {} when none is
+	var books map[string]openLibraryBook
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&books); err != nil {
+		return nil, fmt.Errorf("%w: %v", constants.ErrMetadataUnavailable, err)
+	}
+	found, ok := books[bibkey]
+	if !ok {
+		return nil, constants.ErrMetadataNotFound
+	}
+
+	book := &Book{
+		Title:       strings.TrimSpace(found.Title),
+		PublishedAt: parsePublishDate(found.PublishDate),
+	}
+	if subtitle := strings.TrimSpace(found.Subtitle); subtitle != "" {
+		book.Title += ": " + subtitle
+	}
+	for _, author := range found.Authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			book.Authors = append(book.Authors, name)
+		}
+	}
+	for _, subject := range found.Subjects {
+		if name := strings.TrimSpace(subject.Name); name != "" {
+			book.Subjects = append(book.Subjects, name)
+		}
+	}
+	return book, nil
+}
+
+// parsePublishDate reads a publication date, falling back to January 1st
+// of the year it mentions. It returns the zero time when there is none.
+func parsePublishDate(date string) time.Time {
+	date = strings.TrimSpace(date)
+	for _, layout := range publishDateLayouts {
+		if parsed, err := time.Parse(layout, date); err == nil {
+			return parsed
+		}
+	}
+	if year, err := strconv.Atoi(yearPattern.FindString(date)); err == nil && year > 0 {
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,24 @@
+// Package metadata looks up bibliographic records of books in external
+// catalogs, to fill in new books from their ISBN.
+package metadata
+
+import (
+	"context"
+	"time"
+)
+
+// Book is what a provider knows about a book. Fields it doesn't know are
+// left empty.
+type Book struct {
+	Title       string
+	Authors     []string
+	PublishedAt time.Time
+	Subjects    []string
+}
+
+// MetadataProvider looks up books by ISBN in a bibliographic catalog. It
+// returns constants.ErrMetadataNotFound when the catalog has no record of
+// the ISBN, and constants.ErrMetadataUnavailable when it can't be reached.
+type MetadataProvider interface {
+	LookupISBN(ctx context.Context, isbn string) (*Book, error)
+}
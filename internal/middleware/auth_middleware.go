@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -11,8 +12,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// TokenVerifier checks an access token is valid and not revoked, and
+// returns its claims with the user's current role
+type TokenVerifier interface {
+	VerifyAccessToken(token string) (*auth.Claims, error)
+}
+
 // AuthMiddleware validates JWT token
-func AuthMiddleware() gin.HandlerFunc {
+func AuthMiddleware(verifier TokenVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -30,11 +37,20 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// Verify token
-		claims, err := auth.ValidateToken(token)
-		if err != nil {
+		claims, err := verifier.VerifyAccessToken(token)
+		switch {
+		case errors.Is(err, constants.ErrTokenRevoked):
+			handlers.RespondWithError(c, http.StatusUnauthorized, constants.ErrTokenRevoked)
+			c.Abort()
+			return
+		case errors.Is(err, constants.ErrInvalidOrExpiredToken):
 			handlers.RespondWithError(c, http.StatusUnauthorized, constants.ErrInvalidOrExpiredToken)
 			c.Abort()
 			return
+		case err != nil:
+			handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
+			c.Abort()
+			return
 		}
 
 		// Store user ID and role in context
@@ -9,14 +9,33 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RoleMiddleware(role string) gin.HandlerFunc {
+// PermissionChecker reports whether a role grants a permission
+type PermissionChecker interface {
+	HasPermission(role string, permission constants.Permission) (bool, error)
+}
+
+// RequirePermission lets the request through only when the user's role
+// grants the permission. It runs after AuthMiddleware, which sets the role.
+func RequirePermission(checker PermissionChecker, permission constants.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userRole, exists := c.Get("role")
-		if !exists || userRole != role {
+		role := c.GetString("role")
+		if role == "" {
 			handlers.RespondWithError(c, http.StatusForbidden, constants.ErrUnauthorized)
 			c.Abort()
 			return
 		}
+
+		allowed, err := checker.HasPermission(role, permission)
+		if err != nil {
+			handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
+			c.Abort()
+			return
+		}
+		if !allowed {
+			handlers.RespondWithError(c, http.StatusForbidden, constants.ErrForbidden)
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"library-management/internal/constants"
+	"library-management/internal/models"
+	"library-management/internal/ratelimit"
+	"library-management/internal/utils/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit refuses requests from a client IP over the limiter's limit,
+// telling it when to try again
+func RateLimit(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ok, retryAfter := limiter.Allow(c.ClientIP()); !ok {
+			tooManyRequests(c, retryAfter)
+			return
+		}
+		c.Next()
+	}
+}
+
+// AuditRecorder records security events in the audit log
+type AuditRecorder interface {
+	Create(entry *models.AuditEntry) error
+}
+
+// RateLimitFailures refuses requests from a client IP that got over the
+// limiter's limit of 401 Unauthorized answers. Only those are counted, so
+// clients that get it right aren't held back. The first refusal of an IP
+// in each window is audited.
+func RateLimitFailures(limiter *ratelimit.Limiter, audit AuditRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if refused, first, retryAfter := limiter.Refuse(c.ClientIP()); refused {
+			if first {
+				auditBlock(audit, limiter, c.ClientIP(), retryAfter)
+			}
+			tooManyRequests(c, retryAfter)
+			return
+		}
+		c.Next()
+		if c.Writer.Status() == http.StatusUnauthorized {
+			limiter.Allow(c.ClientIP())
+		}
+	}
+}
+
+// auditBlock records that a client IP is turned away until its window ends.
+// A failure is only logged, the request is refused either way.
+func auditBlock(audit AuditRecorder, limiter *ratelimit.Limiter, clientIP string, retryAfter time.Duration) {
+	err := audit.Create(&models.AuditEntry{
+		Action: string(constants.AuditIPBlocked),
+		IP:     clientIP,
+		Detail: fmt.Sprintf("%d failed logins within %s, blocked until %s", limiter.Limit, limiter.Window, time.Now().Add(retryAfter).UTC().Format(time.RFC3339)),
+	})
+	if err != nil {
+		log.Printf("⚠️ Failed to audit the block of client IP %s: %v", clientIP, err)
+	}
+}
+
+// tooManyRequests refuses a request, telling the client when to try again
+func tooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	handlers.RespondWithError(c, http.StatusTooManyRequests, constants.ErrTooManyRequests)
+	c.Abort()
+}
@@ -0,0 +1,52 @@
+package middlewares_test
+
+import (
+	"library-management/internal/constants"
+	middlewares "library-management/internal/middleware"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/ratelimit"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimitFailures_AuditsBlock(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auditRepo := new(mocks.AuditRepositoryInterface)
+	router := gin.New()
+	router.POST("/login", middlewares.RateLimitFailures(ratelimit.NewLimiter(2, time.Minute), auditRepo), func(c *gin.Context) {
+		c.Status(http.StatusUnauthorized)
+	})
+
+	var entries []*models.AuditEntry
+	auditRepo.On("Create", mock.AnythingOfType("*models.AuditEntry")).
+		Run(func(args mock.Arguments) { entries = append(entries, args.Get(0).(*models.AuditEntry)) }).Return(nil)
+
+	login := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, login())
+	assert.Equal(t, http.StatusUnauthorized, login())
+	assert.Empty(t, entries)
+
+	// Only the first refusal in the window is audited
+	assert.Equal(t, http.StatusTooManyRequests, login())
+	assert.Equal(t, http.StatusTooManyRequests, login())
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, string(constants.AuditIPBlocked), entries[0].Action)
+		assert.Equal(t, "10.0.0.1", entries[0].IP)
+		assert.Contains(t, entries[0].Detail, "2 failed logins within 1m0s")
+		assert.Nil(t, entries[0].UserID)
+	}
+}
@@ -0,0 +1,304 @@
+// Package migrations versions the database schema. Each migration is a
+// pair of SQL files in sql/, e.g. 0002_add_loans.up.sql applies version 2
+// and 0002_add_loans.down.sql reverts it. The versions applied are recorded
+// in the schema_migrations table.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"library-management/internal/constants"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// filePattern matches migration file names: version, name and direction
+var filePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// migrationLockID keeps two migration runs on the same database from
+// interleaving. It is an arbitrary key for pg_advisory_xact_lock.
+const migrationLockID = 7283105
+
+// Migration is a versioned change to the schema and the way to revert it
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus tells whether a migration has been applied, and when
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// SchemaMigration is a row of the schema_migrations table
+type SchemaMigration struct {
+	Version   int `gorm:"primaryKey"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// createSchemaMigrations creates the table the applied versions are
+// recorded in. SQLite, used in tests, only reads datetime columns back as times.
+func createSchemaMigrations(db *gorm.DB) error {
+	timeType := "timestamptz"
+	if db.Dialector.Name() == "sqlite" {
+		timeType = "datetime"
+	}
+	return db.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (" +
+		"version bigint PRIMARY KEY, name varchar(255) NOT NULL, applied_at " + timeType + " NOT NULL)").Error
+}
+
+// Migrations returns the migrations checked into the repository, in order
+func Migrations() ([]Migration, error) {
+	sub, err := fs.Sub(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return Load(sub)
+}
+
+// Load reads the migrations at the root of fsys, in version order. Every
+// version needs both an up and a down file, and versions must be unique.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := filePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			return nil, fmt.Errorf("%w: unexpected file %q", constants.ErrInvalidMigration, entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %q and %q", constants.ErrInvalidMigration, version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Version == 0 {
+			return nil, fmt.Errorf("%w: versions start at 1", constants.ErrInvalidMigration)
+		}
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("%w: %d_%s is missing its up or down file", constants.ErrInvalidMigration, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies and reverts migrations on a database
+type Migrator struct {
+	DB         *gorm.DB
+	Migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB, migrations []Migration) *Migrator {
+	return &Migrator{DB: db, Migrations: migrations}
+}
+
+// Latest returns the version the migrations bring the schema to
+func (m *Migrator) Latest() int {
+	if len(m.Migrations) == 0 {
+		return 0
+	}
+	return m.Migrations[len(m.Migrations)-1].Version
+}
+
+// Version returns the latest version applied to the database, or 0 if none
+func (m *Migrator) Version() (int, error) {
+	applied, err := m.applied(m.DB)
+	if err != nil {
+		return 0, err
+	}
+	return maxVersion(applied), nil
+}
+
+// CheckVersion makes sure the database is at the latest version: not
+// behind it, with migrations pending, nor at a version this build doesn't
+// know.
+func (m *Migrator) CheckVersion() error {
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	switch latest := m.Latest(); {
+	case version > latest:
+		return fmt.Errorf("%w (database at version %d, latest known is %d)", constants.ErrUnknownSchemaVersion, version, latest)
+	case version < latest:
+		return fmt.Errorf("%w (database at version %d, latest is %d)", constants.ErrSchemaOutdated, version, latest)
+	}
+	return nil
+}
+
+// Status lists the migrations with the time each was applied, if it was
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied(m.DB)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, len(m.Migrations))
+	for i, migration := range m.Migrations {
+		statuses[i] = MigrationStatus{Migration: migration}
+		if row, ok := applied[migration.Version]; ok {
+			statuses[i].AppliedAt = &row.AppliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// Up applies the pending migrations in order, each in its own transaction,
+// and returns the ones applied. It stops at the first that fails.
+func (m *Migrator) Up() ([]Migration, error) {
+	if err := createSchemaMigrations(m.DB); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.Migrations {
+		applied := false
+		err := m.DB.Transaction(func(tx *gorm.DB) error {
+			rows, err := m.lock(tx)
+			if err != nil {
+				return err
+			}
+			if err := m.checkKnown(rows); err != nil {
+				return err
+			}
+			if _, ok := rows[migration.Version]; ok {
+				return nil
+			}
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			applied = true
+			return tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if applied {
+			done = append(done, migration)
+		}
+	}
+	return done, nil
+}
+
+// Down reverts the latest steps applied migrations, newest first, each in
+// its own transaction, and returns the ones reverted
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	var done []Migration
+	for len(done) < steps {
+		var reverted *Migration
+		err := m.DB.Transaction(func(tx *gorm.DB) error {
+			rows, err := m.lock(tx)
+			if err != nil {
+				return err
+			}
+			if err := m.checkKnown(rows); err != nil {
+				return err
+			}
+			// The newest migration applied
+			for i := len(m.Migrations) - 1; i >= 0; i-- {
+				if _, ok := rows[m.Migrations[i].Version]; ok {
+					reverted = &m.Migrations[i]
+					break
+				}
+			}
+			if reverted == nil {
+				return nil
+			}
+			if err := tx.Exec(reverted.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, reverted.Version).Error
+		})
+		if err != nil {
+			if reverted != nil {
+				return done, fmt.Errorf("migration %d_%s: %w", reverted.Version, reverted.Name, err)
+			}
+			return done, err
+		}
+		if reverted == nil {
+			break
+		}
+		done = append(done, *reverted)
+	}
+	return done, nil
+}
+
+// lock takes the migration lock for the rest of the transaction, on
+// PostgreSQL, and returns the migrations applied so far
+func (m *Migrator) lock(tx *gorm.DB) (map[int]SchemaMigration, error) {
+	if tx.Dialector.Name() == "postgres" {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error; err != nil {
+			return nil, err
+		}
+	}
+	return m.applied(tx)
+}
+
+// checkKnown refuses to touch a database migrated by a newer build, whose
+// latest migrations this build can't revert
+func (m *Migrator) checkKnown(rows map[int]SchemaMigration) error {
+	if version := maxVersion(rows); version > m.Latest() {
+		return fmt.Errorf("%w (database at version %d, latest known is %d)", constants.ErrUnknownSchemaVersion, version, m.Latest())
+	}
+	return nil
+}
+
+// applied returns the rows of schema_migrations by version. A database
+// without the table has none applied.
+func (m *Migrator) applied(db *gorm.DB) (map[int]SchemaMigration, error) {
+	rows := make(map[int]SchemaMigration)
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		return rows, nil
+	}
+	var migrations []SchemaMigration
+	if err := db.Find(&migrations).Error; err != nil {
+		return nil, err
+	}
+	for _, migration := range migrations {
+		rows[migration.Version] = migration
+	}
+	return rows, nil
+}
+
+// maxVersion returns the newest version in schema_migrations
+func maxVersion(rows map[int]SchemaMigration) int {
+	version := 0
+	for v := range rows {
+		version = max(version, v)
+	}
+	return version
+}
@@ -0,0 +1,453 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"library-management/internal/constants"
+	"library-management/internal/utils/mappers"
+	"library-management/internal/utils/validation"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var testFiles = fstest.MapFS{
+	"0001_create_shelves.up.sql":   {Data: []byte("CREATE TABLE shelves (id integer PRIMARY KEY, name text NOT NULL);")},
+	"0001_create_shelves.down.sql": {Data: []byte("DROP TABLE shelves;")},
+	"0002_add_floor.up.sql":        {Data: []byte("ALTER TABLE shelves ADD COLUMN floor integer; CREATE INDEX idx_shelves_floor ON shelves (floor);")},
+	"0002_add_floor.down.sql":      {Data: []byte("DROP INDEX idx_shelves_floor; ALTER TABLE shelves DROP COLUMN floor;")},
+}
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "library.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Skipf("sqlite unavailable: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestMigrations_Load(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != 1 {
+		t.Fatalf("expected migrations starting at version 1, got %+v", migrations)
+	}
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("expected version %d, got %d_%s", i+1, migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{name: "Missing down", files: fstest.MapFS{"0001_a.up.sql": {Data: []byte("SELECT 1;")}}},
+		{name: "Same version twice", files: fstest.MapFS{
+			"0001_a.up.sql": {Data: []byte("SELECT 1;")}, "0001_a.down.sql": {Data: []byte("SELECT 1;")},
+			"0001_b.up.sql": {Data: []byte("SELECT 1;")}, "0001_b.down.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{name: "Unexpected file", files: fstest.MapFS{"README.md": {Data: []byte("#")}}},
+		{name: "Version 0", files: fstest.MapFS{
+			"0000_a.up.sql": {Data: []byte("SELECT 1;")}, "0000_a.down.sql": {Data: []byte("SELECT 1;")},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load(tc.files)
+			if !errors.Is(err, constants.ErrInvalidMigration) {
+				t.Errorf("Test case %s failed: expected %v, got %v", tc.name, constants.ErrInvalidMigration, err)
+			}
+		})
+	}
+}
+
+func TestMigrator_UpAndDown(t *testing.T) {
+	db := openTestDB(t)
+	migrations, err := Load(testFiles)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	migrator := NewMigrator(db, migrations)
+
+	if err := migrator.CheckVersion(); !errors.Is(err, constants.ErrSchemaOutdated) {
+		t.Errorf("expected %v on an empty database, got %v", constants.ErrSchemaOutdated, err)
+	}
+
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(applied) != 2 || !db.Migrator().HasColumn("shelves", "floor") {
+		t.Fatalf("expected both migrations applied, got %+v", applied)
+	}
+	if err := migrator.CheckVersion(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Nothing left to apply
+	applied, err = migrator.Up()
+	if err != nil || len(applied) != 0 {
+		t.Errorf("expected no migrations applied, got %+v (%v)", applied, err)
+	}
+
+	reverted, err := migrator.Down(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reverted) != 1 || reverted[0].Version != 2 || db.Migrator().HasColumn("shelves", "floor") {
+		t.Errorf("expected version 2 reverted, got %+v", reverted)
+	}
+
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statuses[0].AppliedAt == nil || statuses[1].AppliedAt != nil {
+		t.Errorf("expected only version 1 applied, got %+v", statuses)
+	}
+
+	// Reverting more than was applied stops at the first migration
+	reverted, err = migrator.Down(5)
+	if err != nil || len(reverted) != 1 || db.Migrator().HasTable("shelves") {
+		t.Errorf("expected version 1 reverted, got %+v (%v)", reverted, err)
+	}
+	if version, err := migrator.Version(); err != nil || version != 0 {
+		t.Errorf("expected version 0, got %d (%v)", version, err)
+	}
+}
+
+func TestMigrator_FailedMigrationIsRolledBack(t *testing.T) {
+	db := openTestDB(t)
+	files := fstest.MapFS{
+		"0001_create_shelves.up.sql":   testFiles["0001_create_shelves.up.sql"],
+		"0001_create_shelves.down.sql": testFiles["0001_create_shelves.down.sql"],
+		"0002_broken.up.sql":           {Data: []byte("CREATE TABLE racks (id integer PRIMARY KEY); ALTER TABLE nowhere ADD COLUMN floor integer;")},
+		"0002_broken.down.sql":         {Data: []byte("DROP TABLE racks;")},
+	}
+	migrations, err := Load(files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	migrator := NewMigrator(db, migrations)
+
+	applied, err := migrator.Up()
+
+	if err == nil {
+		t.Fatal("expected the broken migration to fail")
+	}
+	if len(applied) != 1 || db.Migrator().HasTable("racks") {
+		t.Errorf("expected only version 1 applied and version 2 rolled back, got %+v", applied)
+	}
+	if version, _ := migrator.Version(); version != 1 {
+		t.Errorf("expected version 1, got %d", version)
+	}
+}
+
+func TestMigrator_UnknownVersion(t *testing.T) {
+	db := openTestDB(t)
+	migrations, err := Load(testFiles)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := NewMigrator(db, migrations).Up(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// An older build only knows the first migration
+	older := NewMigrator(db, migrations[:1])
+
+	if err := older.CheckVersion(); !errors.Is(err, constants.ErrUnknownSchemaVersion) {
+		t.Errorf("expected %v, got %v", constants.ErrUnknownSchemaVersion, err)
+	}
+	if _, err := older.Down(1); !errors.Is(err, constants.ErrUnknownSchemaVersion) {
+		t.Errorf("expected %v, got %v", constants.ErrUnknownSchemaVersion, err)
+	}
+	if !db.Migrator().HasColumn("shelves", "floor") {
+		t.Error("expected the schema to be left alone")
+	}
+}
+
+// openPostgresDB opens the PostgreSQL database in TEST_DATABASE_DSN, in a
+// schema of its own that is dropped afterwards. The real migrations only run
+// on PostgreSQL, so tests using it are skipped without one.
+func openPostgresDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", dsn, err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// One connection, so the search path set below holds for every query
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("migrations_test_%d", time.Now().UnixNano())
+	if err := db.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DROP SCHEMA " + schema + " CASCADE")
+		sqlDB.Close()
+	})
+	if err := db.Exec("SET search_path TO " + schema).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return db
+}
+
+// The models as they were before migrations were versioned, when the
+// server created the schema with AutoMigrate. Emails and ISBNs are unique
+// as the seed script made them.
+type baselineUser struct {
+	gorm.Model
+	Name     string           `gorm:"type:varchar(100);not null"`
+	Email    string           `gorm:"type:varchar(100);unique;not null"`
+	Password string           `gorm:"type:varchar(255);not null"`
+	Role     string           `gorm:"type:varchar(20);not null;default:'member'"`
+	Borrows  []baselineBorrow `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
+
+func (baselineUser) TableName() string { return "users" }
+
+type baselineBook struct {
+	gorm.Model
+	Title           string           `gorm:"type:varchar(200);not null"`
+	Author          string           `gorm:"type:varchar(100);not null"`
+	ISBN            string           `gorm:"type:varchar(20);unique;not null"`
+	CopiesAvailable int              `gorm:"not null;check:copies_available >= 0"`
+	PublishedAt     time.Time        `gorm:"not null"`
+	Borrows         []baselineBorrow `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE;"`
+}
+
+func (baselineBook) TableName() string { return "books" }
+
+type baselineBorrow struct {
+	gorm.Model
+	UserID   uint         `gorm:"not null"`
+	BookID   uint         `gorm:"not null"`
+	DueDate  time.Time    `gorm:"not null"`
+	Returned bool         `gorm:"not null;default:false"`
+	User     baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Book     baselineBook `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE;"`
+}
+
+func (baselineBorrow) TableName() string { return "borrows" }
+
+func TestMigrations_AdoptBaselineDatabase(t *testing.T) {
+	db := openPostgresDB(t)
+	if err := db.AutoMigrate(&baselineUser{}, &baselineBook{}, &baselineBorrow{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	user := baselineUser{Name: "Jane Doe", Email: "jane@example.com", Password: "secret"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	books := []baselineBook{
+		{Title: "The Go Programming Language", Author: "Alan A. A. Donovan, Brian W. Kernighan", ISBN: "0-13-419044-0", CopiesAvailable: 2},
+		{Title: "The C Programming Language", Author: "Brian W. Kernighan and Dennis M. Ritchie", ISBN: "978-0-13-110362-7", CopiesAvailable: 1},
+		{Title: "The Hobbit", Author: "Tolkien, J. R. R.", ISBN: "not an isbn"},
+	}
+	if err := db.Create(&books).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loans := []baselineBorrow{
+		{UserID: user.ID, BookID: books[0].ID, DueDate: time.Now().AddDate(0, 0, 14)},
+		{UserID: user.ID, BookID: books[0].ID, DueDate: time.Now().AddDate(0, 0, -14), Returned: true},
+		{UserID: user.ID, BookID: books[2].ID, DueDate: time.Now().AddDate(0, 0, 14)},
+	}
+	if err := db.Create(&loans).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	migrator := NewMigrator(db, migrations)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := migrator.CheckVersion(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, column := range []string{"item_type", "cover_version", "cover_content_type"} {
+		if !db.Migrator().HasColumn("books", column) {
+			t.Errorf("expected books.%s to be added", column)
+		}
+	}
+	for _, column := range []string{"copy_id", "returned_at", "returned_by", "renewal_count"} {
+		if !db.Migrator().HasColumn("borrows", column) {
+			t.Errorf("expected borrows.%s to be added", column)
+		}
+	}
+	if db.Migrator().HasColumn("books", "copies_available") {
+		t.Error("expected books.copies_available to be dropped")
+	}
+
+	// A copy for each loan still out and each copy that was on the shelf
+	type copyRow struct {
+		ID      uint
+		BookID  uint
+		Barcode string
+		Status  string
+	}
+	wantCopies := map[uint]map[string]int{
+		books[0].ID: {"on_loan": 1, "available": 2},
+		books[1].ID: {"available": 1},
+		books[2].ID: {"on_loan": 1},
+	}
+	for _, book := range books {
+		var copies []copyRow
+		if err := db.Table("book_copies").Where("book_id = ?", book.ID).Order("id").Scan(&copies).Error; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		statuses := make(map[string]int)
+		for _, bookCopy := range copies {
+			statuses[bookCopy.Status]++
+			if !strings.HasPrefix(bookCopy.Barcode, fmt.Sprintf("LEGACY-%d-", book.ID)) {
+				t.Errorf("expected a placeholder barcode, got %q", bookCopy.Barcode)
+			}
+		}
+		if fmt.Sprint(statuses) != fmt.Sprint(wantCopies[book.ID]) {
+			t.Errorf("book %d: expected copies %v, got %v", book.ID, wantCopies[book.ID], statuses)
+		}
+	}
+	for i, loan := range loans {
+		var row struct{ Status *string }
+		err := db.Table("borrows").Select("book_copies.status AS status").
+			Joins("LEFT JOIN book_copies ON book_copies.id = borrows.copy_id").
+			Where("borrows.id = ?", loan.ID).Scan(&row).Error
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		switch {
+		case loan.Returned && row.Status != nil:
+			t.Errorf("loan %d: expected no copy for a returned loan, got one %s", i, *row.Status)
+		case !loan.Returned && (row.Status == nil || *row.Status != "on_loan"):
+			t.Errorf("loan %d: expected the copy on loan, got %v", i, row.Status)
+		}
+	}
+
+	// Authors are read from the author text and shared between books
+	for _, book := range books {
+		var names []string
+		err := db.Table("book_authors").Select("authors.name").
+			Joins("JOIN authors ON authors.id = book_authors.author_id").
+			Where("book_authors.book_id = ?", book.ID).Order("book_authors.position").Scan(&names).Error
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := mappers.SplitAuthorNames(book.Author)
+		if strings.Join(names, "|") != strings.Join(want, "|") {
+			t.Errorf("book %d: expected authors %q, got %q", book.ID, want, names)
+		}
+
+		var author string
+		if err := db.Table("books").Select("author").Where("id = ?", book.ID).Scan(&author).Error; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if author != strings.Join(want, ", ") {
+			t.Errorf("book %d: expected the author text %q, got %q", book.ID, strings.Join(want, ", "), author)
+		}
+	}
+	var kernighans int64
+	db.Table("authors").Where("name = ?", "Brian W. Kernighan").Count(&kernighans)
+	if kernighans != 1 {
+		t.Errorf("expected one Brian W. Kernighan shared by two books, got %d", kernighans)
+	}
+
+	// Valid ISBNs become bare ISBN-13s, and the others are left alone
+	for _, book := range books {
+		want, err := validation.NormalizeISBN(book.ISBN)
+		if err != nil {
+			want = book.ISBN
+		}
+		var isbn string
+		if err := db.Table("books").Select("isbn").Where("id = ?", book.ID).Scan(&isbn).Error; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if isbn != want {
+			t.Errorf("book %d: expected ISBN %q, got %q", book.ID, want, isbn)
+		}
+	}
+
+	// The seed script's constraints are gone, so a deleted user's email and
+	// a deleted book's ISBN can be used again
+	for _, constraint := range []struct{ table, name string }{{"users", "uni_users_email"}, {"books", "uni_books_isbn"}} {
+		if db.Migrator().HasConstraint(constraint.table, constraint.name) {
+			t.Errorf("expected %s.%s to be dropped", constraint.table, constraint.name)
+		}
+	}
+	if err := db.Exec("UPDATE users SET deleted_at = now() WHERE id = ?", user.ID).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = db.Exec("INSERT INTO users (name, email, password, role) VALUES (?, ?, ?, ?)", "Jane Doe", "Jane@Example.com", "secret", "member").Error
+	if err != nil {
+		t.Errorf("expected the email of a deleted user to be free, got %v", err)
+	}
+	if err := db.Exec("UPDATE books SET deleted_at = now() WHERE id = ?", books[1].ID).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = db.Exec("INSERT INTO books (title, author, isbn, published_at) VALUES (?, ?, ?, now())", books[1].Title, books[1].Author, "9780131103627").Error
+	if err != nil {
+		t.Errorf("expected the ISBN of a deleted book to be free, got %v", err)
+	}
+
+	// Emails stay unique whatever their case
+	err = db.Exec("INSERT INTO users (name, email, password, role) VALUES (?, ?, ?, ?)", "Jane Doe", "jane@example.com", "secret", "member").Error
+	if err == nil {
+		t.Error("expected a taken email to be refused")
+	}
+
+	// A member has one active hold per book, and can place another once it is closed
+	placeHold := func(status string) error {
+		return db.Exec("INSERT INTO holds (created_at, updated_at, user_id, book_id, status) VALUES (now(), now(), ?, ?, ?)", user.ID, books[1].ID, status).Error
+	}
+	for _, status := range []string{"cancelled", "pending"} {
+		if err := placeHold(status); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := placeHold("pending"); err == nil {
+		t.Error("expected a second active hold to be refused")
+	}
+
+	// The circulation policy has terms for each of the seeded roles
+	var roles []string
+	if err := db.Table("roles").Order("id").Pluck("name", &roles).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(roles) != fmt.Sprint(constants.SeededRoles) {
+		t.Errorf("expected the roles %v to be seeded, got %v", constants.SeededRoles, roles)
+	}
+}
@@ -0,0 +1,15 @@
+-- Drops every table, and all the data in them
+DROP TABLE IF EXISTS holds;
+DROP TABLE IF EXISTS fine_transactions;
+DROP TABLE IF EXISTS fines;
+DROP TABLE IF EXISTS borrow_renewals;
+DROP TABLE IF EXISTS borrows;
+DROP TABLE IF EXISTS book_copies;
+DROP TABLE IF EXISTS book_authors;
+DROP TABLE IF EXISTS book_tags;
+DROP TABLE IF EXISTS book_categories;
+DROP TABLE IF EXISTS books;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS authors;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,349 @@
+-- The schema as AutoMigrate left it before migrations were versioned. Every
+-- statement is skipped when its table or index exists, so databases set up
+-- by AutoMigrate are adopted as they are. Databases from before book copies,
+-- book authors and normalized ISBNs are brought up to date at the end.
+
+CREATE TABLE IF NOT EXISTS users (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name varchar(100) NOT NULL,
+    email varchar(100) NOT NULL,
+    password varchar(255) NOT NULL,
+    role varchar(20) NOT NULL DEFAULT 'member'
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS authors (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name varchar(150) NOT NULL,
+    bio text
+);
+CREATE INDEX IF NOT EXISTS idx_authors_deleted_at ON authors (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_authors_name ON authors (lower(name)) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS categories (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name varchar(100) NOT NULL,
+    parent_id bigint,
+    CONSTRAINT fk_categories_children FOREIGN KEY (parent_id) REFERENCES categories (id)
+);
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories (deleted_at);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name varchar(50) NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags (lower(name)) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tags_deleted_at ON tags (deleted_at);
+
+CREATE TABLE IF NOT EXISTS books (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    title varchar(200) NOT NULL,
+    author text NOT NULL,
+    isbn varchar(20) NOT NULL,
+    published_at timestamptz NOT NULL,
+    item_type varchar(20) NOT NULL DEFAULT 'book',
+    cover_version varchar(32) NOT NULL DEFAULT '',
+    cover_content_type varchar(50) NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_books_deleted_at ON books (deleted_at);
+-- Full-text search over title and author, on the expression of repository.BookSearchVector
+CREATE INDEX IF NOT EXISTS idx_books_search ON books USING GIN (
+    (setweight(to_tsvector('english', coalesce(title, '')), 'A') || setweight(to_tsvector('english', coalesce(author, '')), 'B'))
+);
+
+CREATE TABLE IF NOT EXISTS book_categories (
+    book_id bigint,
+    category_id bigint,
+    PRIMARY KEY (book_id, category_id),
+    CONSTRAINT fk_book_categories_book FOREIGN KEY (book_id) REFERENCES books (id),
+    CONSTRAINT fk_book_categories_category FOREIGN KEY (category_id) REFERENCES categories (id)
+);
+
+CREATE TABLE IF NOT EXISTS book_tags (
+    book_id bigint,
+    tag_id bigint,
+    PRIMARY KEY (book_id, tag_id),
+    CONSTRAINT fk_book_tags_book FOREIGN KEY (book_id) REFERENCES books (id),
+    CONSTRAINT fk_book_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id)
+);
+
+CREATE TABLE IF NOT EXISTS book_authors (
+    book_id bigint,
+    author_id bigint,
+    position bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (book_id, author_id),
+    CONSTRAINT fk_authors_book_authors FOREIGN KEY (author_id) REFERENCES authors (id) ON DELETE CASCADE,
+    CONSTRAINT fk_books_book_authors FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_book_authors_author_id ON book_authors (author_id);
+
+CREATE TABLE IF NOT EXISTS book_copies (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    book_id bigint NOT NULL,
+    barcode varchar(50) NOT NULL,
+    location varchar(100),
+    condition varchar(20) NOT NULL DEFAULT 'good',
+    acquired_at timestamptz NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'available',
+    CONSTRAINT fk_books_copies FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_book_copies_status ON book_copies (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_book_copies_barcode ON book_copies (barcode);
+CREATE INDEX IF NOT EXISTS idx_book_copies_book_id ON book_copies (book_id);
+CREATE INDEX IF NOT EXISTS idx_book_copies_deleted_at ON book_copies (deleted_at);
+
+CREATE TABLE IF NOT EXISTS borrows (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id bigint NOT NULL,
+    book_id bigint NOT NULL,
+    copy_id bigint,
+    due_date timestamptz NOT NULL,
+    returned boolean NOT NULL DEFAULT false,
+    returned_at timestamptz,
+    returned_by bigint,
+    renewal_count bigint NOT NULL DEFAULT 0,
+    CONSTRAINT fk_books_borrows FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    CONSTRAINT fk_borrows_copy FOREIGN KEY (copy_id) REFERENCES book_copies (id) ON DELETE SET NULL,
+    CONSTRAINT fk_users_borrows FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_borrows_deleted_at ON borrows (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_borrows_book_id ON borrows (book_id);
+CREATE INDEX IF NOT EXISTS idx_borrows_user_id ON borrows (user_id);
+
+CREATE TABLE IF NOT EXISTS borrow_renewals (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    borrow_id bigint NOT NULL,
+    renewed_at timestamptz NOT NULL,
+    previous_due_date timestamptz NOT NULL,
+    new_due_date timestamptz NOT NULL,
+    CONSTRAINT fk_borrows_renewals FOREIGN KEY (borrow_id) REFERENCES borrows (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_borrow_renewals_borrow_id ON borrow_renewals (borrow_id);
+CREATE INDEX IF NOT EXISTS idx_borrow_renewals_deleted_at ON borrow_renewals (deleted_at);
+
+CREATE TABLE IF NOT EXISTS fines (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id bigint NOT NULL,
+    borrow_id bigint NOT NULL,
+    days_overdue bigint NOT NULL,
+    amount bigint NOT NULL,
+    amount_paid bigint NOT NULL DEFAULT 0,
+    amount_waived bigint NOT NULL DEFAULT 0,
+    status varchar(20) NOT NULL DEFAULT 'unpaid',
+    CONSTRAINT fk_fines_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_fines_borrow FOREIGN KEY (borrow_id) REFERENCES borrows (id) ON DELETE CASCADE,
+    CONSTRAINT chk_fines_amount CHECK (amount >= 0)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fines_borrow_id ON fines (borrow_id);
+CREATE INDEX IF NOT EXISTS idx_fines_user_id ON fines (user_id);
+CREATE INDEX IF NOT EXISTS idx_fines_deleted_at ON fines (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_fines_status ON fines (status);
+
+CREATE TABLE IF NOT EXISTS fine_transactions (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    fine_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    type varchar(20) NOT NULL,
+    amount bigint NOT NULL,
+    recorded_by bigint NOT NULL,
+    note varchar(255),
+    CONSTRAINT fk_fines_transactions FOREIGN KEY (fine_id) REFERENCES fines (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_fine_transactions_user_id ON fine_transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_fine_transactions_fine_id ON fine_transactions (fine_id);
+CREATE INDEX IF NOT EXISTS idx_fine_transactions_deleted_at ON fine_transactions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS holds (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id bigint NOT NULL,
+    book_id bigint NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    copy_id bigint,
+    ready_at timestamptz,
+    expires_at timestamptz,
+    closed_at timestamptz,
+    CONSTRAINT fk_holds_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_holds_book FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_holds_book_id ON holds (book_id);
+CREATE INDEX IF NOT EXISTS idx_holds_user_id ON holds (user_id);
+CREATE INDEX IF NOT EXISTS idx_holds_deleted_at ON holds (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_holds_status ON holds (status);
+
+-- Columns added to books and borrows after AutoMigrate first created them
+ALTER TABLE books ALTER COLUMN author TYPE text;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS item_type varchar(20) NOT NULL DEFAULT 'book';
+ALTER TABLE books ADD COLUMN IF NOT EXISTS cover_version varchar(32) NOT NULL DEFAULT '';
+ALTER TABLE books ADD COLUMN IF NOT EXISTS cover_content_type varchar(50) NOT NULL DEFAULT '';
+ALTER TABLE borrows ADD COLUMN IF NOT EXISTS copy_id bigint;
+ALTER TABLE borrows ADD COLUMN IF NOT EXISTS returned_at timestamptz;
+ALTER TABLE borrows ADD COLUMN IF NOT EXISTS returned_by bigint;
+ALTER TABLE borrows ADD COLUMN IF NOT EXISTS renewal_count bigint NOT NULL DEFAULT 0;
+ALTER TABLE borrows DROP CONSTRAINT IF EXISTS fk_borrows_copy,
+    ADD CONSTRAINT fk_borrows_copy FOREIGN KEY (copy_id) REFERENCES book_copies (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_borrows_copy_id ON borrows (copy_id);
+ALTER TABLE holds ADD COLUMN IF NOT EXISTS copy_id bigint;
+
+-- Books used to count the copies on their shelf in copies_available. Each
+-- book without copies gets one for every loan still out, every ready hold
+-- and every copy on the shelf, with a placeholder barcode to relabel later.
+-- The column is added empty where it is already gone, so this does nothing.
+ALTER TABLE books ADD COLUMN IF NOT EXISTS copies_available bigint NOT NULL DEFAULT 0;
+CREATE TEMPORARY TABLE legacy_copies ON COMMIT DROP AS
+SELECT book_id, 'LEGACY-' || book_id || '-' || row_number() OVER (PARTITION BY book_id ORDER BY kind, item_id) AS barcode,
+    status, borrow_id, hold_id
+FROM (
+    SELECT book_id, 1 AS kind, id AS item_id, 'on_loan' AS status, id AS borrow_id, NULL::bigint AS hold_id
+    FROM borrows WHERE returned = false AND copy_id IS NULL AND deleted_at IS NULL
+    UNION ALL
+    SELECT book_id, 2, id, 'on_hold', NULL, id
+    FROM holds WHERE status = 'ready' AND copy_id IS NULL AND deleted_at IS NULL
+    UNION ALL
+    SELECT books.id, 3, shelved, 'available', NULL, NULL
+    FROM books, generate_series(1, books.copies_available) AS shelved
+) items
+WHERE NOT EXISTS (SELECT 1 FROM book_copies WHERE book_copies.book_id = items.book_id);
+INSERT INTO book_copies (created_at, updated_at, book_id, barcode, condition, acquired_at, status)
+SELECT now(), now(), book_id, barcode, 'good', now(), status FROM legacy_copies;
+UPDATE borrows SET copy_id = book_copies.id
+FROM legacy_copies JOIN book_copies ON book_copies.barcode = legacy_copies.barcode
+WHERE borrows.id = legacy_copies.borrow_id;
+UPDATE holds SET copy_id = book_copies.id
+FROM legacy_copies JOIN book_copies ON book_copies.barcode = legacy_copies.barcode
+WHERE holds.id = legacy_copies.hold_id;
+ALTER TABLE books DROP COLUMN copies_available;
+
+-- Books without authors are linked to the ones named in their author text,
+-- the way mappers.SplitAuthorNames reads it: "Kernighan, Brian; Ritchie,
+-- Dennis" names two authors, but commas only separate full names, so
+-- "Tolkien, J. R. R." is one. Authors with the same name are shared.
+CREATE TEMPORARY TABLE legacy_credits ON COMMIT DROP AS
+WITH parts AS (
+    SELECT books.id AS book_id, part.position AS part_position, part.name
+    FROM books, regexp_split_to_table(books.author, '\s*;\s*|\s+(?:and|&)\s+', 'i') WITH ORDINALITY AS part(name, position)
+    WHERE NOT EXISTS (SELECT 1 FROM book_authors WHERE book_authors.book_id = books.id)
+), pieces AS (
+    SELECT parts.book_id, parts.part_position, piece.position AS piece_position, parts.name AS part, piece.name AS piece,
+        (count(*) OVER credit) > 1 AND (bool_and(piece.name ~ '\S\s+\S') OVER credit) AS full_names
+    FROM parts, regexp_split_to_table(parts.name, ',') WITH ORDINALITY AS piece(name, position)
+    WINDOW credit AS (PARTITION BY parts.book_id, parts.part_position)
+), names AS (
+    SELECT book_id, part_position, piece_position,
+        regexp_replace(regexp_replace(CASE WHEN full_names THEN piece ELSE part END, '^\s+|\s+$', '', 'g'), '\s+', ' ', 'g') AS name
+    FROM pieces
+    WHERE full_names OR piece_position = 1
+), firsts AS (
+    SELECT *, row_number() OVER (PARTITION BY book_id, lower(name) ORDER BY part_position, piece_position) AS occurrence
+    FROM names
+    WHERE name <> ''
+)
+SELECT book_id, name, row_number() OVER (PARTITION BY book_id ORDER BY part_position, piece_position) - 1 AS position
+FROM firsts
+WHERE occurrence = 1;
+INSERT INTO authors (created_at, updated_at, name)
+SELECT now(), now(), (array_agg(name ORDER BY book_id, position))[1]
+FROM legacy_credits
+WHERE NOT EXISTS (SELECT 1 FROM authors WHERE lower(authors.name) = lower(legacy_credits.name) AND authors.deleted_at IS NULL)
+GROUP BY lower(name);
+INSERT INTO book_authors (book_id, author_id, position)
+SELECT legacy_credits.book_id, authors.id, legacy_credits.position
+FROM legacy_credits JOIN authors ON lower(authors.name) = lower(legacy_credits.name) AND authors.deleted_at IS NULL;
+-- The author text follows the authors, as repository.ReplaceAuthors keeps it
+UPDATE books SET author = credits.names
+FROM (
+    SELECT book_authors.book_id, string_agg(authors.name, ', ' ORDER BY book_authors.position) AS names
+    FROM book_authors JOIN authors ON authors.id = book_authors.author_id
+    WHERE book_authors.book_id IN (SELECT book_id FROM legacy_credits)
+    GROUP BY book_authors.book_id
+) credits
+WHERE books.id = credits.book_id;
+
+-- The seed script gave emails and ISBNs gorm unique constraints. They would
+-- keep a deleted user's email and a deleted book's ISBN from being used
+-- again, so they make way for the indexes below and in 0007.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_users_email;
+ALTER TABLE books DROP CONSTRAINT IF EXISTS uni_books_isbn;
+
+-- ISBNs are stored as bare ISBN-13s, as validation.NormalizeISBN writes
+-- them. An ISBN with a wrong check digit is left as it is, and so is one
+-- that would end up the same as another book's.
+CREATE FUNCTION pg_temp.normalize_isbn(isbn text) RETURNS text LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    digits text := upper(replace(replace(isbn, '-', ''), ' ', ''));
+    isbn13 text;
+    total int := 0;
+BEGIN
+    IF digits ~ '^[0-9]{9}[0-9X]$' THEN
+        -- The ISBN-10 check digit is mod 11, where X stands for 10
+        FOR i IN 1..10 LOOP
+            total := total + (11 - i) * CASE WHEN substr(digits, i, 1) = 'X' THEN 10 ELSE substr(digits, i, 1)::int END;
+        END LOOP;
+        IF total % 11 <> 0 THEN
+            RETURN NULL;
+        END IF;
+        isbn13 := '978' || substr(digits, 1, 9);
+    ELSIF digits ~ '^[0-9]{13}$' THEN
+        isbn13 := substr(digits, 1, 12);
+    ELSE
+        RETURN NULL;
+    END IF;
+
+    -- The ISBN-13 check digit weights the digits alternately by 1 and 3
+    total := 0;
+    FOR i IN 1..12 LOOP
+        total := total + CASE WHEN i % 2 = 0 THEN 3 ELSE 1 END * substr(isbn13, i, 1)::int;
+    END LOOP;
+    isbn13 := isbn13 || (10 - total % 10) % 10;
+    IF length(digits) = 13 AND isbn13 <> digits THEN
+        RETURN NULL;
+    END IF;
+    RETURN isbn13;
+END $$;
+UPDATE books SET isbn = normalized.isbn
+FROM (
+    SELECT DISTINCT ON (pg_temp.normalize_isbn(isbn)) id, pg_temp.normalize_isbn(isbn) AS isbn
+    FROM books
+    WHERE deleted_at IS NULL AND pg_temp.normalize_isbn(isbn) IS NOT NULL
+    ORDER BY pg_temp.normalize_isbn(isbn), id
+) normalized
+WHERE books.id = normalized.id AND books.isbn <> normalized.isbn
+    AND NOT EXISTS (SELECT 1 FROM books other WHERE other.isbn = normalized.isbn AND other.deleted_at IS NULL);
+DROP FUNCTION pg_temp.normalize_isbn(text);
+
+-- ISBNs are unique among the books that aren't deleted. Books still sharing
+-- one have to be fixed by hand before migrating.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn ON books (isbn) WHERE deleted_at IS NULL;
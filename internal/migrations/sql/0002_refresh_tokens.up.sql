@@ -0,0 +1,20 @@
+-- Access tokens carry the token version of their user, and stop working
+-- once it is bumped, e.g. on logout or a role change
+ALTER TABLE users ADD COLUMN token_version bigint NOT NULL DEFAULT 0;
+
+-- Refresh tokens are stored as the SHA-256 of the token. Each is used once:
+-- refreshing revokes it and issues the next one of the same family. They
+-- also stop working once the token version of their user is bumped.
+CREATE TABLE refresh_tokens (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz NOT NULL,
+    user_id bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    family_id varchar(64) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    token_version bigint NOT NULL,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz
+);
+CREATE UNIQUE INDEX idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
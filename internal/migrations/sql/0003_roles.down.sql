@@ -0,0 +1,5 @@
+ALTER TABLE users DROP CONSTRAINT fk_users_role;
+-- Only admin and member existed before roles were defined
+UPDATE users SET role = 'member' WHERE role NOT IN ('admin', 'member');
+DROP TABLE role_permissions;
+DROP TABLE roles;
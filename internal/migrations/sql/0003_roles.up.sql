@@ -0,0 +1,39 @@
+-- Roles grant permissions such as books:write. The admin role holds every
+-- permission without listing them.
+CREATE TABLE roles (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    name varchar(20) NOT NULL,
+    description varchar(255) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX idx_roles_name ON roles (name);
+
+CREATE TABLE role_permissions (
+    role_id bigint NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission varchar(50) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+INSERT INTO roles (created_at, updated_at, name, description) VALUES
+    (now(), now(), 'admin', 'Runs the library, with every permission'),
+    (now(), now(), 'member', 'Borrows items and places holds'),
+    (now(), now(), 'librarian', 'Works the circulation desk'),
+    (now(), now(), 'cataloguer', 'Maintains the catalog');
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT roles.id, permission
+FROM roles, unnest(ARRAY[
+    'users:read', 'copies:read', 'loans:read', 'loans:checkout', 'loans:checkin', 'fines:read', 'fines:manage'
+]) AS permission
+WHERE roles.name = 'librarian';
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT roles.id, permission
+FROM roles, unnest(ARRAY['books:write', 'books:export', 'copies:read', 'copies:write']) AS permission
+WHERE roles.name = 'cataloguer';
+
+-- Users can only have a defined role. Any other role, e.g. the "user" the
+-- seeder used to give, becomes member.
+UPDATE users SET role = 'member' WHERE role NOT IN (SELECT name FROM roles);
+ALTER TABLE users ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES roles (name);
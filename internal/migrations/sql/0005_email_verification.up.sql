@@ -0,0 +1,18 @@
+-- Self-registered users verify their email with a link sent to it. Users
+-- from before verification existed count as verified.
+ALTER TABLE users ADD COLUMN email_verified_at timestamptz;
+UPDATE users SET email_verified_at = created_at;
+
+-- Verification tokens are stored as the SHA-256 of the token, with the
+-- address they were sent to. Each can be used once, before it expires.
+CREATE TABLE email_verification_tokens (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz NOT NULL,
+    user_id bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    email varchar(100) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz
+);
+CREATE UNIQUE INDEX idx_email_verification_tokens_token_hash ON email_verification_tokens (token_hash);
+CREATE INDEX idx_email_verification_tokens_user_id ON email_verification_tokens (user_id);
@@ -0,0 +1,18 @@
+-- Failed logins are counted per account, and too many in a short time lock
+-- the account for a while. A successful login starts the count again.
+ALTER TABLE users ADD COLUMN failed_logins integer NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN last_failed_login_at timestamptz;
+ALTER TABLE users ADD COLUMN locked_until timestamptz;
+
+-- Security events, such as accounts being locked and unlocked. actor_id is
+-- the staff member who acted, if any.
+CREATE TABLE audit_entries (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz NOT NULL,
+    action varchar(50) NOT NULL,
+    user_id bigint REFERENCES users (id) ON DELETE SET NULL,
+    actor_id bigint REFERENCES users (id) ON DELETE SET NULL,
+    ip varchar(45) NOT NULL DEFAULT '',
+    detail text NOT NULL DEFAULT ''
+);
+CREATE INDEX idx_audit_entries_user_id ON audit_entries (user_id);
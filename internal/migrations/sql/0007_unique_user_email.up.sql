@@ -0,0 +1,4 @@
+-- An email belongs to one account, whatever its case. The services check it
+-- before saving, and this index settles two requests racing for the same one.
+-- Accounts already sharing an email have to be merged or renamed first.
+CREATE UNIQUE INDEX idx_users_email ON users (lower(email)) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+DROP INDEX idx_holds_active_user_book;
@@ -0,0 +1,18 @@
+-- A member has at most one place in a book's queue. The service checks it
+-- before placing a hold, and this index settles two requests racing for it.
+-- Where a member already has several, the ready one or else the oldest is
+-- kept; copies set aside for the others go back on the shelf.
+WITH ranked AS (
+    SELECT id, row_number() OVER (PARTITION BY user_id, book_id ORDER BY status = 'ready' DESC, id) AS n
+    FROM holds
+    WHERE status IN ('pending', 'ready') AND deleted_at IS NULL
+), cancelled AS (
+    UPDATE holds SET status = 'cancelled', closed_at = now(), updated_at = now()
+    WHERE id IN (SELECT id FROM ranked WHERE n > 1)
+    RETURNING copy_id
+)
+UPDATE book_copies SET status = 'available', updated_at = now()
+WHERE status = 'on_hold' AND id IN (SELECT copy_id FROM cancelled WHERE copy_id IS NOT NULL);
+
+CREATE UNIQUE INDEX idx_holds_active_user_book ON holds (user_id, book_id)
+WHERE status IN ('pending', 'ready') AND deleted_at IS NULL;
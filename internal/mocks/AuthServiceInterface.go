@@ -4,6 +4,7 @@ package mocks
 
 import (
 	dto "library-management/internal/dto"
+	auth "library-management/internal/utils/auth"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,74 +14,136 @@ type AuthServiceInterface struct {
 	mock.Mock
 }
 
-// Login provides a mock function with given fields: req
-func (_m *AuthServiceInterface) Login(req dto.UserLoginRequest) (string, dto.UserResponse, error) {
-	ret := _m.Called(req)
+// Login provides a mock function with given fields: req, clientIP
+func (_m *AuthServiceInterface) Login(req dto.UserLoginRequest, clientIP string) (dto.AuthResponse, error) {
+	ret := _m.Called(req, clientIP)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
-	var r0 string
-	var r1 dto.UserResponse
-	var r2 error
-	if rf, ok := ret.Get(0).(func(dto.UserLoginRequest) (string, dto.UserResponse, error)); ok {
-		return rf(req)
+	var r0 dto.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(dto.UserLoginRequest, string) (dto.AuthResponse, error)); ok {
+		return rf(req, clientIP)
+	}
+	if rf, ok := ret.Get(0).(func(dto.UserLoginRequest, string) dto.AuthResponse); ok {
+		r0 = rf(req, clientIP)
+	} else {
+		r0 = ret.Get(0).(dto.AuthResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(dto.UserLoginRequest, string) error); ok {
+		r1 = rf(req, clientIP)
+	} else {
+		r1 = ret.Error(1)
 	}
-	if rf, ok := ret.Get(0).(func(dto.UserLoginRequest) string); ok {
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: req
+func (_m *AuthServiceInterface) Logout(req dto.LogoutRequest) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(dto.LogoutRequest) error); ok {
 		r0 = rf(req)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(dto.UserLoginRequest) dto.UserResponse); ok {
-		r1 = rf(req)
+	return r0
+}
+
+// Refresh provides a mock function with given fields: req
+func (_m *AuthServiceInterface) Refresh(req dto.RefreshRequest) (dto.AuthResponse, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 dto.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(dto.RefreshRequest) (dto.AuthResponse, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(dto.RefreshRequest) dto.AuthResponse); ok {
+		r0 = rf(req)
 	} else {
-		r1 = ret.Get(1).(dto.UserResponse)
+		r0 = ret.Get(0).(dto.AuthResponse)
 	}
 
-	if rf, ok := ret.Get(2).(func(dto.UserLoginRequest) error); ok {
-		r2 = rf(req)
+	if rf, ok := ret.Get(1).(func(dto.RefreshRequest) error); ok {
+		r1 = rf(req)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
 // Register provides a mock function with given fields: req
-func (_m *AuthServiceInterface) Register(req dto.UserRegisterRequest) (string, dto.UserResponse, error) {
+func (_m *AuthServiceInterface) Register(req dto.UserRegisterRequest) (dto.AuthResponse, error) {
 	ret := _m.Called(req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Register")
 	}
 
-	var r0 string
-	var r1 dto.UserResponse
-	var r2 error
-	if rf, ok := ret.Get(0).(func(dto.UserRegisterRequest) (string, dto.UserResponse, error)); ok {
+	var r0 dto.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(dto.UserRegisterRequest) (dto.AuthResponse, error)); ok {
 		return rf(req)
 	}
-	if rf, ok := ret.Get(0).(func(dto.UserRegisterRequest) string); ok {
+	if rf, ok := ret.Get(0).(func(dto.UserRegisterRequest) dto.AuthResponse); ok {
 		r0 = rf(req)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(dto.AuthResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(dto.UserRegisterRequest) dto.UserResponse); ok {
+	if rf, ok := ret.Get(1).(func(dto.UserRegisterRequest) error); ok {
 		r1 = rf(req)
 	} else {
-		r1 = ret.Get(1).(dto.UserResponse)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyAccessToken provides a mock function with given fields: token
+func (_m *AuthServiceInterface) VerifyAccessToken(token string) (*auth.Claims, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAccessToken")
+	}
+
+	var r0 *auth.Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*auth.Claims, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *auth.Claims); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.Claims)
+		}
 	}
 
-	if rf, ok := ret.Get(2).(func(dto.UserRegisterRequest) error); ok {
-		r2 = rf(req)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
 // NewAuthServiceInterface creates a new instance of AuthServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -3,7 +3,10 @@
 package mocks
 
 import (
+	gorm "gorm.io/gorm"
 	models "library-management/internal/models"
+	repository "library-management/internal/repository"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,6 +16,24 @@ type UserRepositoryInterface struct {
 	mock.Mock
 }
 
+// ClearFailedLogins provides a mock function with given fields: id
+func (_m *UserRepositoryInterface) ClearFailedLogins(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearFailedLogins")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: user
 func (_m *UserRepositoryInterface) Create(user *models.User) (*models.User, error) {
 	ret := _m.Called(user)
@@ -158,6 +179,106 @@ func (_m *UserRepositoryInterface) GetByID(id uint, fields []string) (*models.Us
 	return r0, r1
 }
 
+// IncrementTokenVersion provides a mock function with given fields: id
+func (_m *UserRepositoryInterface) IncrementTokenVersion(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementTokenVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Lock provides a mock function with given fields: id, until
+func (_m *UserRepositoryInterface) Lock(id uint, until time.Time) error {
+	ret := _m.Called(id, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) error); ok {
+		r0 = rf(id, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LockForUpdate provides a mock function with given fields: id
+func (_m *UserRepositoryInterface) LockForUpdate(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockForUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordFailedLogin provides a mock function with given fields: id, since
+func (_m *UserRepositoryInterface) RecordFailedLogin(id uint, since time.Time) (int, error) {
+	ret := _m.Called(id, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailedLogin")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) (int, error)); ok {
+		return rf(id, since)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time) int); ok {
+		r0 = rf(id, since)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(id, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetEmailVerifiedAt provides a mock function with given fields: id, verifiedAt
+func (_m *UserRepositoryInterface) SetEmailVerifiedAt(id uint, verifiedAt *time.Time) error {
+	ret := _m.Called(id, verifiedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEmailVerifiedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, *time.Time) error); ok {
+		r0 = rf(id, verifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: user
 func (_m *UserRepositoryInterface) Update(user *models.User) error {
 	ret := _m.Called(user)
@@ -176,6 +297,26 @@ func (_m *UserRepositoryInterface) Update(user *models.User) error {
 	return r0
 }
 
+// WithTx provides a mock function with given fields: tx
+func (_m *UserRepositoryInterface) WithTx(tx *gorm.DB) repository.UserRepositoryInterface {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.UserRepositoryInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) repository.UserRepositoryInterface); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.UserRepositoryInterface)
+		}
+	}
+
+	return r0
+}
+
 // NewUserRepositoryInterface creates a new instance of UserRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepositoryInterface(t interface {
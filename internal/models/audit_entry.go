@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// AuditEntry records a security event, such as an account being locked
+type AuditEntry struct {
+	ID        uint      `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"not null"`
+	Action    string    `gorm:"type:varchar(50);not null"`
+	// The user the event is about, and the staff member who acted, if any
+	UserID  *uint `gorm:"index"`
+	ActorID *uint
+	// Client IP of the request that caused the event
+	IP     string `gorm:"type:varchar(45);not null;default:''"`
+	Detail string `gorm:"type:text;not null;default:''"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "gorm.io/gorm"
+
+type Author struct {
+	gorm.Model
+	// Names are unique regardless of case, so "j. r. r. tolkien" and
+	// "J. R. R. Tolkien" are the same author
+	Name string `json:"name" gorm:"type:varchar(150);not null;index:idx_authors_name,unique,expression:lower(name),where:deleted_at IS NULL"`
+	Bio  string `json:"bio" gorm:"type:text"`
+
+	// The books credited to the author
+	BookAuthors []BookAuthor `gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE;"`
+}
+
+// BookAuthor links a book to one of its authors. Position keeps the order
+// the authors are credited in, first author first.
+type BookAuthor struct {
+	BookID   uint `gorm:"primaryKey"`
+	AuthorID uint `gorm:"primaryKey;index"`
+	Position int  `gorm:"not null;default:0"`
+
+	Author Author `gorm:"foreignKey:AuthorID"`
+}
@@ -6,14 +6,65 @@ import (
 	"gorm.io/gorm"
 )
 
+// Book is a title in the catalog. Author holds the names of its authors
+// joined for display and search, e.g. "Alan A. A. Donovan, Brian W. Kernighan";
+// it follows BookAuthors and isn't edited directly. ISBN is a bare ISBN-13,
+// unique among the books that aren't deleted.
 type Book struct {
 	gorm.Model
-	Title           string    `json:"title" gorm:"type:varchar(200);not null"`
-	Author          string    `json:"author" gorm:"type:varchar(100);not null"`
-	ISBN            string    `json:"isbn" gorm:"type:varchar(20);not null"`
-	CopiesAvailable int       `json:"copies_available" gorm:"not null;check:copies_available >= 0"`
-	PublishedAt     time.Time `json:"published_at" gorm:"not null"`
+	Title       string    `json:"title" gorm:"type:varchar(200);not null"`
+	Author      string    `json:"author" gorm:"type:text;not null"`
+	ISBN        string    `json:"isbn" gorm:"type:varchar(20);not null"`
+	PublishedAt time.Time `json:"published_at" gorm:"not null"`
+	ItemType    string    `json:"item_type" gorm:"type:varchar(20);not null;default:'book'"`
+
+	// The cover image, kept in storage along with its thumbnail. CoverVersion
+	// is derived from the image's content, so it changes with every new cover
+	// and is empty when the book has none.
+	CoverVersion     string `json:"cover_version" gorm:"type:varchar(32);not null;default:''"`
+	CoverContentType string `json:"cover_content_type" gorm:"type:varchar(50);not null;default:''"`
+
+	// Number of copies on the shelf. It is computed from the copies' status
+	// when the book is loaded and never written.
+	CopiesAvailable int `json:"copies_available" gorm:"->;-:migration"`
+
+	// The authors of the book, in credit order
+	BookAuthors []BookAuthor `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE;"`
+
+	// The subjects the book is filed under, and its tags
+	Categories []Category `gorm:"many2many:book_categories;"`
+	Tags       []Tag      `gorm:"many2many:book_tags;"`
+
+	// The physical copies of the book
+	Copies []BookCopy `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE;"`
 
 	// A Book can be borrowed multiple times
 	Borrows []Borrow `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE;"`
 }
+
+// BookSearchResult is a book matched by a catalog search, with its relevance
+// and the matched terms highlighted. It is not a table.
+type BookSearchResult struct {
+	Book            `gorm:"embedded"`
+	Rank            float64
+	TitleHighlight  string
+	AuthorHighlight string
+}
+
+// HighlightStart and HighlightStop surround the matched terms in the
+// highlights of a BookSearchResult. The highlights are otherwise raw text,
+// to be escaped before the markers are turned into <mark> tags.
+const (
+	HighlightStart = "\x02"
+	HighlightStop  = "\x03"
+)
+
+// BookFacetCount is a facet value with the number of books that have it
+// and the number of those with a copy available. It is not a table.
+type BookFacetCount struct {
+	// Set for facets over entities, e.g. the author's ID
+	ID        uint
+	Value     string
+	Count     int64
+	Available int64
+}
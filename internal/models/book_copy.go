@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BookCopy is one physical item of a book, identified by the barcode on its label
+type BookCopy struct {
+	gorm.Model
+	BookID     uint      `json:"book_id" gorm:"not null;index"`
+	Barcode    string    `json:"barcode" gorm:"type:varchar(50);not null;uniqueIndex"`
+	Location   string    `json:"location" gorm:"type:varchar(100)"` // shelf or branch
+	Condition  string    `json:"condition" gorm:"type:varchar(20);not null;default:'good'"`
+	AcquiredAt time.Time `json:"acquired_at" gorm:"not null"`
+	Status     string    `json:"status" gorm:"type:varchar(20);not null;default:'available';index"`
+
+	// Relationships
+	Book Book `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE;"`
+}
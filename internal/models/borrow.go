@@ -8,12 +8,27 @@ import (
 
 type Borrow struct {
 	gorm.Model
-	UserID   uint      `json:"user_id" gorm:"not null"`
-	BookID   uint      `json:"book_id" gorm:"not null"`
-	DueDate  time.Time `json:"due_date" gorm:"not null"`
-	Returned bool      `json:"returned" gorm:"not null;default:false"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	BookID     uint       `json:"book_id" gorm:"not null;index"`
+	CopyID     *uint      `json:"copy_id" gorm:"index"` // the physical copy handed out
+	DueDate    time.Time  `json:"due_date" gorm:"not null"`
+	Returned   bool       `json:"returned" gorm:"not null;default:false"`
+	ReturnedAt *time.Time `json:"returned_at"`
+	ReturnedBy *uint      `json:"returned_by"`
+
+	// Number of times the due date was extended, see Renewals for when
+	RenewalCount int `json:"renewal_count" gorm:"not null;default:0"`
 
 	// Relationships
-	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
-	Book Book `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE;"`
+	User User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Book Book      `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE;"`
+	Copy *BookCopy `gorm:"foreignKey:CopyID;constraint:OnDelete:SET NULL;"`
+
+	// A Borrow can be renewed multiple times
+	Renewals []BorrowRenewal `gorm:"foreignKey:BorrowID;constraint:OnDelete:CASCADE;"`
+}
+
+// IsOverdue reports whether the loan is still out past its due date
+func (b *Borrow) IsOverdue(now time.Time) bool {
+	return !b.Returned && b.DueDate.Before(now)
 }
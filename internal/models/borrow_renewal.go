@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BorrowRenewal records one extension of a loan's due date
+type BorrowRenewal struct {
+	gorm.Model
+	BorrowID        uint      `json:"borrow_id" gorm:"not null;index"`
+	RenewedAt       time.Time `json:"renewed_at" gorm:"not null"`
+	PreviousDueDate time.Time `json:"previous_due_date" gorm:"not null"`
+	NewDueDate      time.Time `json:"new_due_date" gorm:"not null"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "gorm.io/gorm"
+
+// Category is a subject in the catalog's taxonomy. Categories form a tree,
+// e.g. "Science" > "Physics" > "Astrophysics".
+type Category struct {
+	gorm.Model
+	Name     string `json:"name" gorm:"type:varchar(100);not null"`
+	ParentID *uint  `json:"parent_id" gorm:"index"`
+
+	Parent   *Category  `gorm:"foreignKey:ParentID"`
+	Children []Category `gorm:"foreignKey:ParentID"`
+}
+
+// Tag is a free-form label on books
+type Tag struct {
+	gorm.Model
+	// Names are unique regardless of case
+	Name string `json:"name" gorm:"type:varchar(50);not null;index:idx_tags_name,unique,expression:lower(name),where:deleted_at IS NULL"`
+
+	// Number of books carrying the tag. It is only computed when listing
+	// tags and never written.
+	BookCount int64 `json:"book_count" gorm:"->;-:migration"`
+}
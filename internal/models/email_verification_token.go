@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// EmailVerificationToken proves a user owns the email it was sent to. Only
+// the SHA-256 of the token is stored; it works once, until it expires, and
+// only while the user still has that email.
+type EmailVerificationToken struct {
+	ID        uint       `gorm:"primaryKey"`
+	CreatedAt time.Time  `gorm:"not null"`
+	UserID    uint       `gorm:"not null;index"`
+	Email     string     `gorm:"type:varchar(100);not null"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time // set once the email is verified, or a newer token is sent
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
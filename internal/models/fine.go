@@ -0,0 +1,41 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// Fine is the overdue charge accrued by a late loan. All amounts are in cents.
+// Payments and waivers against it are recorded in its Transactions ledger.
+type Fine struct {
+	gorm.Model
+	UserID       uint   `json:"user_id" gorm:"not null;index"`
+	BorrowID     uint   `json:"borrow_id" gorm:"not null;uniqueIndex"`
+	DaysOverdue  int    `json:"days_overdue" gorm:"not null"`
+	Amount       int64  `json:"amount" gorm:"not null;check:amount >= 0"`
+	AmountPaid   int64  `json:"amount_paid" gorm:"not null;default:0"`
+	AmountWaived int64  `json:"amount_waived" gorm:"not null;default:0"`
+	Status       string `json:"status" gorm:"type:varchar(20);not null;default:'unpaid';index"`
+
+	// Relationships
+	User   User   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Borrow Borrow `gorm:"foreignKey:BorrowID;constraint:OnDelete:CASCADE;"`
+
+	// A Fine can be settled in several payments
+	Transactions []FineTransaction `gorm:"foreignKey:FineID;constraint:OnDelete:CASCADE;"`
+}
+
+// Balance is what is still owed on the fine
+func (f *Fine) Balance() int64 {
+	return f.Amount - f.AmountPaid - f.AmountWaived
+}
+
+// FineTransaction is a ledger entry recording a payment or a waiver against a fine
+type FineTransaction struct {
+	gorm.Model
+	FineID     uint   `json:"fine_id" gorm:"not null;index"`
+	UserID     uint   `json:"user_id" gorm:"not null;index"`
+	Type       string `json:"type" gorm:"type:varchar(20);not null"`
+	Amount     int64  `json:"amount" gorm:"not null"`
+	RecordedBy uint   `json:"recorded_by" gorm:"not null"`
+	Note       string `json:"note" gorm:"type:varchar(255)"`
+}
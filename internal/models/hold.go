@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Hold is a member's place in the queue for a book that has no copies available.
+// Holds are served in FIFO order (by ID).
+type Hold struct {
+	gorm.Model
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	BookID    uint       `json:"book_id" gorm:"not null;index"`
+	Status    string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	CopyID    *uint      `json:"copy_id"` // the copy set aside once ready
+	ReadyAt   *time.Time `json:"ready_at"`
+	ExpiresAt *time.Time `json:"expires_at"` // end of the pickup window once ready
+	ClosedAt  *time.Time `json:"closed_at"`  // when it was fulfilled, cancelled or expired
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Book Book `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE;"`
+}
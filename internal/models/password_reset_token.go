@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// PasswordResetToken lets a user who forgot their password set a new one.
+// Only the SHA-256 of the token is stored; it works once, until it expires.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey"`
+	CreatedAt time.Time  `gorm:"not null"`
+	UserID    uint       `gorm:"not null;index"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time // set once the password is reset, or a newer token is requested
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
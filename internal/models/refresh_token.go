@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// RefreshToken is a long-lived token exchanged for new access tokens. Only
+// the SHA-256 of the token is stored. Each refresh revokes the token used
+// and issues the next one of the same family, so a token presented twice
+// gives away a stolen copy and the whole family is revoked. Tokens issued
+// before the user's token version was bumped no longer work either.
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"not null"`
+	UserID    uint      `gorm:"not null;index"`
+	FamilyID  string    `gorm:"type:varchar(64);not null;index"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	// The user's token version when the token was issued
+	TokenVersion int        `gorm:"not null"`
+	ExpiresAt    time.Time  `gorm:"not null"`
+	RevokedAt    *time.Time // set once the token is used, or on logout
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Role is a named set of permissions given to users
+type Role struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Name        string `gorm:"type:varchar(20);not null;uniqueIndex"`
+	Description string `gorm:"type:varchar(255);not null;default:''"`
+
+	Permissions []RolePermission `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE;"`
+}
+
+// RolePermission grants a permission to a role
+type RolePermission struct {
+	RoleID     uint   `gorm:"primaryKey"`
+	Permission string `gorm:"type:varchar(50);primaryKey"`
+}
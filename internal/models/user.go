@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -9,7 +11,16 @@ type User struct {
 	Name     string `json:"name" gorm:"type:varchar(100);not null" validate:"required"`
 	Email    string `json:"email" gorm:"type:varchar(100);not null" validate:"required,email"`
 	Password string `json:"-" gorm:"type:varchar(255);not null" validate:"required"`
-	Role     string `json:"role" gorm:"type:varchar(20);not null;default:'member'" validate:"required,oneof=admin member"`
+	Role     string `json:"role" gorm:"type:varchar(20);not null;default:'member'" validate:"required"`
+	// Bumped to revoke the user's access tokens, which carry the version they were issued at
+	TokenVersion int `json:"-" gorm:"not null;default:0"`
+	// When the user proved they own their email; nil until then
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// Recent failed logins, counted until the user logs in or is locked out
+	FailedLogins      int        `json:"-" gorm:"not null;default:0"`
+	LastFailedLoginAt *time.Time `json:"-"`
+	// Logins are refused until then after too many failed ones
+	LockedUntil *time.Time `json:"locked_until"`
 
 	// A User can borrow many books
 	Borrows []Borrow `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
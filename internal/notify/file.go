@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileNotifier writes each message to its own file in a directory, named
+// after when it was sent and to whom, e.g. to read them in tests or a
+// local mail viewer
+type FileNotifier struct {
+	Dir string
+}
+
+// NewFileNotifier returns a notifier writing to dir, which is created if
+// it doesn't exist yet
+func NewFileNotifier(dir string) (Notifier, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileNotifier{Dir: dir}, nil
+}
+
+func (n *FileNotifier) Send(msg Message) error {
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), fileSafe(msg.To))
+	content := fmt.Sprintf("To: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n",
+		msg.To, msg.Subject, strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	// Messages carry secrets such as reset links, so only the owner can read them
+	return os.WriteFile(filepath.Join(n.Dir, name), []byte(content), 0o600)
+}
+
+// fileSafe keeps the characters of an address that are safe in a file name
+func fileSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '@' || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,12 @@
+package notify
+
+import "log"
+
+// LogNotifier writes messages to the log instead of delivering them. It is
+// meant for development, as the log then holds secrets like reset links.
+type LogNotifier struct{}
+
+func (n *LogNotifier) Send(msg Message) error {
+	log.Printf("📧 To: %s\nSubject: %s\n\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package notify delivers messages to users, such as password reset links.
+// Where they go depends on the Notifier: a mail service in production, or
+// the log or a directory of files in development.
+package notify
+
+import (
+	"library-management/internal/constants"
+)
+
+// Message is a plain-text message to one recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages
+type Notifier interface {
+	Send(msg Message) error
+}
+
+const (
+	DriverLog  = "log"
+	DriverFile = "file"
+)
+
+// New returns the notifier of a driver: "log" writes messages to the log,
+// "file" writes each one to a file in dir
+func New(driver, dir string) (Notifier, error) {
+	switch driver {
+	case DriverLog:
+		return &LogNotifier{}, nil
+	case DriverFile:
+		return NewFileNotifier(dir)
+	default:
+		return nil, constants.ErrUnknownNotifier
+	}
+}
@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileNotifier(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	notifier, err := New(DriverFile, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = notifier.Send(Message{To: "jane/../doe@example.com", Subject: "Hello", Body: "Line one\nLine two"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("got files %v, %v; want one message", files, err)
+	}
+	if strings.Contains(filepath.Base(files[0]), "/") || !strings.HasSuffix(files[0], "-jane_.._doe@example.com.eml") {
+		t.Errorf("file name %q doesn't name the recipient safely", filepath.Base(files[0]))
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "To: jane/../doe@example.com\r\nSubject: Hello\r\nContent-Type: text/plain; charset=utf-8\r\n\r\nLine one\r\nLine two\r\n"
+	if string(content) != want {
+		t.Errorf("message is %q, want %q", content, want)
+	}
+}
+
+func TestNewUnknownDriver(t *testing.T) {
+	if _, err := New("smtp", ""); err == nil {
+		t.Error("an unknown driver was accepted")
+	}
+}
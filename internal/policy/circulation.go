@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"library-management/internal/constants"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// RolePolicy holds the lending terms of one role
+type RolePolicy struct {
+	// Loan period per item type. Item types missing from the map can't be borrowed.
+	LoanPeriods map[constants.ItemType]time.Duration
+	// How many loans can be out at the same time
+	MaxConcurrentLoans int
+	// Whether the role may pick a due date instead of the computed one
+	CanOverrideDueDate bool
+}
+
+// CirculationPolicy decides the terms of every loan. Roles without their own
+// terms, such as ones created by an admin later, get the Default ones.
+type CirculationPolicy struct {
+	Roles   map[string]RolePolicy
+	Default RolePolicy
+}
+
+// DefaultCirculationPolicy returns the library's standard lending terms
+func DefaultCirculationPolicy() *CirculationPolicy {
+	member := RolePolicy{
+		LoanPeriods: map[constants.ItemType]time.Duration{
+			constants.ItemBook:     21 * day,
+			constants.ItemMagazine: 7 * day,
+			constants.ItemDVD:      7 * day,
+		},
+		MaxConcurrentLoans: 5,
+	}
+
+	staff := RolePolicy{
+		LoanPeriods: map[constants.ItemType]time.Duration{
+			constants.ItemBook:      42 * day,
+			constants.ItemMagazine:  14 * day,
+			constants.ItemDVD:       14 * day,
+			constants.ItemReference: 7 * day,
+		},
+		MaxConcurrentLoans: 20,
+	}
+	circulationStaff := staff
+	circulationStaff.CanOverrideDueDate = true
+
+	return &CirculationPolicy{
+		Roles: map[string]RolePolicy{
+			string(constants.Member):     member,
+			string(constants.Admin):      circulationStaff,
+			string(constants.Librarian):  circulationStaff,
+			string(constants.Cataloguer): staff,
+		},
+		Default: member,
+	}
+}
+
+// For returns the lending terms of a role
+func (p *CirculationPolicy) For(role string) RolePolicy {
+	if rolePolicy, ok := p.Roles[role]; ok {
+		return rolePolicy
+	}
+	return p.Default
+}
+
+// DueDate computes when an item borrowed at borrowedAt has to be back
+func (p *CirculationPolicy) DueDate(role string, itemType constants.ItemType, borrowedAt time.Time) (time.Time, error) {
+	period, ok := p.For(role).LoanPeriods[itemType]
+	if !ok || period <= 0 {
+		return time.Time{}, constants.ErrItemNotLoanable
+	}
+	return borrowedAt.Add(period), nil
+}
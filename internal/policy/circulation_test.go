@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"library-management/internal/constants"
+	"testing"
+	"time"
+)
+
+func TestDueDate(t *testing.T) {
+	circulationPolicy := DefaultCirculationPolicy()
+	borrowedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		role        string
+		itemType    constants.ItemType
+		expected    time.Time
+		expectedErr error
+	}{
+		{"Member borrows a book", "member", constants.ItemBook, borrowedAt.Add(21 * day), nil},
+		{"Member borrows a DVD", "member", constants.ItemDVD, borrowedAt.Add(7 * day), nil},
+		{"Admin borrows a book", "admin", constants.ItemBook, borrowedAt.Add(42 * day), nil},
+		{"Reference items stay in the library for members", "member", constants.ItemReference, time.Time{}, constants.ErrItemNotLoanable},
+		{"Admins can take reference items out", "admin", constants.ItemReference, borrowedAt.Add(7 * day), nil},
+		{"Librarian borrows a book", "librarian", constants.ItemBook, borrowedAt.Add(42 * day), nil},
+		{"Cataloguer borrows a DVD", "cataloguer", constants.ItemDVD, borrowedAt.Add(14 * day), nil},
+		{"Unknown roles get the default terms", "guest", constants.ItemBook, borrowedAt.Add(21 * day), nil},
+		{"Unknown item types can't be borrowed", "member", constants.ItemType("map"), time.Time{}, constants.ErrItemNotLoanable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dueDate, err := circulationPolicy.DueDate(tc.role, tc.itemType, borrowedAt)
+			if err != tc.expectedErr || !dueDate.Equal(tc.expected) {
+				t.Errorf("Test case %s failed: expected %v (%v), got %v (%v)", tc.name, tc.expected, tc.expectedErr, dueDate, err)
+			}
+		})
+	}
+}
+
+func TestFor(t *testing.T) {
+	circulationPolicy := DefaultCirculationPolicy()
+
+	if circulationPolicy.For("member").CanOverrideDueDate {
+		t.Errorf("members must not be able to override the due date")
+	}
+	if !circulationPolicy.For("admin").CanOverrideDueDate {
+		t.Errorf("admins must be able to override the due date")
+	}
+	if !circulationPolicy.For("librarian").CanOverrideDueDate {
+		t.Errorf("librarians must be able to override the due date")
+	}
+	if circulationPolicy.For("cataloguer").CanOverrideDueDate {
+		t.Errorf("cataloguers must not be able to override the due date")
+	}
+	if got := circulationPolicy.For("guest").MaxConcurrentLoans; got != 5 {
+		t.Errorf("expected the default loan limit of 5, got %d", got)
+	}
+}
+
+func TestDefaultCirculationPolicy_SeededRoles(t *testing.T) {
+	circulationPolicy := DefaultCirculationPolicy()
+
+	// Staff roles falling back to the member terms would go unnoticed
+	for _, role := range constants.SeededRoles {
+		if _, ok := circulationPolicy.Roles[string(role)]; !ok {
+			t.Errorf("expected lending terms for the %s role", role)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Package ratelimit counts requests per key, such as a client IP, in memory.
+// Counts are per process, so each instance behind a load balancer keeps
+// its own.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows up to Limit requests per key in each Window. A key's
+// window starts with its first request.
+type Limiter struct {
+	Limit  int
+	Window time.Duration
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type window struct {
+	count   int
+	ends    time.Time
+	refused bool // whether a request was turned away in this window
+}
+
+// NewLimiter allows limit requests per key in every window
+func NewLimiter(limit int, per time.Duration) *Limiter {
+	return &Limiter{
+		Limit:   limit,
+		Window:  per,
+		windows: make(map[string]*window),
+		now:     time.Now,
+	}
+}
+
+// Allow counts a request for the key. When the key is over its limit, it
+// returns false and how long until the window ends.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.ends) {
+		w = &window{ends: now.Add(l.Window)}
+		l.windows[key] = w
+	}
+	if w.count >= l.Limit {
+		return false, w.ends.Sub(now)
+	}
+	w.count++
+	return true, 0
+}
+
+// Exceeded reports whether the key is over its limit, and how long until
+// its window ends, without counting a request
+func (l *Limiter) Exceeded(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	w := l.exceeded(key, now)
+	if w == nil {
+		return false, 0
+	}
+	return true, w.ends.Sub(now)
+}
+
+// Refuse is Exceeded for a request that is turned away when the key is
+// over its limit. It also reports whether this is the key's first refusal
+// in its window, so the block can be recorded once.
+func (l *Limiter) Refuse(key string) (refused, first bool, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	w := l.exceeded(key, now)
+	if w == nil {
+		return false, false, 0
+	}
+	first = !w.refused
+	w.refused = true
+	return true, first, w.ends.Sub(now)
+}
+
+// exceeded returns the key's window if the key is over its limit in it
+func (l *Limiter) exceeded(key string, now time.Time) *window {
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.ends) || w.count < l.Limit {
+		return nil
+	}
+	return w
+}
+
+// sweep forgets the windows that have ended, at most once per window, so
+// keys seen once don't pile up
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.Window {
+		return
+	}
+	for key, w := range l.windows {
+		if !now.Before(w.ends) {
+			delete(l.windows, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.Allow("10.0.0.1"); !ok {
+			t.Fatalf("request %d was refused", i+1)
+		}
+	}
+
+	now = now.Add(20 * time.Second)
+	ok, retryAfter := limiter.Allow("10.0.0.1")
+	if ok || retryAfter != 40*time.Second {
+		t.Errorf("third request: got %v, retry after %v; want refused, retry after 40s", ok, retryAfter)
+	}
+	if ok, _ := limiter.Allow("10.0.0.2"); !ok {
+		t.Error("another key was refused")
+	}
+
+	// A new window starts once the first one has ended
+	now = now.Add(40 * time.Second)
+	if ok, _ := limiter.Allow("10.0.0.1"); !ok {
+		t.Error("request in the next window was refused")
+	}
+}
+
+func TestLimiterExceeded(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	// Checking doesn't count
+	for i := 0; i < 3; i++ {
+		if exceeded, _ := limiter.Exceeded("10.0.0.1"); exceeded {
+			t.Fatal("unseen key is over its limit")
+		}
+	}
+
+	limiter.Allow("10.0.0.1")
+	limiter.Allow("10.0.0.1")
+	now = now.Add(15 * time.Second)
+	exceeded, retryAfter := limiter.Exceeded("10.0.0.1")
+	if !exceeded || retryAfter != 45*time.Second {
+		t.Errorf("got %v, retry after %v; want exceeded, retry after 45s", exceeded, retryAfter)
+	}
+
+	now = now.Add(45 * time.Second)
+	if exceeded, _ := limiter.Exceeded("10.0.0.1"); exceeded {
+		t.Error("key is still over its limit after its window ended")
+	}
+}
+
+func TestLimiterRefuse(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(1, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	if refused, _, _ := limiter.Refuse("10.0.0.1"); refused {
+		t.Fatal("unseen key was refused")
+	}
+
+	limiter.Allow("10.0.0.1")
+	refused, first, retryAfter := limiter.Refuse("10.0.0.1")
+	if !refused || !first || retryAfter != time.Minute {
+		t.Errorf("got %v, first %v, retry after %v; want the first refusal, retry after 1m", refused, first, retryAfter)
+	}
+	if refused, first, _ := limiter.Refuse("10.0.0.1"); !refused || first {
+		t.Errorf("got %v, first %v; want refused again, not for the first time", refused, first)
+	}
+
+	// Each window has its own first refusal
+	now = now.Add(time.Minute)
+	limiter.Allow("10.0.0.1")
+	if refused, first, _ := limiter.Refuse("10.0.0.1"); !refused || !first {
+		t.Errorf("got %v, first %v; want the first refusal of the next window", refused, first)
+	}
+}
+
+func TestLimiterForgetsEndedWindows(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(1, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow("10.0.0.1")
+	limiter.Allow("10.0.0.2")
+	now = now.Add(2 * time.Minute)
+	limiter.Allow("10.0.0.3")
+
+	if len(limiter.windows) != 1 {
+		t.Errorf("%d windows kept, want only the current one", len(limiter.windows))
+	}
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"library-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepositoryInterface interface {
+	Create(entry *models.AuditEntry) error
+}
+
+type AuditRepository struct {
+	DB *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepositoryInterface {
+	return &AuditRepository{DB: db}
+}
+
+// Create records an audit entry
+func (r *AuditRepository) Create(entry *models.AuditEntry) error {
+	return r.DB.Create(entry).Error
+}
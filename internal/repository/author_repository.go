@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AuthorRepositoryInterface interface {
+	Create(author *models.Author) (*models.Author, error)
+	GetByID(id uint) (*models.Author, error)
+	GetByName(name string) (*models.Author, error)
+	GetAll(name string, page, limit int) ([]models.Author, int64, error)
+	FindOrCreateByNames(names []string) ([]models.Author, error)
+	CountBooks(id uint) (int64, error)
+	Update(author *models.Author) error
+	Delete(id uint) error
+	WithTx(tx *gorm.DB) AuthorRepositoryInterface
+}
+
+type AuthorRepository struct {
+	DB *gorm.DB
+}
+
+func NewAuthorRepository(db *gorm.DB) AuthorRepositoryInterface {
+	return &AuthorRepository{DB: db}
+}
+
+// WithTx returns a repository bound to a transaction started elsewhere
+func (r *AuthorRepository) WithTx(tx *gorm.DB) AuthorRepositoryInterface {
+	return &AuthorRepository{DB: tx}
+}
+
+// Create Author
+func (r *AuthorRepository) Create(author *models.Author) (*models.Author, error) {
+	err := r.DB.Create(author).Error
+	return author, err
+}
+
+// Get Author by ID
+func (r *AuthorRepository) GetByID(id uint) (*models.Author, error) {
+	var author models.Author
+	err := r.DB.First(&author, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrAuthorNotFound
+	}
+	return &author, err
+}
+
+// Get Author by name, ignoring case
+func (r *AuthorRepository) GetByName(name string) (*models.Author, error) {
+	var author models.Author
+	err := r.DB.Where("LOWER(name) = LOWER(?)", name).First(&author).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrAuthorNotFound
+	}
+	return &author, err
+}
+
+// Get All Authors, alphabetically. A non-empty name only keeps the authors
+// whose name contains it.
+func (r *AuthorRepository) GetAll(name string, page, limit int) ([]models.Author, int64, error) {
+	var authors []models.Author
+	var total int64
+
+	query := r.DB.Model(&models.Author{})
+	if name != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+name+"%")
+	}
+
+	// Count total authors (without pagination)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Pagination logic
+	offset := (page - 1) * limit
+	if err := query.Order("name ASC, id ASC").Limit(limit).Offset(offset).Find(&authors).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return authors, total, nil
+}
+
+// FindOrCreateByNames returns the authors with the given names, in the same
+// order, creating the ones that don't exist yet.
+func (r *AuthorRepository) FindOrCreateByNames(names []string) ([]models.Author, error) {
+	authors := make([]models.Author, 0, len(names))
+	for _, name := range names {
+		author, err := r.GetByName(name)
+		if errors.Is(err, constants.ErrAuthorNotFound) {
+			author = &models.Author{Name: name}
+			result := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(author)
+			err = result.Error
+			// Someone else just created the same author, use theirs
+			if err == nil && result.RowsAffected == 0 {
+				author, err = r.GetByName(name)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, *author)
+	}
+	return authors, nil
+}
+
+// CountBooks counts the books the author is credited on
+func (r *AuthorRepository) CountBooks(id uint) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.BookAuthor{}).
+		Joins("JOIN books ON books.id = book_authors.book_id AND books.deleted_at IS NULL").
+		Where("book_authors.author_id = ?", id).
+		Count(&count).Error
+	return count, err
+}
+
+// Update Author
+func (r *AuthorRepository) Update(author *models.Author) error {
+	return r.DB.Save(author).Error
+}
+
+// Delete Author
+func (r *AuthorRepository) Delete(id uint) error {
+	return r.DB.Delete(&models.Author{}, id).Error
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// copyBarcodeIndexName is the unique index gorm creates for BookCopy.Barcode
+const copyBarcodeIndexName = "idx_book_copies_barcode"
+
+type BookCopyRepositoryInterface interface {
+	Create(bookCopy *models.BookCopy) error
+	GetByID(id uint) (*models.BookCopy, error)
+	GetByBarcode(barcode string) (*models.BookCopy, error)
+	GetByBookID(bookID uint) ([]models.BookCopy, error)
+	Update(bookCopy *models.BookCopy) error
+	UpdateStatus(bookCopy *models.BookCopy, from constants.CopyStatus) error
+	CheckOutAvailable(bookID uint) (*models.BookCopy, error)
+	Delete(id uint) error
+	WithTx(tx *gorm.DB) BookCopyRepositoryInterface
+}
+
+type BookCopyRepository struct {
+	DB *gorm.DB
+}
+
+func NewBookCopyRepository(db *gorm.DB) BookCopyRepositoryInterface {
+	return &BookCopyRepository{DB: db}
+}
+
+// WithTx returns a repository bound to a transaction started elsewhere
+func (r *BookCopyRepository) WithTx(tx *gorm.DB) BookCopyRepositoryInterface {
+	return &BookCopyRepository{DB: tx}
+}
+
+// Create Copy
+func (r *BookCopyRepository) Create(bookCopy *models.BookCopy) error {
+	err := r.DB.Create(bookCopy).Error
+	if isUniqueViolation(err, copyBarcodeIndexName) {
+		return constants.ErrBarcodeExists
+	}
+	return err
+}
+
+// Get Copy by ID, along with its book
+func (r *BookCopyRepository) GetByID(id uint) (*models.BookCopy, error) {
+	var bookCopy models.BookCopy
+	err := r.DB.Preload("Book", func(db *gorm.DB) *gorm.DB {
+		return db.Select(selectBookFields(nil))
+	}).First(&bookCopy, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrCopyNotFound
+	}
+	return &bookCopy, err
+}
+
+// Get Copy by barcode, along with its book
+func (r *BookCopyRepository) GetByBarcode(barcode string) (*models.BookCopy, error) {
+	var bookCopy models.BookCopy
+	err := r.DB.Preload("Book", func(db *gorm.DB) *gorm.DB {
+		return db.Select(selectBookFields(nil))
+	}).Where("barcode = ?", barcode).First(&bookCopy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrCopyNotFound
+	}
+	return &bookCopy, err
+}
+
+// Get all copies of a book
+func (r *BookCopyRepository) GetByBookID(bookID uint) ([]models.BookCopy, error) {
+	var copies []models.BookCopy
+	err := r.DB.Where("book_id = ?", bookID).Order("id ASC").Find(&copies).Error
+	return copies, err
+}
+
+// Update saves the copy's details. The status is left alone, see UpdateStatus.
+func (r *BookCopyRepository) Update(bookCopy *models.BookCopy) error {
+	return r.DB.Model(bookCopy).
+		Select("barcode", "location", "condition", "acquired_at").
+		Updates(bookCopy).Error
+}
+
+// UpdateStatus saves the copy's new status, but only if it is still in the
+// "from" status. This keeps concurrent checkouts, returns and holds from
+// acting on the same copy.
+func (r *BookCopyRepository) UpdateStatus(bookCopy *models.BookCopy, from constants.CopyStatus) error {
+	result := r.DB.Model(&models.BookCopy{}).
+		Where("id = ? AND status = ?", bookCopy.ID, from).
+		Update("status", bookCopy.Status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrCopyStatusChanged
+	}
+	return nil
+}
+
+// CheckOutAvailable takes one of the book's copies off the shelf and marks it
+// as on loan. A copy is only claimed if it is still available, so concurrent
+// checkouts never get the same copy; losing that race just moves on to the
+// next one.
+func (r *BookCopyRepository) CheckOutAvailable(bookID uint) (*models.BookCopy, error) {
+	for {
+		var bookCopy models.BookCopy
+		err := r.DB.Where("book_id = ? AND status = ?", bookID, constants.CopyAvailable).
+			Order("id ASC").First(&bookCopy).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrBookNotAvailable
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		bookCopy.Status = string(constants.CopyOnLoan)
+		err = r.UpdateStatus(&bookCopy, constants.CopyAvailable)
+		if errors.Is(err, constants.ErrCopyStatusChanged) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &bookCopy, nil
+	}
+}
+
+// Delete removes a copy, unless it is out with a member or set aside for one
+func (r *BookCopyRepository) Delete(id uint) error {
+	result := r.DB.Where("id = ? AND status NOT IN ?", id, []constants.CopyStatus{constants.CopyOnLoan, constants.CopyOnHold}).
+		Delete(&models.BookCopy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrCopyInCirculation
+	}
+	return nil
+}
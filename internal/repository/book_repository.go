@@ -3,22 +3,125 @@ package repository
 import (
 	"errors"
 	"library-management/internal/constants"
+	"library-management/internal/dto"
 	"library-management/internal/models"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-var defaultBookFields = []string{"id", "title", "author", "isbn", "copies_available", "published_at"}
+var defaultBookFields = []string{"id", "title", "author", "isbn", "copies_available", "published_at", "item_type", "cover_version", "cover_content_type"}
+
+// copiesAvailableColumn derives a book's availability from its copies on the shelf
+const copiesAvailableColumn = "(SELECT COUNT(*) FROM book_copies WHERE book_copies.book_id = books.id" +
+	" AND book_copies.status = 'available' AND book_copies.deleted_at IS NULL) AS copies_available"
+
+// BookSearchVector is the full-text document of a book, with the title
+// weighted above the author. The idx_books_search index of migration 0001
+// is built on the same expression, so the two must stay in sync.
+const BookSearchVector = "(setweight(to_tsvector('english', coalesce(title, '')), 'A') || " +
+	"setweight(to_tsvector('english', coalesce(author, '')), 'B'))"
+
+// bookISBNIndexName is the index keeping ISBNs unique among the books that
+// aren't deleted
+const bookISBNIndexName = "idx_books_isbn"
+
+// headlineOptions surrounds every matched term of a highlighted field with
+// models.HighlightStart and models.HighlightStop, which are chr(2) and chr(3)
+const headlineOptions = "'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', HighlightAll=true'"
+
+// hasAvailableCopy matches books with at least one copy on the shelf
+const hasAvailableCopy = "EXISTS (SELECT 1 FROM book_copies WHERE book_copies.book_id = books.id" +
+	" AND book_copies.status = 'available' AND book_copies.deleted_at IS NULL)"
+
+// bookSortColumns whitelists the columns book listings can be sorted by
+var bookSortColumns = map[constants.BookSortField]string{
+	constants.SortByTitle:       "title",
+	constants.SortByAuthor:      "author",
+	constants.SortByPublishedAt: "published_at",
+	constants.SortByCreatedAt:   "created_at",
+}
+
+// hasAuthorNamed and hasAuthorID match books credited to an author
+const (
+	hasAuthorNamed = "EXISTS (SELECT 1 FROM book_authors JOIN authors ON authors.id = book_authors.author_id" +
+		" AND authors.deleted_at IS NULL WHERE book_authors.book_id = books.id AND LOWER(authors.name) = LOWER(?))"
+	hasAuthorID = "EXISTS (SELECT 1 FROM book_authors WHERE book_authors.book_id = books.id AND book_authors.author_id = ?)"
+)
+
+// inCategorySubtree matches books filed under a category or any category below it
+const inCategorySubtree = "EXISTS (SELECT 1 FROM book_categories WHERE book_categories.book_id = books.id" +
+	" AND book_categories.category_id IN (" + categorySubtree + "))"
+
+// hasTagNamed matches books carrying a tag
+const hasTagNamed = "EXISTS (SELECT 1 FROM book_tags JOIN tags ON tags.id = book_tags.tag_id" +
+	" AND tags.deleted_at IS NULL WHERE book_tags.book_id = books.id AND LOWER(tags.name) = LOWER(?))"
+
+// bookFacets lists the facets of book listings: the tables to join to get
+// at a facet's values, the column each one groups by and, for facets over
+// entities, the column with their ID. A facet ignores its own filter, so
+// the other values stay visible while one is selected.
+var bookFacets = map[string]struct {
+	joins  string
+	column string
+	id     string
+	clear  func(filter *dto.BookFilter)
+}{
+	"author": {
+		joins: "JOIN book_authors ON book_authors.book_id = books.id" +
+			" JOIN authors ON authors.id = book_authors.author_id AND authors.deleted_at IS NULL",
+		column: "authors.name",
+		id:     "authors.id",
+		clear: func(filter *dto.BookFilter) {
+			filter.Author = ""
+			filter.AuthorID = 0
+		},
+	},
+	"item_type": {
+		column: "books.item_type",
+		clear:  func(filter *dto.BookFilter) { filter.ItemType = "" },
+	},
+}
+
+// selectBookFields returns the columns to select for the given book fields,
+// computing copies_available instead of reading it from the table.
+func selectBookFields(fields []string) []string {
+	// Use default fields if no specific fields are provided
+	if len(fields) == 0 {
+		fields = defaultBookFields
+	}
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		if field == "copies_available" {
+			columns[i] = copiesAvailableColumn
+		} else {
+			columns[i] = field
+		}
+	}
+	return columns
+}
 
 type BookRepositoryInterface interface {
+	BeginTransaction() (*gorm.DB, BookRepositoryInterface)
+	CommitTransaction(tx *gorm.DB) error
+	RollbackTransaction(tx *gorm.DB)
 	Create(book *models.Book) (*models.Book, error)
 	GetByID(id uint, fields []string) (*models.Book, error)
-	GetAll(page, limit int, fields []string) ([]models.Book, int64, error)
+	GetAll(filter dto.BookFilter, page, limit int, fields []string) ([]models.Book, int64, error)
+	GetAllInBatches(filter dto.BookFilter, size int, fn func(books []models.Book) error) error
+	GetFacets(filter dto.BookFilter, size int) (map[string][]models.BookFacetCount, error)
 	GetByISBN(isbn string) (*models.Book, error)
+	FindByISBN(isbn string) ([]models.Book, error)
+	Search(filter dto.BookFilter, page, limit int) ([]models.BookSearchResult, int64, error)
 	Update(book *models.Book) error
+	UpdateCover(book *models.Book) error
+	ReplaceAuthors(book *models.Book, authors []models.Author) error
+	ReplaceCategories(book *models.Book, categories []models.Category) error
+	ReplaceTags(book *models.Book, tags []models.Tag) error
+	RefreshAuthorNames(authorID uint) error
 	Delete(id uint) error
-	DecreaseBookCopies(bookID uint) error
-	IncreaseBookCopies(bookID uint) error
+	WithTx(tx *gorm.DB) BookRepositoryInterface
 }
 
 type BookRepository struct {
@@ -29,9 +132,37 @@ func NewBookRepository(db *gorm.DB) BookRepositoryInterface {
 	return &BookRepository{DB: db}
 }
 
-// Create Book
+func (r *BookRepository) BeginTransaction() (*gorm.DB, BookRepositoryInterface) {
+	tx := r.DB.Begin()
+	return tx, &BookRepository{DB: tx}
+}
+
+func (r *BookRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (r *BookRepository) RollbackTransaction(tx *gorm.DB) {
+	tx.Rollback()
+}
+
+// WithTx returns a repository bound to a transaction started elsewhere
+func (r *BookRepository) WithTx(tx *gorm.DB) BookRepositoryInterface {
+	return &BookRepository{DB: tx}
+}
+
+// Create Book, along with its copies and its links to authors, categories
+// and tags. Those must already exist.
 func (r *BookRepository) Create(book *models.Book) (*models.Book, error) {
-	err := r.DB.Create(book).Error
+	if len(book.BookAuthors) > 0 {
+		book.Author = authorNames(book.BookAuthors)
+	}
+	err := r.DB.Omit("BookAuthors.Author", "Categories.*", "Tags.*").Create(book).Error
+	switch {
+	case isUniqueViolation(err, bookISBNIndexName):
+		return book, constants.ErrISBNExists
+	case isUniqueViolation(err, copyBarcodeIndexName):
+		return book, constants.ErrBarcodeExists
+	}
 	return book, err
 }
 
@@ -41,34 +172,29 @@ func (r *BookRepository) GetByID(id uint, fields []string) (*models.Book, error)
 	// Start with a base query
 	query := r.DB.Model(&models.Book{})
 
-	// Use default fields if no specific fields are provided
-	if len(fields) == 0 {
-		fields = defaultBookFields
-	}
 	// Select specific fields
-	query = query.Select(fields)
+	query = query.Select(selectBookFields(fields))
 
 	err := query.First(&book, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, constants.ErrBookNotFound
 	}
-	return &book, err
+	if err != nil {
+		return nil, err
+	}
+	return &book, r.attachDetails(&book)
 }
 
-// Get All Books
-func (r *BookRepository) GetAll(page, limit int, fields []string) ([]models.Book, int64, error) {
+// Get All Books matching the filter
+func (r *BookRepository) GetAll(filter dto.BookFilter, page, limit int, fields []string) ([]models.Book, int64, error) {
 	var books []models.Book
 	var total int64
 
 	// Start with a base query
-	query := r.DB.Model(&models.Book{})
+	query := applyBookFilter(r.DB.Model(&models.Book{}), filter)
 
-	// Use default fields if no specific fields are provided
-	if len(fields) == 0 {
-		fields = defaultBookFields
-	}
 	// Select specific fields
-	query = query.Select(fields)
+	query = query.Select(selectBookFields(fields))
 
 	// Count total books (without pagination)
 	if err := query.Count(&total).Error; err != nil {
@@ -78,8 +204,12 @@ func (r *BookRepository) GetAll(page, limit int, fields []string) ([]models.Book
 	// Pagination logic
 	offset := (page - 1) * limit
 
-	// Fetch books with pagination and sorting
-	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&books).Error; err != nil {
+	// Fetch books with pagination and sorting, newest first by default
+	query = orderBooks(query, filter, "created_at DESC")
+	if err := query.Limit(limit).Offset(offset).Find(&books).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := r.attachDetails(bookPointers(books)...); err != nil {
 		return nil, 0, err
 	}
 
@@ -96,9 +226,219 @@ func (r *BookRepository) GetByISBN(isbn string) (*models.Book, error) {
 	return &book, err
 }
 
-// Update Book
+// FindByISBN finds books by ISBN. The ISBN has to be normalized the way
+// ISBNs are stored, so the lookup can use idx_books_isbn.
+func (r *BookRepository) FindByISBN(isbn string) ([]models.Book, error) {
+	var books []models.Book
+	err := r.DB.Model(&models.Book{}).
+		Select(selectBookFields(nil)).
+		Where("isbn = ?", isbn).
+		Order("id ASC").
+		Find(&books).Error
+	if err != nil {
+		return nil, err
+	}
+	return books, r.attachDetails(bookPointers(books)...)
+}
+
+// Search runs a full-text search over the title and author of the books
+// matching the filter. Results are ranked by relevance unless the filter
+// sorts them otherwise, with the matched terms highlighted.
+func (r *BookRepository) Search(filter dto.BookFilter, page, limit int) ([]models.BookSearchResult, int64, error) {
+	var results []models.BookSearchResult
+	var total int64
+
+	// Count total matches (without pagination)
+	if err := applyBookFilter(r.DB.Model(&models.Book{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Pagination logic
+	offset := (page - 1) * limit
+
+	columns := append(selectBookFields(nil),
+		"ts_rank("+BookSearchVector+", query) AS rank",
+		"ts_headline('english', title, query, "+headlineOptions+") AS title_highlight",
+		"ts_headline('english', author, query, "+headlineOptions+") AS author_highlight",
+	)
+	query := applyBookFilter(r.DB.Model(&models.Book{}), filter).
+		Joins("CROSS JOIN websearch_to_tsquery('english', ?) AS query", filter.Query).
+		Select(columns)
+	err := orderBooks(query, filter, "rank DESC, id ASC").
+		Limit(limit).Offset(offset).
+		Find(&results).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	books := make([]*models.Book, len(results))
+	for i := range results {
+		books[i] = &results[i].Book
+	}
+	if err := r.attachDetails(books...); err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// GetAllInBatches loads the books matching the filter a batch at a time, in
+// ID order, and hands each batch to fn with its details attached. It is for
+// going through more books than fit in memory at once.
+func (r *BookRepository) GetAllInBatches(filter dto.BookFilter, size int, fn func(books []models.Book) error) error {
+	var books []models.Book
+	query := applyBookFilter(r.DB.Model(&models.Book{}), filter).Select(selectBookFields(nil))
+	return query.FindInBatches(&books, size, func(tx *gorm.DB, batch int) error {
+		if err := r.attachDetails(bookPointers(books)...); err != nil {
+			return err
+		}
+		return fn(books)
+	}).Error
+}
+
+// GetFacets counts the books matching the filter per value of each facet,
+// keeping the most common values of each.
+func (r *BookRepository) GetFacets(filter dto.BookFilter, size int) (map[string][]models.BookFacetCount, error) {
+	facets := make(map[string][]models.BookFacetCount, len(bookFacets))
+	for name, facet := range bookFacets {
+		facetFilter := filter
+		facet.clear(&facetFilter)
+
+		columns := facet.column + " AS value"
+		group := facet.column
+		if facet.id != "" {
+			columns += ", " + facet.id + " AS id"
+			group += ", " + facet.id
+		}
+
+		var counts []models.BookFacetCount
+		query := applyBookFilter(r.DB.Model(&models.Book{}), facetFilter)
+		if facet.joins != "" {
+			query = query.Joins(facet.joins)
+		}
+		err := query.
+			Select(columns + ", COUNT(*) AS count, SUM(CASE WHEN " + hasAvailableCopy + " THEN 1 ELSE 0 END) AS available").
+			Group(group).
+			Order("count DESC, value ASC").
+			Limit(size).
+			Scan(&counts).Error
+		if err != nil {
+			return nil, err
+		}
+		facets[name] = counts
+	}
+	return facets, nil
+}
+
+// Update Book. Its authors are changed with ReplaceAuthors.
 func (r *BookRepository) Update(book *models.Book) error {
-	return r.DB.Save(book).Error
+	err := r.DB.Omit(clause.Associations).Save(book).Error
+	if isUniqueViolation(err, bookISBNIndexName) {
+		return constants.ErrISBNExists
+	}
+	return err
+}
+
+// UpdateCover saves the cover of the book, leaving its other fields alone
+func (r *BookRepository) UpdateCover(book *models.Book) error {
+	return r.DB.Model(book).Select("cover_version", "cover_content_type").Updates(book).Error
+}
+
+// ReplaceAuthors credits the book to the given authors, in that order,
+// instead of its current ones and updates its author names to match.
+func (r *BookRepository) ReplaceAuthors(book *models.Book, authors []models.Author) error {
+	bookAuthors := make([]models.BookAuthor, len(authors))
+	for i, author := range authors {
+		bookAuthors[i] = models.BookAuthor{BookID: book.ID, AuthorID: author.ID, Position: i, Author: author}
+	}
+	names := authorNames(bookAuthors)
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("book_id = ?", book.ID).Delete(&models.BookAuthor{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Author").Create(&bookAuthors).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Book{}).Where("id = ?", book.ID).UpdateColumn("author", names).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	book.BookAuthors = bookAuthors
+	book.Author = names
+	return nil
+}
+
+// ReplaceCategories files the book under the given categories instead of its current ones
+func (r *BookRepository) ReplaceCategories(book *models.Book, categories []models.Category) error {
+	links := make([]map[string]interface{}, len(categories))
+	for i, category := range categories {
+		links[i] = map[string]interface{}{"book_id": book.ID, "category_id": category.ID}
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_categories WHERE book_id = ?", book.ID).Error; err != nil {
+			return err
+		}
+		if len(links) == 0 {
+			return nil
+		}
+		return tx.Table("book_categories").Create(links).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	book.Categories = categories
+	return nil
+}
+
+// ReplaceTags gives the book the given tags instead of its current ones
+func (r *BookRepository) ReplaceTags(book *models.Book, tags []models.Tag) error {
+	links := make([]map[string]interface{}, len(tags))
+	for i, tag := range tags {
+		links[i] = map[string]interface{}{"book_id": book.ID, "tag_id": tag.ID}
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_tags WHERE book_id = ?", book.ID).Error; err != nil {
+			return err
+		}
+		if len(links) == 0 {
+			return nil
+		}
+		return tx.Table("book_tags").Create(links).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	book.Tags = tags
+	return nil
+}
+
+// RefreshAuthorNames rewrites the author names of every book credited to
+// the author, e.g. after it was renamed
+func (r *BookRepository) RefreshAuthorNames(authorID uint) error {
+	var books []models.Book
+	err := r.DB.Model(&models.Book{}).Select("id").
+		Where("id IN (?)", r.DB.Model(&models.BookAuthor{}).Select("book_id").Where("author_id = ?", authorID)).
+		Find(&books).Error
+	if err != nil {
+		return err
+	}
+	if err := r.attachAuthors(bookPointers(books)...); err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		err := r.DB.Model(&models.Book{}).Where("id = ?", book.ID).
+			UpdateColumn("author", authorNames(book.BookAuthors)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Delete Book
@@ -106,24 +446,132 @@ func (r *BookRepository) Delete(id uint) error {
 	return r.DB.Delete(&models.Book{}, id).Error
 }
 
-func (r *BookRepository) DecreaseBookCopies(bookID uint) error {
-	book, err := r.GetByID(bookID, nil)
+// applyBookFilter narrows a books query down to the filter
+func applyBookFilter(query *gorm.DB, filter dto.BookFilter) *gorm.DB {
+	if filter.Query != "" {
+		// websearch_to_tsquery accepts what users type in a search box:
+		// plain words, "quoted phrases", "or" and -excluded words
+		query = query.Where(BookSearchVector+" @@ websearch_to_tsquery('english', ?)", filter.Query)
+	}
+	if filter.Author != "" {
+		query = query.Where(hasAuthorNamed, filter.Author)
+	}
+	if filter.AuthorID != 0 {
+		query = query.Where(hasAuthorID, filter.AuthorID)
+	}
+	if filter.CategoryID != 0 {
+		query = query.Where(inCategorySubtree, filter.CategoryID)
+	}
+	if filter.Tag != "" {
+		query = query.Where(hasTagNamed, filter.Tag)
+	}
+	if filter.ItemType != "" {
+		query = query.Where("item_type = ?", filter.ItemType)
+	}
+	if filter.PublishedFrom != nil {
+		query = query.Where("published_at >= ?", *filter.PublishedFrom)
+	}
+	if filter.PublishedUntil != nil {
+		query = query.Where("published_at < ?", *filter.PublishedUntil)
+	}
+	if filter.AvailableOnly {
+		query = query.Where(hasAvailableCopy)
+	}
+	return query
+}
+
+// orderBooks sorts a books query by the filter's sort field, or by the
+// fallback order when it has none (or sorts by relevance).
+func orderBooks(query *gorm.DB, filter dto.BookFilter, fallback string) *gorm.DB {
+	column, ok := bookSortColumns[filter.Sort]
+	if !ok {
+		return query.Order(fallback)
+	}
+	return query.
+		Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: filter.SortDesc}).
+		Order("id ASC")
+}
+
+// attachDetails loads the authors, categories and tags of the books
+func (r *BookRepository) attachDetails(books ...*models.Book) error {
+	if err := r.attachAuthors(books...); err != nil {
+		return err
+	}
+	if len(books) == 0 {
+		return nil
+	}
+
+	byID := make(map[uint]*models.Book, len(books))
+	ids := make([]uint, 0, len(books))
+	for _, book := range books {
+		book.Categories = []models.Category{}
+		book.Tags = []models.Tag{}
+		byID[book.ID] = book
+		ids = append(ids, book.ID)
+	}
+
+	var loaded []models.Book
+	byName := func(db *gorm.DB) *gorm.DB {
+		return db.Order("name ASC")
+	}
+	err := r.DB.Select("id").
+		Preload("Categories", byName).
+		Preload("Tags", byName).
+		Find(&loaded, ids).Error
 	if err != nil {
 		return err
 	}
-	// if book.CopiesAvailable < copies {
-	//     return errors.New("not enough copies available")
-	// }
-	book.CopiesAvailable -= 1
-	return r.DB.Save(&book).Error
+	for _, book := range loaded {
+		byID[book.ID].Categories = book.Categories
+		byID[book.ID].Tags = book.Tags
+	}
+	return nil
 }
 
-func (r *BookRepository) IncreaseBookCopies(bookID uint) error {
-	book, err := r.GetByID(bookID, nil)
-	if err != nil {
-		// do nothing (in case the book was deleted)
+// attachAuthors loads the authors of the books, in credit order
+func (r *BookRepository) attachAuthors(books ...*models.Book) error {
+	if len(books) == 0 {
 		return nil
 	}
-	book.CopiesAvailable += 1
-	return r.DB.Save(&book).Error
+	byID := make(map[uint]*models.Book, len(books))
+	for _, book := range books {
+		book.BookAuthors = []models.BookAuthor{}
+		byID[book.ID] = book
+	}
+	ids := make([]uint, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+
+	var bookAuthors []models.BookAuthor
+	err := r.DB.Preload("Author").
+		Where("book_id IN ?", ids).
+		Order("book_id ASC, position ASC").
+		Find(&bookAuthors).Error
+	if err != nil {
+		return err
+	}
+	for _, bookAuthor := range bookAuthors {
+		book := byID[bookAuthor.BookID]
+		book.BookAuthors = append(book.BookAuthors, bookAuthor)
+	}
+	return nil
+}
+
+// bookPointers returns pointers to the books of a slice, to fill them in place
+func bookPointers(books []models.Book) []*models.Book {
+	pointers := make([]*models.Book, len(books))
+	for i := range books {
+		pointers[i] = &books[i]
+	}
+	return pointers
+}
+
+// authorNames joins the names of a book's authors for display
+func authorNames(bookAuthors []models.BookAuthor) string {
+	names := make([]string, len(bookAuthors))
+	for i, bookAuthor := range bookAuthors {
+		names[i] = bookAuthor.Author.Name
+	}
+	return strings.Join(names, ", ")
 }
@@ -2,20 +2,26 @@ package repository
 
 import (
 	"library-management/internal/constants"
+	"library-management/internal/dto"
 	"library-management/internal/models"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type BorrowRepositoryInterface interface {
 	BeginTransaction() (*gorm.DB, BorrowRepositoryInterface)
-	CommitTransaction(tx *gorm.DB)
+	CommitTransaction(tx *gorm.DB) error
 	RollbackTransaction(tx *gorm.DB)
 	Create(borrow *models.Borrow) error
-	GetAll(page, limit int) ([]models.Borrow, int64, error)
+	GetAll(filter dto.BorrowFilter, page, limit int) ([]models.Borrow, int64, error)
 	GetBorrowRecord(userID, bookID uint) (*models.Borrow, error)
-	Delete(borrow *models.Borrow) error
-	GetBorrowsByUserID(userID uint, page, limit int) ([]models.Borrow, int64, error)
+	GetByID(id uint) (*models.Borrow, error)
+	Renew(borrow *models.Borrow, dueDate time.Time) error
+	MarkReturned(borrow *models.Borrow) error
+	CreateRenewal(renewal *models.BorrowRenewal) error
+	CountActiveLoans(userID uint) (int64, error)
+	HasActiveLoan(userID, bookID uint) (bool, error)
 }
 
 type BorrowRepository struct {
@@ -31,8 +37,8 @@ func (r *BorrowRepository) BeginTransaction() (*gorm.DB, BorrowRepositoryInterfa
 	return tx, &BorrowRepository{DB: tx} // Return a new repository instance using the transaction
 }
 
-func (r *BorrowRepository) CommitTransaction(tx *gorm.DB) {
-	tx.Commit()
+func (r *BorrowRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
 }
 
 func (r *BorrowRepository) RollbackTransaction(tx *gorm.DB) {
@@ -44,28 +50,46 @@ func (r *BorrowRepository) Create(borrow *models.Borrow) error {
 	return r.DB.Create(borrow).Error
 }
 
-// Get All Borrows
-func (r *BorrowRepository) GetAll(page, limit int) ([]models.Borrow, int64, error) {
+// Get All Borrows matching the filter, newest first
+func (r *BorrowRepository) GetAll(filter dto.BorrowFilter, page, limit int) ([]models.Borrow, int64, error) {
 	var borrows []models.Borrow
 	var total int64
 
+	query := applyBorrowFilter(r.DB.Model(&models.Borrow{}), filter)
+
 	// Count total borrows
-	r.DB.Model(&models.Borrow{}).Count(&total)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
 
 	// Pagination logic
 	offset := (page - 1) * limit
 
 	// Fetch borrows with pagination and sorting
-	err := r.DB.Order("created_at DESC").Limit(limit).Offset(offset).Preload("Book").
-		Preload("User").Find(&borrows).Error
-	if err != nil {
+	query = query.Order("created_at DESC").Limit(limit).Offset(offset).
+		Preload("Renewals", func(db *gorm.DB) *gorm.DB {
+			return db.Order("renewed_at ASC")
+		}).
+		Preload("Book", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Select(selectBookFields(nil)) // This ensures soft-deleted books are included
+		}).
+		Preload("Copy", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		})
+	// The user is only interesting when the records are not already scoped to one
+	if filter.UserID == 0 {
+		query = query.Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		})
+	}
+	if err := query.Find(&borrows).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return borrows, total, nil
 }
 
-// Get a borrow record by UserID and BookID
+// Get a borrow record by UserID and BorrowID
 func (r *BorrowRepository) GetBorrowRecord(userID, BorrowID uint) (*models.Borrow, error) {
 	var borrow models.Borrow
 	err := r.DB.Where("user_id = ? AND id = ?", userID, BorrowID).First(&borrow).Error
@@ -75,34 +99,88 @@ func (r *BorrowRepository) GetBorrowRecord(userID, BorrowID uint) (*models.Borro
 	return &borrow, nil
 }
 
-// Delete a borrow record when a book is returned
-func (r *BorrowRepository) Delete(borrow *models.Borrow) error {
-	return r.DB.Delete(borrow).Error
+// Get a borrow record by ID, whoever the borrower
+func (r *BorrowRepository) GetByID(id uint) (*models.Borrow, error) {
+	var borrow models.Borrow
+	if err := r.DB.First(&borrow, id).Error; err != nil {
+		return nil, constants.ErrBorrowNotFound
+	}
+	return &borrow, nil
 }
 
-// GetBorrowsByUserID retrieves borrow records for a specific user
-func (r *BorrowRepository) GetBorrowsByUserID(userID uint, page, limit int) ([]models.Borrow, int64, error) {
-	var borrows []models.Borrow
-	var total int64
+// Renew moves the due date of a loan and counts the renewal, if the loan
+// is still out and wasn't renewed since it was read. Otherwise it returns
+// ErrLoanChanged, so concurrent renewals can't go over the limit and a
+// return isn't undone.
+func (r *BorrowRepository) Renew(borrow *models.Borrow, dueDate time.Time) error {
+	result := r.DB.Model(&models.Borrow{}).
+		Where("id = ? AND returned = ? AND renewal_count = ?", borrow.ID, false, borrow.RenewalCount).
+		Updates(map[string]interface{}{
+			"due_date":      dueDate,
+			"renewal_count": gorm.Expr("renewal_count + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrLoanChanged
+	}
+	return nil
+}
 
-	// Count total borrows for the user
-	if err := r.DB.Model(&models.Borrow{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
-		return nil, 0, err
+// MarkReturned closes a loan. Only one of several concurrent returns of the
+// same loan succeeds, the others get ErrAlreadyReturned.
+func (r *BorrowRepository) MarkReturned(borrow *models.Borrow) error {
+	result := r.DB.Model(&models.Borrow{}).
+		Where("id = ? AND returned = ?", borrow.ID, false).
+		Updates(map[string]interface{}{
+			"returned":    true,
+			"returned_at": borrow.ReturnedAt,
+			"returned_by": borrow.ReturnedBy,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrAlreadyReturned
 	}
+	return nil
+}
 
-	// Fetch borrows with pagination and preload book and user details
-	offset := (page - 1) * limit
-	err := r.DB.Where("user_id = ?", userID).
-		Offset(offset).
-		Limit(limit).
-		Preload("Book", func(db *gorm.DB) *gorm.DB {
-			return db.Unscoped() // This ensures soft-deleted books are included
-		}).
-		// Preload("User").
-		Find(&borrows).Error
-	if err != nil {
-		return nil, 0, err
+// CreateRenewal records an extension of a loan
+func (r *BorrowRepository) CreateRenewal(renewal *models.BorrowRenewal) error {
+	return r.DB.Create(renewal).Error
+}
+
+// CountActiveLoans counts the books the user currently has out
+func (r *BorrowRepository) CountActiveLoans(userID uint) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.Borrow{}).Where("user_id = ? AND returned = ?", userID, false).Count(&count).Error
+	return count, err
+}
+
+// HasActiveLoan reports whether the user currently has a copy of the book out
+func (r *BorrowRepository) HasActiveLoan(userID, bookID uint) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.Borrow{}).Where("user_id = ? AND book_id = ? AND returned = ?", userID, bookID, false).Count(&count).Error
+	return count > 0, err
+}
+
+func applyBorrowFilter(query *gorm.DB, filter dto.BorrowFilter) *gorm.DB {
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.BookID != 0 {
+		query = query.Where("book_id = ?", filter.BookID)
 	}
 
-	return borrows, total, nil
+	switch filter.Status {
+	case constants.BorrowActive:
+		query = query.Where("returned = ?", false)
+	case constants.BorrowReturned:
+		query = query.Where("returned = ?", true)
+	case constants.BorrowOverdue:
+		query = query.Where("returned = ? AND due_date < ?", false, time.Now())
+	}
+	return query
 }
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// categorySubtree selects the IDs of a category and of all the categories
+// below it
+const categorySubtree = "WITH RECURSIVE subtree(id) AS (" +
+	"SELECT id FROM categories WHERE id = ? AND deleted_at IS NULL" +
+	" UNION SELECT categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id" +
+	" WHERE categories.deleted_at IS NULL" +
+	") SELECT id FROM subtree"
+
+type CategoryRepositoryInterface interface {
+	Create(category *models.Category) (*models.Category, error)
+	GetByID(id uint) (*models.Category, error)
+	GetByIDs(ids []uint) ([]models.Category, error)
+	GetByName(parentID *uint, name string) (*models.Category, error)
+	GetAll() ([]models.Category, error)
+	GetSubtreeIDs(id uint) ([]uint, error)
+	CountChildren(id uint) (int64, error)
+	CountBooks(id uint) (int64, error)
+	Update(category *models.Category) error
+	Delete(id uint) error
+}
+
+type CategoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepositoryInterface {
+	return &CategoryRepository{DB: db}
+}
+
+// Create Category
+func (r *CategoryRepository) Create(category *models.Category) (*models.Category, error) {
+	err := r.DB.Omit(clause.Associations).Create(category).Error
+	return category, err
+}
+
+// Get Category by ID
+func (r *CategoryRepository) GetByID(id uint) (*models.Category, error) {
+	var category models.Category
+	err := r.DB.First(&category, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrCategoryNotFound
+	}
+	return &category, err
+}
+
+// Get Categories by ID. Unknown IDs are left out.
+func (r *CategoryRepository) GetByIDs(ids []uint) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.DB.Where("id IN ?", ids).Order("name ASC").Find(&categories).Error
+	return categories, err
+}
+
+// Get Category by name, ignoring case, among the children of a parent
+// (or the top-level categories when the parent is nil)
+func (r *CategoryRepository) GetByName(parentID *uint, name string) (*models.Category, error) {
+	var category models.Category
+	query := r.DB.Where("LOWER(name) = LOWER(?)", name)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+	err := query.First(&category).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrCategoryNotFound
+	}
+	return &category, err
+}
+
+// Get All Categories, alphabetically
+func (r *CategoryRepository) GetAll() ([]models.Category, error) {
+	var categories []models.Category
+	err := r.DB.Order("name ASC, id ASC").Find(&categories).Error
+	return categories, err
+}
+
+// GetSubtreeIDs returns the IDs of the category and of all its descendants
+func (r *CategoryRepository) GetSubtreeIDs(id uint) ([]uint, error) {
+	var ids []uint
+	err := r.DB.Raw(categorySubtree, id).Scan(&ids).Error
+	return ids, err
+}
+
+// CountChildren counts the direct subcategories of a category
+func (r *CategoryRepository) CountChildren(id uint) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// CountBooks counts the books filed directly under a category
+func (r *CategoryRepository) CountBooks(id uint) (int64, error) {
+	var count int64
+	err := r.DB.Table("book_categories").
+		Joins("JOIN books ON books.id = book_categories.book_id AND books.deleted_at IS NULL").
+		Where("book_categories.category_id = ?", id).
+		Count(&count).Error
+	return count, err
+}
+
+// Update Category
+func (r *CategoryRepository) Update(category *models.Category) error {
+	return r.DB.Omit(clause.Associations).Save(category).Error
+}
+
+// Delete Category
+func (r *CategoryRepository) Delete(id uint) error {
+	return r.DB.Delete(&models.Category{}, id).Error
+}
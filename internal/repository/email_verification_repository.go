@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EmailVerificationRepositoryInterface interface {
+	BeginTransaction() (*gorm.DB, EmailVerificationRepositoryInterface)
+	CommitTransaction(tx *gorm.DB) error
+	RollbackTransaction(tx *gorm.DB)
+	Create(token *models.EmailVerificationToken) error
+	GetByHash(hash string) (*models.EmailVerificationToken, error)
+	Use(token *models.EmailVerificationToken) error
+	InvalidateForUser(userID uint) error
+	CountSince(userID uint, since time.Time) (int64, error)
+}
+
+type EmailVerificationRepository struct {
+	DB *gorm.DB
+}
+
+func NewEmailVerificationRepository(db *gorm.DB) EmailVerificationRepositoryInterface {
+	return &EmailVerificationRepository{DB: db}
+}
+
+func (r *EmailVerificationRepository) BeginTransaction() (*gorm.DB, EmailVerificationRepositoryInterface) {
+	tx := r.DB.Begin()
+	return tx, &EmailVerificationRepository{DB: tx}
+}
+
+func (r *EmailVerificationRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (r *EmailVerificationRepository) RollbackTransaction(tx *gorm.DB) {
+	tx.Rollback()
+}
+
+// Create a new email verification token
+func (r *EmailVerificationRepository) Create(token *models.EmailVerificationToken) error {
+	return r.DB.Create(token).Error
+}
+
+// Get an email verification token by the hash of its value
+func (r *EmailVerificationRepository) GetByHash(hash string) (*models.EmailVerificationToken, error) {
+	var token models.EmailVerificationToken
+	err := r.DB.Where("token_hash = ?", hash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrInvalidVerificationToken
+	}
+	return &token, err
+}
+
+// Use marks a token used, if it is still unused and unexpired. Of several
+// concurrent uses of the same token only one succeeds, the others get
+// ErrInvalidVerificationToken.
+func (r *EmailVerificationRepository) Use(token *models.EmailVerificationToken) error {
+	now := time.Now()
+	result := r.DB.Model(&models.EmailVerificationToken{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", token.ID, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrInvalidVerificationToken
+	}
+	token.UsedAt = &now
+	return nil
+}
+
+// InvalidateForUser stops the unused tokens of a user from working, so
+// only the latest link sent to them does
+func (r *EmailVerificationRepository) InvalidateForUser(userID uint) error {
+	return r.DB.Model(&models.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
+
+// CountSince counts the tokens issued to a user since a point in time
+func (r *EmailVerificationRepository) CountSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.EmailVerificationToken{}).
+		Where("user_id = ? AND created_at > ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// pgUniqueViolation is the PostgreSQL error code of unique constraint violations
+const pgUniqueViolation = "23505"
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint
+// violation on the given index
+func isUniqueViolation(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == index
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FineRepositoryInterface interface {
+	BeginTransaction() (*gorm.DB, FineRepositoryInterface)
+	CommitTransaction(tx *gorm.DB) error
+	RollbackTransaction(tx *gorm.DB)
+	WithTx(tx *gorm.DB) FineRepositoryInterface
+	Create(fine *models.Fine) error
+	GetByID(id uint) (*models.Fine, error)
+	GetByIDForUpdate(id uint) (*models.Fine, error)
+	GetAll(filter dto.FineFilter, page, limit int) ([]models.Fine, int64, error)
+	GetOutstandingBalance(userID uint) (int64, error)
+	Update(fine *models.Fine) error
+	CreateTransaction(transaction *models.FineTransaction) error
+}
+
+type FineRepository struct {
+	DB *gorm.DB
+}
+
+func NewFineRepository(db *gorm.DB) FineRepositoryInterface {
+	return &FineRepository{DB: db}
+}
+
+func (r *FineRepository) BeginTransaction() (*gorm.DB, FineRepositoryInterface) {
+	tx := r.DB.Begin()
+	return tx, &FineRepository{DB: tx}
+}
+
+func (r *FineRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (r *FineRepository) RollbackTransaction(tx *gorm.DB) {
+	tx.Rollback()
+}
+
+// WithTx returns a repository bound to a transaction started elsewhere
+func (r *FineRepository) WithTx(tx *gorm.DB) FineRepositoryInterface {
+	return &FineRepository{DB: tx}
+}
+
+// Create a new fine
+func (r *FineRepository) Create(fine *models.Fine) error {
+	return r.DB.Create(fine).Error
+}
+
+// Get Fine by ID along with its ledger
+func (r *FineRepository) GetByID(id uint) (*models.Fine, error) {
+	var fine models.Fine
+	err := r.DB.Preload("Transactions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&fine, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrFineNotFound
+	}
+	return &fine, err
+}
+
+// GetByIDForUpdate gets a fine with its ledger and locks it until the end
+// of the transaction, so concurrent payments and waivers of the same fine
+// take turns
+func (r *FineRepository) GetByIDForUpdate(id uint) (*models.Fine, error) {
+	var fine models.Fine
+	err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Transactions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).First(&fine, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrFineNotFound
+	}
+	return &fine, err
+}
+
+// Get All Fines matching the filter, newest first
+func (r *FineRepository) GetAll(filter dto.FineFilter, page, limit int) ([]models.Fine, int64, error) {
+	var fines []models.Fine
+	var total int64
+
+	query := r.DB.Model(&models.Fine{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	query = query.Order("created_at DESC").Limit(limit).Offset(offset).
+		Preload("Transactions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		})
+	if filter.UserID == 0 {
+		query = query.Preload("User")
+	}
+	if err := query.Find(&fines).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return fines, total, nil
+}
+
+// GetOutstandingBalance sums what the user still owes across all unpaid fines
+func (r *FineRepository) GetOutstandingBalance(userID uint) (int64, error) {
+	var balance int64
+	err := r.DB.Model(&models.Fine{}).
+		Where("user_id = ? AND status = ?", userID, constants.FineUnpaid).
+		Select("COALESCE(SUM(amount - amount_paid - amount_waived), 0)").
+		Scan(&balance).Error
+	return balance, err
+}
+
+// Update Fine
+func (r *FineRepository) Update(fine *models.Fine) error {
+	return r.DB.Omit("Transactions").Save(fine).Error
+}
+
+// CreateTransaction adds an entry to the fines ledger
+func (r *FineRepository) CreateTransaction(transaction *models.FineTransaction) error {
+	return r.DB.Create(transaction).Error
+}
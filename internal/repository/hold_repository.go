@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// holdActiveIndexName is the unique index keeping a member to one active
+// hold per book
+const holdActiveIndexName = "idx_holds_active_user_book"
+
+type HoldRepositoryInterface interface {
+	BeginTransaction() (*gorm.DB, HoldRepositoryInterface)
+	CommitTransaction(tx *gorm.DB) error
+	RollbackTransaction(tx *gorm.DB)
+	WithTx(tx *gorm.DB) HoldRepositoryInterface
+	Create(hold *models.Hold) error
+	GetByID(id uint) (*models.Hold, error)
+	GetAll(filter dto.HoldFilter, page, limit int) ([]models.Hold, int64, error)
+	GetActiveHold(userID, bookID uint) (*models.Hold, error)
+	GetNextPending(bookID uint) (*models.Hold, error)
+	GetExpiredReady(now time.Time) ([]models.Hold, error)
+	GetQueuePosition(hold *models.Hold) (int64, error)
+	CountActiveHolds(bookID, excludeUserID uint) (int64, error)
+	UpdateStatus(hold *models.Hold, from constants.HoldStatus) error
+}
+
+type HoldRepository struct {
+	DB *gorm.DB
+}
+
+func NewHoldRepository(db *gorm.DB) HoldRepositoryInterface {
+	return &HoldRepository{DB: db}
+}
+
+func (r *HoldRepository) BeginTransaction() (*gorm.DB, HoldRepositoryInterface) {
+	tx := r.DB.Begin()
+	return tx, &HoldRepository{DB: tx}
+}
+
+func (r *HoldRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (r *HoldRepository) RollbackTransaction(tx *gorm.DB) {
+	tx.Rollback()
+}
+
+// WithTx returns a repository bound to a transaction started elsewhere
+func (r *HoldRepository) WithTx(tx *gorm.DB) HoldRepositoryInterface {
+	return &HoldRepository{DB: tx}
+}
+
+// Create a new hold
+func (r *HoldRepository) Create(hold *models.Hold) error {
+	err := r.DB.Create(hold).Error
+	if isUniqueViolation(err, holdActiveIndexName) {
+		return constants.ErrHoldExists
+	}
+	return err
+}
+
+// Get Hold by ID
+func (r *HoldRepository) GetByID(id uint) (*models.Hold, error) {
+	var hold models.Hold
+	err := r.DB.First(&hold, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrHoldNotFound
+	}
+	return &hold, err
+}
+
+// Get All Holds matching the filter, oldest first so queues read in order
+func (r *HoldRepository) GetAll(filter dto.HoldFilter, page, limit int) ([]models.Hold, int64, error) {
+	var holds []models.Hold
+	var total int64
+
+	query := r.DB.Model(&models.Hold{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.BookID != 0 {
+		query = query.Where("book_id = ?", filter.BookID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("status IN ?", []constants.HoldStatus{constants.HoldPending, constants.HoldReady})
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	query = query.Order("id ASC").Limit(limit).Offset(offset).
+		Preload("Book", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Select(selectBookFields(nil))
+		})
+	if filter.UserID == 0 {
+		query = query.Preload("User")
+	}
+	if err := query.Find(&holds).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return holds, total, nil
+}
+
+// GetActiveHold returns the user's pending or ready hold on a book
+func (r *HoldRepository) GetActiveHold(userID, bookID uint) (*models.Hold, error) {
+	var hold models.Hold
+	err := r.DB.Where("user_id = ? AND book_id = ? AND status IN ?", userID, bookID,
+		[]constants.HoldStatus{constants.HoldPending, constants.HoldReady}).First(&hold).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrHoldNotFound
+	}
+	return &hold, err
+}
+
+// GetNextPending returns the oldest pending hold in a book's queue
+func (r *HoldRepository) GetNextPending(bookID uint) (*models.Hold, error) {
+	var hold models.Hold
+	err := r.DB.Where("book_id = ? AND status = ?", bookID, constants.HoldPending).
+		Order("id ASC").First(&hold).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrHoldNotFound
+	}
+	return &hold, err
+}
+
+// GetExpiredReady returns ready holds whose pickup window has passed
+func (r *HoldRepository) GetExpiredReady(now time.Time) ([]models.Hold, error) {
+	var holds []models.Hold
+	err := r.DB.Where("status = ? AND expires_at < ?", constants.HoldReady, now).
+		Order("id ASC").Find(&holds).Error
+	return holds, err
+}
+
+// GetQueuePosition returns the 1-based position of a pending hold in its book's queue
+func (r *HoldRepository) GetQueuePosition(hold *models.Hold) (int64, error) {
+	var ahead int64
+	err := r.DB.Model(&models.Hold{}).
+		Where("book_id = ? AND status = ? AND id < ?", hold.BookID, constants.HoldPending, hold.ID).
+		Count(&ahead).Error
+	return ahead + 1, err
+}
+
+// CountActiveHolds counts the pending and ready holds on a book placed by anyone but excludeUserID
+func (r *HoldRepository) CountActiveHolds(bookID, excludeUserID uint) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.Hold{}).
+		Where("book_id = ? AND user_id <> ? AND status IN ?", bookID, excludeUserID,
+			[]constants.HoldStatus{constants.HoldPending, constants.HoldReady}).
+		Count(&count).Error
+	return count, err
+}
+
+// UpdateStatus saves the hold's new status and timestamps, but only if it is
+// still in the "from" status. This keeps concurrent returns and cancellations
+// from both acting on the same hold.
+func (r *HoldRepository) UpdateStatus(hold *models.Hold, from constants.HoldStatus) error {
+	result := r.DB.Model(&models.Hold{}).
+		Where("id = ? AND status = ?", hold.ID, from).
+		Updates(map[string]interface{}{
+			"status":     hold.Status,
+			"copy_id":    hold.CopyID,
+			"ready_at":   hold.ReadyAt,
+			"expires_at": hold.ExpiresAt,
+			"closed_at":  hold.ClosedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrHoldNotActive
+	}
+	return nil
+}
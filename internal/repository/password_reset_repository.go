@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepositoryInterface interface {
+	BeginTransaction() (*gorm.DB, PasswordResetRepositoryInterface)
+	CommitTransaction(tx *gorm.DB) error
+	RollbackTransaction(tx *gorm.DB)
+	Create(token *models.PasswordResetToken) error
+	GetByHash(hash string) (*models.PasswordResetToken, error)
+	Use(token *models.PasswordResetToken) error
+	InvalidateForUser(userID uint) error
+	CountSince(userID uint, since time.Time) (int64, error)
+}
+
+type PasswordResetRepository struct {
+	DB *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepositoryInterface {
+	return &PasswordResetRepository{DB: db}
+}
+
+func (r *PasswordResetRepository) BeginTransaction() (*gorm.DB, PasswordResetRepositoryInterface) {
+	tx := r.DB.Begin()
+	return tx, &PasswordResetRepository{DB: tx}
+}
+
+func (r *PasswordResetRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (r *PasswordResetRepository) RollbackTransaction(tx *gorm.DB) {
+	tx.Rollback()
+}
+
+// Create a new password reset token
+func (r *PasswordResetRepository) Create(token *models.PasswordResetToken) error {
+	return r.DB.Create(token).Error
+}
+
+// Get a password reset token by the hash of its value
+func (r *PasswordResetRepository) GetByHash(hash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := r.DB.Where("token_hash = ?", hash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrInvalidResetToken
+	}
+	return &token, err
+}
+
+// Use marks a token used, if it is still unused and unexpired. Of several
+// concurrent uses of the same token only one succeeds, the others get
+// ErrInvalidResetToken.
+func (r *PasswordResetRepository) Use(token *models.PasswordResetToken) error {
+	now := time.Now()
+	result := r.DB.Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", token.ID, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrInvalidResetToken
+	}
+	token.UsedAt = &now
+	return nil
+}
+
+// InvalidateForUser stops the unused tokens of a user from working, so
+// only the latest link sent to them does
+func (r *PasswordResetRepository) InvalidateForUser(userID uint) error {
+	return r.DB.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
+
+// CountSince counts the tokens issued to a user since a point in time
+func (r *PasswordResetRepository) CountSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND created_at > ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
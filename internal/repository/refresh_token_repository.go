@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryInterface interface {
+	BeginTransaction() (*gorm.DB, RefreshTokenRepositoryInterface)
+	CommitTransaction(tx *gorm.DB) error
+	RollbackTransaction(tx *gorm.DB)
+	Create(token *models.RefreshToken) error
+	GetByHash(hash string) (*models.RefreshToken, error)
+	Revoke(token *models.RefreshToken) error
+	RevokeFamily(familyID string) error
+	RevokeAllForUser(userID uint) error
+}
+
+type RefreshTokenRepository struct {
+	DB *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepositoryInterface {
+	return &RefreshTokenRepository{DB: db}
+}
+
+func (r *RefreshTokenRepository) BeginTransaction() (*gorm.DB, RefreshTokenRepositoryInterface) {
+	tx := r.DB.Begin()
+	return tx, &RefreshTokenRepository{DB: tx}
+}
+
+func (r *RefreshTokenRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (r *RefreshTokenRepository) RollbackTransaction(tx *gorm.DB) {
+	tx.Rollback()
+}
+
+// Create a new refresh token
+func (r *RefreshTokenRepository) Create(token *models.RefreshToken) error {
+	return r.DB.Create(token).Error
+}
+
+// Get a refresh token by the hash of its value
+func (r *RefreshTokenRepository) GetByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.DB.Where("token_hash = ?", hash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrInvalidRefreshToken
+	}
+	return &token, err
+}
+
+// Revoke a refresh token that is still good. Of several concurrent
+// revocations of the same token only one succeeds, the others get
+// ErrInvalidRefreshToken.
+func (r *RefreshTokenRepository) Revoke(token *models.RefreshToken) error {
+	now := time.Now()
+	result := r.DB.Model(&models.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", token.ID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrInvalidRefreshToken
+	}
+	token.RevokedAt = &now
+	return nil
+}
+
+// RevokeFamily revokes every token descended from the same login
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.DB.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllForUser revokes every refresh token of a user, e.g. to log them out everywhere
+func (r *RefreshTokenRepository) RevokeAllForUser(userID uint) error {
+	return r.DB.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const roleNameIndexName = "idx_roles_name"
+
+type RoleRepositoryInterface interface {
+	Create(role *models.Role) error
+	GetByID(id uint) (*models.Role, error)
+	GetByName(name string) (*models.Role, error)
+	GetAll() ([]models.Role, error)
+	Update(role *models.Role) error
+	Delete(id uint) error
+	CountUsers(name string) (int64, error)
+	HasPermission(name string, permission constants.Permission) (bool, error)
+}
+
+type RoleRepository struct {
+	DB *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepositoryInterface {
+	return &RoleRepository{DB: db}
+}
+
+// Create a role together with its permissions
+func (r *RoleRepository) Create(role *models.Role) error {
+	err := r.DB.Create(role).Error
+	if isUniqueViolation(err, roleNameIndexName) {
+		return constants.ErrRoleExists
+	}
+	return err
+}
+
+// Get Role by ID, with its permissions
+func (r *RoleRepository) GetByID(id uint) (*models.Role, error) {
+	var role models.Role
+	err := r.DB.Preload("Permissions").First(&role, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrRoleNotFound
+	}
+	return &role, err
+}
+
+// Get Role by name, with its permissions
+func (r *RoleRepository) GetByName(name string) (*models.Role, error) {
+	var role models.Role
+	err := r.DB.Preload("Permissions").Where("name = ?", name).First(&role).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrRoleNotFound
+	}
+	return &role, err
+}
+
+// Get All Roles by name, with their permissions
+func (r *RoleRepository) GetAll() ([]models.Role, error) {
+	var roles []models.Role
+	err := r.DB.Preload("Permissions").Order("name ASC").Find(&roles).Error
+	return roles, err
+}
+
+// Update the description of a role and replace its permissions
+func (r *RoleRepository) Update(role *models.Role) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(role).Update("description", role.Description).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", role.ID).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+		for i := range role.Permissions {
+			role.Permissions[i].RoleID = role.ID
+		}
+		if len(role.Permissions) == 0 {
+			return nil
+		}
+		return tx.Create(&role.Permissions).Error
+	})
+}
+
+// Delete a role; its permissions go with it
+func (r *RoleRepository) Delete(id uint) error {
+	return r.DB.Delete(&models.Role{}, id).Error
+}
+
+// CountUsers counts the users with a role, deleted ones included since
+// they still reference it
+func (r *RoleRepository) CountUsers(name string) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.User{}).Unscoped().Where("role = ?", name).Count(&count).Error
+	return count, err
+}
+
+// HasPermission reports whether a role grants a permission
+func (r *RoleRepository) HasPermission(name string, permission constants.Permission) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.RolePermission{}).
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ? AND role_permissions.permission = ?", name, permission).
+		Count(&count).Error
+	return count > 0, err
+}
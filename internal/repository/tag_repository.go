@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tagBookCountColumn counts the books carrying a tag
+const tagBookCountColumn = "(SELECT COUNT(*) FROM book_tags JOIN books ON books.id = book_tags.book_id" +
+	" AND books.deleted_at IS NULL WHERE book_tags.tag_id = tags.id) AS book_count"
+
+type TagRepositoryInterface interface {
+	Create(tag *models.Tag) (*models.Tag, error)
+	GetByID(id uint) (*models.Tag, error)
+	GetByName(name string) (*models.Tag, error)
+	GetAll(name string, page, limit int) ([]models.Tag, int64, error)
+	FindOrCreateByNames(names []string) ([]models.Tag, error)
+	Update(tag *models.Tag) error
+	Merge(sourceIDs []uint, targetID uint) error
+	Delete(id uint) error
+	WithTx(tx *gorm.DB) TagRepositoryInterface
+}
+
+type TagRepository struct {
+	DB *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepositoryInterface {
+	return &TagRepository{DB: db}
+}
+
+// WithTx returns a repository bound to a transaction started elsewhere
+func (r *TagRepository) WithTx(tx *gorm.DB) TagRepositoryInterface {
+	return &TagRepository{DB: tx}
+}
+
+// Create Tag
+func (r *TagRepository) Create(tag *models.Tag) (*models.Tag, error) {
+	err := r.DB.Create(tag).Error
+	return tag, err
+}
+
+// Get Tag by ID
+func (r *TagRepository) GetByID(id uint) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.DB.First(&tag, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrTagNotFound
+	}
+	return &tag, err
+}
+
+// Get Tag by name, ignoring case
+func (r *TagRepository) GetByName(name string) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.DB.Where("LOWER(name) = LOWER(?)", name).First(&tag).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrTagNotFound
+	}
+	return &tag, err
+}
+
+// Get All Tags, alphabetically and with the number of books carrying each.
+// A non-empty name only keeps the tags whose name contains it.
+func (r *TagRepository) GetAll(name string, page, limit int) ([]models.Tag, int64, error) {
+	var tags []models.Tag
+	var total int64
+
+	query := r.DB.Model(&models.Tag{})
+	if name != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+name+"%")
+	}
+
+	// Count total tags (without pagination)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Pagination logic
+	offset := (page - 1) * limit
+	err := query.Select("*", tagBookCountColumn).
+		Order("name ASC, id ASC").
+		Limit(limit).Offset(offset).
+		Find(&tags).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tags, total, nil
+}
+
+// FindOrCreateByNames returns the tags with the given names, in the same
+// order, creating the ones that don't exist yet.
+func (r *TagRepository) FindOrCreateByNames(names []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tag, err := r.GetByName(name)
+		if errors.Is(err, constants.ErrTagNotFound) {
+			tag = &models.Tag{Name: name}
+			result := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(tag)
+			err = result.Error
+			// Someone else just created the same tag, use theirs
+			if err == nil && result.RowsAffected == 0 {
+				tag, err = r.GetByName(name)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+	return tags, nil
+}
+
+// Update Tag
+func (r *TagRepository) Update(tag *models.Tag) error {
+	return r.DB.Save(tag).Error
+}
+
+// Merge moves the books of the source tags over to the target tag, then
+// deletes the source tags. Books that already carry the target keep a
+// single link to it.
+func (r *TagRepository) Merge(sourceIDs []uint, targetID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("INSERT INTO book_tags (book_id, tag_id)"+
+			" SELECT DISTINCT book_id, CAST(? AS BIGINT) FROM book_tags WHERE tag_id IN ?"+
+			" AND book_id NOT IN (SELECT book_id FROM book_tags WHERE tag_id = ?)",
+			targetID, sourceIDs, targetID).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM book_tags WHERE tag_id IN ?", sourceIDs).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Tag{}, sourceIDs).Error
+	})
+}
+
+// Delete Tag, removing it from its books
+func (r *TagRepository) Delete(id uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Tag{}, id).Error
+	})
+}
@@ -4,11 +4,17 @@ import (
 	"errors"
 	"library-management/internal/constants"
 	"library-management/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-var defaultUserFields = []string{"id", "name", "email", "role", "created_at"}
+var defaultUserFields = []string{"id", "name", "email", "role", "email_verified_at", "locked_until", "created_at"}
+
+// userEmailIndexName is the index keeping emails unique, whatever their case,
+// among the users that aren't deleted
+const userEmailIndexName = "idx_users_email"
 
 // Define the UserRepository interface
 type UserRepositoryInterface interface {
@@ -17,7 +23,14 @@ type UserRepositoryInterface interface {
 	GetAll(page, limit int, fields []string) ([]models.User, int64, error)
 	GetByEmail(email string, fields []string) (*models.User, error)
 	Update(user *models.User) error
+	IncrementTokenVersion(id uint) error
+	SetEmailVerifiedAt(id uint, verifiedAt *time.Time) error
+	RecordFailedLogin(id uint, since time.Time) (int, error)
+	Lock(id uint, until time.Time) error
+	ClearFailedLogins(id uint) error
+	LockForUpdate(id uint) error
 	Delete(id uint) error
+	WithTx(tx *gorm.DB) UserRepositoryInterface
 }
 
 // Implement the UserRepository interface with a struct
@@ -29,9 +42,17 @@ func NewUserRepository(db *gorm.DB) UserRepositoryInterface {
 	return &UserRepository{DB: db}
 }
 
+// WithTx returns a repository bound to a transaction started elsewhere
+func (r *UserRepository) WithTx(tx *gorm.DB) UserRepositoryInterface {
+	return &UserRepository{DB: tx}
+}
+
 // Create User
 func (r *UserRepository) Create(user *models.User) (*models.User, error) {
 	err := r.DB.Create(user).Error
+	if isUniqueViolation(err, userEmailIndexName) {
+		return user, constants.ErrEmailTaken
+	}
 	return user, err
 }
 
@@ -84,9 +105,65 @@ func (r *UserRepository) GetByEmail(email string, fields []string) (*models.User
 	return &user, err
 }
 
-// Update User
+// Update User. Only the fields set are written, so a user loaded without
+// their password or token version keeps them.
 func (r *UserRepository) Update(user *models.User) error {
-	return r.DB.Save(user).Error
+	err := r.DB.Model(user).Updates(user).Error
+	if isUniqueViolation(err, userEmailIndexName) {
+		return constants.ErrEmailTaken
+	}
+	return err
+}
+
+// IncrementTokenVersion bumps the token version of a user, which revokes
+// the access and refresh tokens issued to them so far
+func (r *UserRepository) IncrementTokenVersion(id uint) error {
+	return r.DB.Model(&models.User{}).Where("id = ?", id).
+		UpdateColumn("token_version", gorm.Expr("token_version + 1")).Error
+}
+
+// SetEmailVerifiedAt marks the email of a user verified, or unverified with nil
+func (r *UserRepository) SetEmailVerifiedAt(id uint, verifiedAt *time.Time) error {
+	return r.DB.Model(&models.User{}).Where("id = ?", id).
+		UpdateColumn("email_verified_at", verifiedAt).Error
+}
+
+// RecordFailedLogin counts a failed login of a user and returns how many
+// there were since the given time, this one included. Older failures are
+// forgotten.
+func (r *UserRepository) RecordFailedLogin(id uint, since time.Time) (int, error) {
+	var user models.User
+	err := r.DB.Model(&user).Clauses(clause.Returning{Columns: []clause.Column{{Name: "failed_logins"}}}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"failed_logins":        gorm.Expr("CASE WHEN last_failed_login_at IS NULL OR last_failed_login_at < ? THEN 1 ELSE failed_logins + 1 END", since),
+			"last_failed_login_at": time.Now(),
+		}).Error
+	return user.FailedLogins, err
+}
+
+// Lock refuses the logins of a user until the given time. The failed
+// logins that led to it are forgotten.
+func (r *UserRepository) Lock(id uint, until time.Time) error {
+	return r.DB.Model(&models.User{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"locked_until": until, "failed_logins": 0, "last_failed_login_at": nil}).Error
+}
+
+// ClearFailedLogins forgets the failed logins of a user and lifts their lockout
+func (r *UserRepository) ClearFailedLogins(id uint) error {
+	return r.DB.Model(&models.User{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"locked_until": nil, "failed_logins": 0, "last_failed_login_at": nil}).Error
+}
+
+// LockForUpdate locks a user until the end of the transaction, so
+// concurrent checkouts for the same user take turns
+func (r *UserRepository) LockForUpdate(id uint) error {
+	var user models.User
+	err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constants.ErrUserNotFound
+	}
+	return err
 }
 
 // func (r *UserRepository) Update(userID uint, updates map[string]interface{}) error {
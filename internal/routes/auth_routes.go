@@ -2,14 +2,20 @@ package routes
 
 import (
 	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+	"library-management/internal/ratelimit"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupAuthRoutes(router *gin.Engine, authHandler *handlers.AuthHandler) {
+func SetupAuthRoutes(router *gin.Engine, authHandler *handlers.AuthHandler, loginLimiter *ratelimit.Limiter, audit middlewares.AuditRecorder) {
 	authGroup := router.Group("/auth")
 	{
 		authGroup.POST("/register", authHandler.Register)
-		authGroup.POST("/login", authHandler.Login)
+		// Client IPs with too many failed logins are turned away, and audited
+		authGroup.POST("/login", middlewares.RateLimitFailures(loginLimiter, audit), authHandler.Login)
+		// The refresh token is the credential of these two
+		authGroup.POST("/refresh", authHandler.Refresh)
+		authGroup.POST("/logout", authHandler.Logout)
 	}
 }
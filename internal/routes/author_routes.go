@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAuthorRoutes(r *gin.Engine, authorHandler *handlers.AuthorHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
+	authorRoutes := r.Group("/authors")
+	{
+		authorRoutes.Use(middlewares.AuthMiddleware(verifier))
+
+		authorRoutes.GET("/", authorHandler.GetAllAuthors)
+		authorRoutes.GET("/:id", authorHandler.GetAuthor)
+		authorRoutes.GET("/:id/books", authorHandler.GetAuthorBooks)
+
+		authorRoutes.Use(middlewares.RequirePermission(permissions, constants.PermBooksWrite))
+		authorRoutes.POST("/", authorHandler.CreateAuthor)
+		authorRoutes.PUT("/:id", authorHandler.UpdateAuthor)
+		authorRoutes.DELETE("/:id", authorHandler.DeleteAuthor)
+	}
+}
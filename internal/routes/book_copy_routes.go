@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBookCopyRoutes(r *gin.Engine, copyHandler *handlers.BookCopyHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
+	canWrite := middlewares.RequirePermission(permissions, constants.PermCopiesWrite)
+
+	bookCopyRoutes := r.Group("/books/:id/copies")
+	{
+		bookCopyRoutes.Use(middlewares.AuthMiddleware(verifier))
+		bookCopyRoutes.GET("/", copyHandler.GetBookCopies)
+		bookCopyRoutes.POST("/", canWrite, copyHandler.AddCopy)
+	}
+
+	copyRoutes := r.Group("/copies")
+	{
+		copyRoutes.Use(middlewares.AuthMiddleware(verifier))
+		copyRoutes.GET("/barcode/:barcode", middlewares.RequirePermission(permissions, constants.PermCopiesRead), copyHandler.GetCopyByBarcode)
+		copyRoutes.PATCH("/:id", canWrite, copyHandler.UpdateCopy)
+		copyRoutes.DELETE("/:id", canWrite, copyHandler.DeleteCopy)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBookCoverRoutes(r *gin.Engine, coverHandler *handlers.BookCoverHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
+	coverRoutes := r.Group("/books/:id/cover")
+	{
+		// Cover images are public, so they can be shown with plain <img> tags
+		coverRoutes.GET("", coverHandler.GetCover)
+		coverRoutes.GET("/thumbnail", coverHandler.GetCoverThumbnail)
+
+		coverRoutes.Use(middlewares.AuthMiddleware(verifier), middlewares.RequirePermission(permissions, constants.PermBooksWrite))
+		coverRoutes.PUT("", coverHandler.UploadCover)
+		coverRoutes.DELETE("", coverHandler.DeleteCover)
+	}
+}
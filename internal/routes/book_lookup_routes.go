@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBookLookupRoutes(r *gin.Engine, lookupHandler *handlers.BookLookupHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
+	lookupRoutes := r.Group("/books/lookup")
+	{
+		lookupRoutes.Use(middlewares.AuthMiddleware(verifier), middlewares.RequirePermission(permissions, constants.PermBooksWrite))
+		lookupRoutes.POST("", lookupHandler.LookupBook)
+	}
+}
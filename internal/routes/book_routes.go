@@ -8,16 +8,18 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupBookRoutes(r *gin.Engine, bookHandler *handlers.BookHandler) {
+func SetupBookRoutes(r *gin.Engine, bookHandler *handlers.BookHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
 	bookRoutes := r.Group("/books")
 	{
-		bookRoutes.Use(middlewares.AuthMiddleware())
+		bookRoutes.Use(middlewares.AuthMiddleware(verifier))
 
 		bookRoutes.GET("/:id", bookHandler.GetBook)
 		bookRoutes.GET("/", bookHandler.GetAllBooks)
+		bookRoutes.GET("/export", middlewares.RequirePermission(permissions, constants.PermBooksExport), bookHandler.ExportBooks)
 
-		bookRoutes.Use(middlewares.RoleMiddleware(string(constants.Admin)))
+		bookRoutes.Use(middlewares.RequirePermission(permissions, constants.PermBooksWrite))
 		bookRoutes.POST("/", bookHandler.CreateBook)
+		bookRoutes.POST("/import", bookHandler.ImportBooks)
 		bookRoutes.PUT("/:id", bookHandler.UpdateBook)
 		bookRoutes.DELETE("/:id", bookHandler.DeleteBook)
 	}
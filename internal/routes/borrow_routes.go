@@ -8,17 +8,22 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupBorrowRoutes(r *gin.Engine, borrowHandler *handlers.BorrowHandler) {
+func SetupBorrowRoutes(r *gin.Engine, borrowHandler *handlers.BorrowHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
 	borrowRoutes := r.Group("/borrows")
 	{
-		borrowRoutes.Use(middlewares.AuthMiddleware())
+		borrowRoutes.Use(middlewares.AuthMiddleware(verifier))
 		borrowRoutes.POST("/", borrowHandler.BorrowBook)
 		borrowRoutes.PATCH("/return", borrowHandler.ReturnBook)
+		borrowRoutes.PATCH("/:id/renew", borrowHandler.RenewBorrow)
 		// Get borrowed books for the logged-in user
 		borrowRoutes.GET("/", borrowHandler.GetMyBorrows)
 
-		borrowRoutes.Use(middlewares.RoleMiddleware(string(constants.Admin)))
-		// borrowRoutes.GET("/", borrowHandler.GetBorrowRecords)
-		borrowRoutes.GET("/users/:user_id", borrowHandler.GetUserBorrows)
+		// Circulation desk: lend to a member and check returned items in
+		borrowRoutes.POST("/users/:user_id", middlewares.RequirePermission(permissions, constants.PermLoansCheckout), borrowHandler.CheckOut)
+		borrowRoutes.PATCH("/:id/checkin", middlewares.RequirePermission(permissions, constants.PermLoansCheckin), borrowHandler.CheckIn)
+
+		canRead := middlewares.RequirePermission(permissions, constants.PermLoansRead)
+		borrowRoutes.GET("/history", canRead, borrowHandler.GetBorrowRecords)
+		borrowRoutes.GET("/users/:user_id", canRead, borrowHandler.GetUserBorrows)
 	}
 }
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupCategoryRoutes(r *gin.Engine, categoryHandler *handlers.CategoryHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
+	categoryRoutes := r.Group("/categories")
+	{
+		categoryRoutes.Use(middlewares.AuthMiddleware(verifier))
+
+		categoryRoutes.GET("/", categoryHandler.GetCategoryTree)
+		categoryRoutes.GET("/:id", categoryHandler.GetCategory)
+
+		categoryRoutes.Use(middlewares.RequirePermission(permissions, constants.PermBooksWrite))
+		categoryRoutes.POST("/", categoryHandler.CreateCategory)
+		categoryRoutes.PUT("/:id", categoryHandler.UpdateCategory)
+		categoryRoutes.DELETE("/:id", categoryHandler.DeleteCategory)
+	}
+}
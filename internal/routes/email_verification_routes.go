@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupEmailVerificationRoutes(r *gin.Engine, verificationHandler *handlers.EmailVerificationHandler, verifier middlewares.TokenVerifier) {
+	// The token sent by email is the credential
+	r.POST("/auth/verify-email", verificationHandler.VerifyEmail)
+
+	meRoutes := r.Group("/me")
+	{
+		meRoutes.Use(middlewares.AuthMiddleware(verifier))
+		meRoutes.POST("/verify-email", verificationHandler.ResendVerification)
+	}
+}
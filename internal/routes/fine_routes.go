@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupFineRoutes(r *gin.Engine, fineHandler *handlers.FineHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
+	fineRoutes := r.Group("/fines")
+	{
+		fineRoutes.Use(middlewares.AuthMiddleware(verifier))
+		// Get fines for the logged-in user
+		fineRoutes.GET("/", fineHandler.GetMyFines)
+
+		canRead := middlewares.RequirePermission(permissions, constants.PermFinesRead)
+		fineRoutes.GET("/all", canRead, fineHandler.GetFines)
+		fineRoutes.GET("/users/:user_id", canRead, fineHandler.GetUserFines)
+
+		canManage := middlewares.RequirePermission(permissions, constants.PermFinesManage)
+		fineRoutes.POST("/:id/payments", canManage, fineHandler.RecordPayment)
+		fineRoutes.POST("/:id/waive", canManage, fineHandler.WaiveFine)
+	}
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupHoldRoutes(r *gin.Engine, holdHandler *handlers.HoldHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
+	holdRoutes := r.Group("/holds")
+	{
+		holdRoutes.Use(middlewares.AuthMiddleware(verifier))
+		holdRoutes.POST("/", holdHandler.PlaceHold)
+		// Get holds for the logged-in user
+		holdRoutes.GET("/", holdHandler.GetMyHolds)
+		holdRoutes.DELETE("/:id", holdHandler.CancelHold)
+
+		holdRoutes.Use(middlewares.RequirePermission(permissions, constants.PermLoansRead))
+		holdRoutes.GET("/books/:book_id", holdHandler.GetBookQueue)
+	}
+}
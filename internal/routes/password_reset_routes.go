@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+	"library-management/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupPasswordResetRoutes(r *gin.Engine, resetHandler *handlers.PasswordResetHandler, limiter *ratelimit.Limiter) {
+	resetRoutes := r.Group("/auth")
+	{
+		// Both are open to anyone, so each client IP gets a limited number of tries
+		resetRoutes.Use(middlewares.RateLimit(limiter))
+		resetRoutes.POST("/forgot-password", resetHandler.ForgotPassword)
+		resetRoutes.POST("/reset-password", resetHandler.ResetPassword)
+	}
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoleRoutes(r *gin.Engine, roleHandler *handlers.RoleHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
+	roleRoutes := r.Group("/roles")
+	{
+		roleRoutes.Use(middlewares.AuthMiddleware(verifier), middlewares.RequirePermission(permissions, constants.PermRolesManage))
+
+		roleRoutes.GET("/", roleHandler.GetAllRoles)
+		roleRoutes.GET("/permissions", roleHandler.GetPermissions)
+		roleRoutes.GET("/:id", roleHandler.GetRole)
+		roleRoutes.POST("/", roleHandler.CreateRole)
+		roleRoutes.PUT("/:id", roleHandler.UpdateRole)
+		roleRoutes.DELETE("/:id", roleHandler.DeleteRole)
+	}
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/handlers"
+	middlewares "library-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTagRoutes(r *gin.Engine, tagHandler *handlers.TagHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
+	tagRoutes := r.Group("/tags")
+	{
+		tagRoutes.Use(middlewares.AuthMiddleware(verifier))
+
+		tagRoutes.GET("/", tagHandler.GetAllTags)
+
+		tagRoutes.Use(middlewares.RequirePermission(permissions, constants.PermBooksWrite))
+		tagRoutes.POST("/", tagHandler.CreateTag)
+		tagRoutes.POST("/merge", tagHandler.MergeTags)
+		tagRoutes.PUT("/:id", tagHandler.UpdateTag)
+		tagRoutes.DELETE("/:id", tagHandler.DeleteTag)
+	}
+}
@@ -8,16 +8,29 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupUserRoutes(r *gin.Engine, userHandler *handlers.UserHandler) {
+func SetupUserRoutes(r *gin.Engine, userHandler *handlers.UserHandler, verifier middlewares.TokenVerifier, permissions middlewares.PermissionChecker) {
 	userRoutes := r.Group("/users")
 	{
-		userRoutes.Use(middlewares.AuthMiddleware())
-		userRoutes.Use(middlewares.RoleMiddleware(string(constants.Admin)))
+		userRoutes.Use(middlewares.AuthMiddleware(verifier))
 
-		userRoutes.POST("/", userHandler.CreateUser)
-		userRoutes.GET("/", userHandler.GetAllUsers)
-		userRoutes.GET("/:id", userHandler.GetUser)
-		userRoutes.PUT("/:id", userHandler.UpdateUser)
-		userRoutes.DELETE("/:id", userHandler.DeleteUser)
+		canRead := middlewares.RequirePermission(permissions, constants.PermUsersRead)
+		userRoutes.GET("/", canRead, userHandler.GetAllUsers)
+		userRoutes.GET("/:id", canRead, userHandler.GetUser)
+
+		canWrite := middlewares.RequirePermission(permissions, constants.PermUsersWrite)
+		userRoutes.POST("/", canWrite, userHandler.CreateUser)
+		userRoutes.PUT("/:id", canWrite, userHandler.UpdateUser)
+		userRoutes.DELETE("/:id", canWrite, userHandler.DeleteUser)
+		userRoutes.POST("/:id/unlock", canWrite, userHandler.UnlockUser)
+	}
+
+	// Any logged-in user's own account
+	meRoutes := r.Group("/me")
+	{
+		meRoutes.Use(middlewares.AuthMiddleware(verifier))
+		meRoutes.GET("", userHandler.GetMe)
+		meRoutes.PATCH("", userHandler.UpdateMe)
+		meRoutes.DELETE("", userHandler.DeleteMe)
+		meRoutes.POST("/password", userHandler.ChangeMyPassword)
 	}
 }
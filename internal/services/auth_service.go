@@ -1,29 +1,62 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"library-management/config"
 	"library-management/internal/constants"
 	"library-management/internal/dto"
+	"library-management/internal/models"
 	"library-management/internal/repository"
 	"library-management/internal/utils/auth"
 	"library-management/internal/utils/mappers"
+	"log"
 	"strings"
+	"sync"
+	"time"
 )
 
 type AuthServiceInterface interface {
-	Register(req dto.UserRegisterRequest) (string, dto.UserResponse, error)
-	Login(req dto.UserLoginRequest) (string, dto.UserResponse, error)
+	Register(req dto.UserRegisterRequest) (dto.AuthResponse, error)
+	Login(req dto.UserLoginRequest, clientIP string) (dto.AuthResponse, error)
+	Refresh(req dto.RefreshRequest) (dto.AuthResponse, error)
+	Logout(req dto.LogoutRequest) error
+	VerifyAccessToken(token string) (*auth.Claims, error)
 }
 
 type AuthService struct {
-	Repo repository.UserRepositoryInterface
+	Repo         repository.UserRepositoryInterface
+	RefreshRepo  repository.RefreshTokenRepositoryInterface
+	AuditRepo    repository.AuditRepositoryInterface
+	Verification EmailVerificationServiceInterface
+	Config       config.AuthConfig
 }
 
-func NewAuthService(repo repository.UserRepositoryInterface) AuthServiceInterface {
-	return &AuthService{Repo: repo}
+func NewAuthService(repo repository.UserRepositoryInterface, refreshRepo repository.RefreshTokenRepositoryInterface, auditRepo repository.AuditRepositoryInterface, verification EmailVerificationServiceInterface, authConfig config.AuthConfig) AuthServiceInterface {
+	return &AuthService{Repo: repo, RefreshRepo: refreshRepo, AuditRepo: auditRepo, Verification: verification, Config: authConfig}
 }
 
+// sessionUserFields are the user fields needed to issue and check tokens
+var sessionUserFields = []string{"id", "name", "email", "role", "token_version", "email_verified_at", "created_at"}
+
+// loginUserFields are the user fields needed to check a login
+var loginUserFields = append([]string{"password", "failed_logins", "last_failed_login_at", "locked_until"}, sessionUserFields...)
+
+// maxLoginDelay caps the wait between failed logins
+const maxLoginDelay = time.Minute
+
+// noAccountHash is checked passwords against when there's no account to
+// check them with, so that takes as long as for a registered email
+var noAccountHash = sync.OnceValue(func() string {
+	hash, err := auth.HashPassword("no account")
+	if err != nil {
+		log.Fatalf("❌ Failed to hash a password: %v", err)
+	}
+	return hash
+})
+
 // Create User (with hashed password)
-func (s *AuthService) Register(req dto.UserRegisterRequest) (string, dto.UserResponse, error) {
+func (s *AuthService) Register(req dto.UserRegisterRequest) (dto.AuthResponse, error) {
 
 	user := mappers.MapRegisterRequestToUser(req)
 	// Convert email to lowercase
@@ -32,52 +65,255 @@ func (s *AuthService) Register(req dto.UserRegisterRequest) (string, dto.UserRes
 	// Check if the email already exists
 	existingUser, _ := s.Repo.GetByEmail(user.Email, []string{"id"})
 	if existingUser != nil {
-		return "", dto.UserResponse{}, constants.ErrEmailTaken
+		return dto.AuthResponse{}, constants.ErrEmailTaken
 	}
 
 	// Hash password before saving
 	hashedPassword, err := auth.HashPassword(user.Password)
 	if err != nil {
-		return "", dto.UserResponse{}, err
+		return dto.AuthResponse{}, err
 	}
 	user.Password = hashedPassword
 
 	// Save to DB
 	user, err = s.Repo.Create(user)
 	if err != nil {
-		return "", dto.UserResponse{}, err
+		return dto.AuthResponse{}, err
 	}
 
-	// Generate JWT token
-	token, err := auth.GenerateToken(user.ID, user.Role)
-	if err != nil {
-		return "", dto.UserResponse{}, err
+	// The account works at once; a new link can be asked for if this one is lost
+	if err := s.Verification.SendVerification(user.ID); err != nil {
+		log.Printf("⚠️ Failed to send the verification email to user %d: %v", user.ID, err)
 	}
-	// Map user to response DTO
-	userResponse := mappers.MapUserToResponse(user)
-	return token, userResponse, nil
+
+	return s.startSession(user)
 }
 
-// Login (returns user if successful)
-func (s *AuthService) Login(req dto.UserLoginRequest) (string, dto.UserResponse, error) {
+// Login (returns user if successful). After a failed login the account
+// can't log in for a while, twice as long after each further one, and it
+// is locked once it had too many. Logins refused for that are answered
+// like a wrong password, after checking it all the same, so they don't
+// give away which emails are registered.
+func (s *AuthService) Login(req dto.UserLoginRequest, clientIP string) (dto.AuthResponse, error) {
 	user := mappers.MapLoginRequestToUser(req)
 
-	user, err := s.Repo.GetByEmail(user.Email, []string{"id", "name", "email", "password", "role", "created_at"})
+	user, err := s.Repo.GetByEmail(user.Email, loginUserFields)
 	if err != nil {
-		return "", dto.UserResponse{}, constants.ErrInvalidCredentials
+		auth.CheckPasswordHash(req.Password, noAccountHash())
+		return dto.AuthResponse{}, constants.ErrInvalidCredentials
 	}
 
 	// Verify password
-	if !auth.CheckPasswordHash(req.Password, user.Password) {
-		return "", dto.UserResponse{}, constants.ErrInvalidCredentials
+	passwordOK := auth.CheckPasswordHash(req.Password, user.Password)
+	if s.loginHeldBack(user, time.Now()) {
+		return dto.AuthResponse{}, constants.ErrInvalidCredentials
+	}
+	if !passwordOK {
+		s.recordFailedLogin(user, clientIP)
+		return dto.AuthResponse{}, constants.ErrInvalidCredentials
+	}
+
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := s.Repo.ClearFailedLogins(user.ID); err != nil {
+			return dto.AuthResponse{}, err
+		}
+	}
+	return s.startSession(user)
+}
+
+// loginHeldBack reports whether the user is locked out, or has to wait
+// longer after their last failed login
+func (s *AuthService) loginHeldBack(user *models.User, now time.Time) bool {
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return true
+	}
+	if user.FailedLogins == 0 || user.LastFailedLoginAt == nil {
+		return false
+	}
+	delay := maxLoginDelay
+	if user.FailedLogins <= 6 {
+		delay = min(time.Duration(1<<(user.FailedLogins-1))*time.Second, maxLoginDelay)
+	}
+	return now.Before(user.LastFailedLoginAt.Add(delay))
+}
+
+// recordFailedLogin counts a failed login, and locks the account once it
+// had too many in a short time. Errors are only logged, as answering
+// differently would tell the account exists.
+func (s *AuthService) recordFailedLogin(user *models.User, clientIP string) {
+	now := time.Now()
+	failures, err := s.Repo.RecordFailedLogin(user.ID, now.Add(-s.Config.LockoutDuration))
+	if err != nil {
+		log.Printf("⚠️ Failed to record a failed login of user %d: %v", user.ID, err)
+		return
+	}
+	if failures < s.Config.MaxFailedLogins {
+		return
+	}
+
+	if err := s.Repo.Lock(user.ID, now.Add(s.Config.LockoutDuration)); err != nil {
+		log.Printf("⚠️ Failed to lock user %d: %v", user.ID, err)
+		return
+	}
+	err = s.AuditRepo.Create(&models.AuditEntry{
+		Action: string(constants.AuditAccountLocked),
+		UserID: &user.ID,
+		IP:     clientIP,
+		Detail: fmt.Sprintf("%d failed logins, locked until %s", failures, now.Add(s.Config.LockoutDuration).UTC().Format(time.RFC3339)),
+	})
+	if err != nil {
+		log.Printf("⚠️ Failed to audit the lockout of user %d: %v", user.ID, err)
+	}
+}
+
+// Refresh exchanges a refresh token for a new access token and the next
+// refresh token. A refresh token that was already used is being replayed,
+// by whoever stole it or by its owner after the thief refreshed first, so
+// the whole session is revoked.
+func (s *AuthService) Refresh(req dto.RefreshRequest) (dto.AuthResponse, error) {
+	stored, err := s.RefreshRepo.GetByHash(auth.HashToken(req.RefreshToken))
+	if err != nil {
+		return dto.AuthResponse{}, err
+	}
+	if stored.RevokedAt != nil {
+		if err := s.RefreshRepo.RevokeFamily(stored.FamilyID); err != nil {
+			return dto.AuthResponse{}, err
+		}
+		return dto.AuthResponse{}, constants.ErrInvalidRefreshToken
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return dto.AuthResponse{}, constants.ErrInvalidRefreshToken
+	}
+
+	// Deleted users, and tokens issued before the user's tokens were revoked, are out
+	user, err := s.Repo.GetByID(stored.UserID, sessionUserFields)
+	if errors.Is(err, constants.ErrUserNotFound) {
+		return dto.AuthResponse{}, constants.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return dto.AuthResponse{}, err
+	}
+	if user.TokenVersion != stored.TokenVersion {
+		return dto.AuthResponse{}, constants.ErrInvalidRefreshToken
+	}
+
+	// Start transaction. The token is used up and its successor issued together.
+	tx, refreshRepo := s.RefreshRepo.BeginTransaction()
+
+	if err := refreshRepo.Revoke(stored); err != nil {
+		refreshRepo.RollbackTransaction(tx)
+		// Used concurrently, which is a replay as well
+		if errors.Is(err, constants.ErrInvalidRefreshToken) {
+			if err := s.RefreshRepo.RevokeFamily(stored.FamilyID); err != nil {
+				return dto.AuthResponse{}, err
+			}
+		}
+		return dto.AuthResponse{}, err
+	}
+	response, err := s.issueTokens(refreshRepo, user, stored.FamilyID)
+	if err != nil {
+		refreshRepo.RollbackTransaction(tx)
+		return dto.AuthResponse{}, err
+	}
+
+	if err := refreshRepo.CommitTransaction(tx); err != nil {
+		return dto.AuthResponse{}, err
+	}
+	return response, nil
+}
+
+// Logout ends the session of a refresh token. With All, it logs the user
+// out everywhere: the token version is bumped, so their access tokens stop
+// working right away rather than when they expire. Only a token that could
+// still be refreshed is accepted, so an old one can't log the user out.
+func (s *AuthService) Logout(req dto.LogoutRequest) error {
+	stored, err := s.RefreshRepo.GetByHash(auth.HashToken(req.RefreshToken))
+	if err != nil {
+		return err
+	}
+	if stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return constants.ErrInvalidRefreshToken
+	}
+	user, err := s.Repo.GetByID(stored.UserID, []string{"id", "token_version"})
+	if errors.Is(err, constants.ErrUserNotFound) {
+		return constants.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+	if user.TokenVersion != stored.TokenVersion {
+		return constants.ErrInvalidRefreshToken
+	}
+
+	if req.All {
+		if err := s.Repo.IncrementTokenVersion(stored.UserID); err != nil {
+			return err
+		}
+		return s.RefreshRepo.RevokeAllForUser(stored.UserID)
+	}
+	return s.RefreshRepo.RevokeFamily(stored.FamilyID)
+}
+
+// VerifyAccessToken checks an access token and that it wasn't revoked
+// since it was issued. The role in the claims is replaced with the user's
+// current one, so a demotion takes effect on the next request.
+func (s *AuthService) VerifyAccessToken(token string) (*auth.Claims, error) {
+	claims, err := auth.ValidateToken(token)
+	if err != nil {
+		return nil, constants.ErrInvalidOrExpiredToken
+	}
+
+	user, err := s.Repo.GetByID(claims.UserID, []string{"id", "role", "token_version"})
+	if errors.Is(err, constants.ErrUserNotFound) {
+		return nil, constants.ErrTokenRevoked
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.TokenVersion != claims.TokenVersion {
+		return nil, constants.ErrTokenRevoked
 	}
 
+	claims.Role = user.Role
+	return claims, nil
+}
+
+// startSession issues the tokens of a new login
+func (s *AuthService) startSession(user *models.User) (dto.AuthResponse, error) {
+	familyID, err := auth.NewRandomToken()
+	if err != nil {
+		return dto.AuthResponse{}, err
+	}
+	return s.issueTokens(s.RefreshRepo, user, familyID)
+}
+
+// issueTokens issues an access token and a refresh token of the family
+func (s *AuthService) issueTokens(refreshRepo repository.RefreshTokenRepositoryInterface, user *models.User, familyID string) (dto.AuthResponse, error) {
 	// Generate JWT token
-	token, err := auth.GenerateToken(user.ID, user.Role)
+	token, err := auth.GenerateToken(user.ID, user.Role, user.TokenVersion, s.Config.AccessTokenTTL)
 	if err != nil {
-		return "", dto.UserResponse{}, err
+		return dto.AuthResponse{}, err
 	}
-	// Map user to response DTO
-	userResponse := mappers.MapUserToResponse(user)
-	return token, userResponse, nil
+
+	refreshToken, err := auth.NewRandomToken()
+	if err != nil {
+		return dto.AuthResponse{}, err
+	}
+	err = refreshRepo.Create(&models.RefreshToken{
+		UserID:       user.ID,
+		FamilyID:     familyID,
+		TokenHash:    auth.HashToken(refreshToken),
+		TokenVersion: user.TokenVersion,
+		ExpiresAt:    time.Now().Add(s.Config.RefreshTokenTTL),
+	})
+	if err != nil {
+		return dto.AuthResponse{}, err
+	}
+
+	return dto.AuthResponse{
+		Token:        token,
+		ExpiresIn:    int(s.Config.AccessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+		User:         mappers.MapUserToResponse(user),
+	}, nil
 }
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"library-management/config"
 	"library-management/internal/constants"
 	"library-management/internal/dto"
 	"library-management/internal/mocks"
@@ -9,14 +10,25 @@ import (
 	"library-management/internal/utils/auth"
 	"library-management/internal/utils/mappers"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
+var testAuthConfig = config.AuthConfig{
+	AccessTokenTTL:  15 * time.Minute,
+	RefreshTokenTTL: 30 * 24 * time.Hour,
+	MaxFailedLogins: 5,
+	LockoutDuration: 15 * time.Minute,
+}
+
 func TestRegister_Success(t *testing.T) {
 	mockRepo := new(mocks.UserRepositoryInterface)
-	authService := services.NewAuthService(mockRepo)
+	refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+	verification := new(mocks.EmailVerificationServiceInterface)
+	authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), verification, testAuthConfig)
 
 	req := dto.UserRegisterRequest{
 		Name:     "John Doe",
@@ -28,18 +40,24 @@ func TestRegister_Success(t *testing.T) {
 	user.Password, _ = auth.HashPassword(req.Password)
 	mockRepo.On("GetByEmail", user.Email, mock.Anything).Return(nil, nil)
 	mockRepo.On("Create", mock.Anything).Return(user, nil)
+	refreshRepo.On("Create", mock.Anything).Return(nil)
+	verification.On("SendVerification", user.ID).Return(nil)
 
-	token, userResponse, err := authService.Register(req)
+	response, err := authService.Register(req)
 
 	assert.NoError(t, err)
-	assert.NotEmpty(t, token)
-	assert.Equal(t, req.Email, userResponse.Email)
+	assert.NotEmpty(t, response.Token)
+	assert.NotEmpty(t, response.RefreshToken)
+	assert.Equal(t, req.Email, response.User.Email)
+	assert.Nil(t, response.User.EmailVerifiedAt)
 	mockRepo.AssertExpectations(t)
+	verification.AssertExpectations(t)
 }
 
 func TestRegister_EmailAlreadyExists(t *testing.T) {
 	mockRepo := new(mocks.UserRepositoryInterface)
-	authService := services.NewAuthService(mockRepo)
+	refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
 
 	req := dto.UserRegisterRequest{
 		Email: "existing@example.com",
@@ -47,19 +65,41 @@ func TestRegister_EmailAlreadyExists(t *testing.T) {
 
 	mockRepo.On("GetByEmail", req.Email, mock.Anything).Return(&models.User{}, nil)
 
-	token, userResponse, err := authService.Register(req)
+	response, err := authService.Register(req)
 
 	assert.Error(t, err)
 	assert.Equal(t, constants.ErrEmailTaken, err)
-	assert.Empty(t, token)
-	assert.Empty(t, userResponse)
+	assert.Empty(t, response)
 	mockRepo.AssertCalled(t, "GetByEmail", req.Email, mock.Anything)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestRegister_EmailTakenMeanwhile(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+	req := dto.UserRegisterRequest{
+		Name:     "John Doe",
+		Email:    "johna@example.com",
+		Password: "Aa12345@",
+	}
+
+	// Another registration got the email in between the check and the insert
+	mockRepo.On("GetByEmail", req.Email, mock.Anything).Return(nil, constants.ErrUserNotFound)
+	mockRepo.On("Create", mock.Anything).Return(nil, constants.ErrEmailTaken)
+
+	response, err := authService.Register(req)
+
+	assert.Equal(t, constants.ErrEmailTaken, err)
+	assert.Empty(t, response)
+	refreshRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
 func TestLogin_Success(t *testing.T) {
 	mockRepo := new(mocks.UserRepositoryInterface)
-	authService := services.NewAuthService(mockRepo)
+	refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
 
 	req := dto.UserLoginRequest{
 		Email:    "johasn@example.com",
@@ -74,19 +114,22 @@ func TestLogin_Success(t *testing.T) {
 	}
 
 	mockRepo.On("GetByEmail", req.Email, mock.Anything).Return(&user, nil)
+	refreshRepo.On("Create", mock.Anything).Return(nil)
 
-	token, userResponse, err := authService.Login(req)
+	response, err := authService.Login(req, "10.0.0.1")
 
 	assert.NoError(t, err)
-	assert.NotEmpty(t, token)
-	assert.Equal(t, req.Email, userResponse.Email)
+	assert.NotEmpty(t, response.Token)
+	assert.NotEmpty(t, response.RefreshToken)
+	assert.Equal(t, req.Email, response.User.Email)
 	mockRepo.AssertCalled(t, "GetByEmail", req.Email, mock.Anything)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestLogin_InvalidPassword(t *testing.T) {
 	mockRepo := new(mocks.UserRepositoryInterface)
-	authService := services.NewAuthService(mockRepo)
+	refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
 
 	req := dto.UserLoginRequest{
 		Email:    "john@example.com",
@@ -100,12 +143,272 @@ func TestLogin_InvalidPassword(t *testing.T) {
 	}
 
 	mockRepo.On("GetByEmail", req.Email, mock.Anything).Return(&user, nil)
+	mockRepo.On("RecordFailedLogin", user.ID, mock.AnythingOfType("time.Time")).Return(1, nil)
 
-	token, userResponse, err := authService.Login(req)
+	response, err := authService.Login(req, "10.0.0.1")
 
 	assert.Error(t, err)
 	assert.Equal(t, constants.ErrInvalidCredentials, err)
-	assert.Empty(t, token)
-	assert.Empty(t, userResponse)
+	assert.Empty(t, response)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestLogin_UnknownEmail(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, new(mocks.RefreshTokenRepositoryInterface), new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+	mockRepo.On("GetByEmail", "nobody@example.com", mock.Anything).Return(nil, constants.ErrUserNotFound)
+
+	_, err := authService.Login(dto.UserLoginRequest{Email: "nobody@example.com", Password: "Aa12345@"}, "10.0.0.1")
+
+	assert.Equal(t, constants.ErrInvalidCredentials, err)
+	mockRepo.AssertNotCalled(t, "RecordFailedLogin", mock.Anything, mock.Anything)
+}
+
+func TestLogin_LocksAccount(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	auditRepo := new(mocks.AuditRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, new(mocks.RefreshTokenRepositoryInterface), auditRepo, new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+	hashedPassword, _ := auth.HashPassword("Aa12345@")
+	lastFailure := time.Now().Add(-time.Minute)
+	user := &models.User{Email: "john@example.com", Password: hashedPassword, FailedLogins: 4, LastFailedLoginAt: &lastFailure}
+	user.ID = 1
+	var entry *models.AuditEntry
+	mockRepo.On("GetByEmail", "john@example.com", mock.Anything).Return(user, nil)
+	mockRepo.On("RecordFailedLogin", uint(1), mock.AnythingOfType("time.Time")).Return(5, nil)
+	mockRepo.On("Lock", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
+	auditRepo.On("Create", mock.AnythingOfType("*models.AuditEntry")).
+		Run(func(args mock.Arguments) { entry = args.Get(0).(*models.AuditEntry) }).Return(nil)
+
+	_, err := authService.Login(dto.UserLoginRequest{Email: "john@example.com", Password: "Bb12789@"}, "10.0.0.1")
+
+	assert.Equal(t, constants.ErrInvalidCredentials, err)
+	mockRepo.AssertExpectations(t)
+	assert.Equal(t, string(constants.AuditAccountLocked), entry.Action)
+	assert.Equal(t, uint(1), *entry.UserID)
+	assert.Equal(t, "10.0.0.1", entry.IP)
+}
+
+func TestLogin_HeldBack(t *testing.T) {
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	lastFailure := time.Now().Add(-time.Second)
+	testCases := []struct {
+		name string
+		user models.User
+	}{
+		{name: "Locked out", user: models.User{LockedUntil: &lockedUntil}},
+		{name: "Too soon after a failed login", user: models.User{FailedLogins: 3, LastFailedLoginAt: &lastFailure}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.UserRepositoryInterface)
+			refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+			authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+			// Even the right password is refused like a wrong one
+			hashedPassword, _ := auth.HashPassword("Aa12345@")
+			user := tc.user
+			user.Email = "john@example.com"
+			user.Password = hashedPassword
+			mockRepo.On("GetByEmail", "john@example.com", mock.Anything).Return(&user, nil)
+
+			_, err := authService.Login(dto.UserLoginRequest{Email: "john@example.com", Password: "Aa12345@"}, "10.0.0.1")
+
+			assert.Equal(t, constants.ErrInvalidCredentials, err)
+			mockRepo.AssertNotCalled(t, "RecordFailedLogin", mock.Anything, mock.Anything)
+			refreshRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestLogin_ClearsFailedLogins(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+	hashedPassword, _ := auth.HashPassword("Aa12345@")
+	lastFailure := time.Now().Add(-5 * time.Second)
+	user := &models.User{Email: "john@example.com", Password: hashedPassword, FailedLogins: 2, LastFailedLoginAt: &lastFailure}
+	user.ID = 1
+	mockRepo.On("GetByEmail", "john@example.com", mock.Anything).Return(user, nil)
+	mockRepo.On("ClearFailedLogins", uint(1)).Return(nil)
+	refreshRepo.On("Create", mock.Anything).Return(nil)
+
+	_, err := authService.Login(dto.UserLoginRequest{Email: "john@example.com", Password: "Aa12345@"}, "10.0.0.1")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+	stored := &models.RefreshToken{ID: 7, UserID: 1, FamilyID: "family", TokenVersion: 2, ExpiresAt: time.Now().Add(time.Hour)}
+	user := &models.User{Email: "john@example.com", Role: string(constants.Member), TokenVersion: 2}
+	user.ID = 1
+	tx := &gorm.DB{}
+
+	refreshRepo.On("GetByHash", auth.HashToken("old-token")).Return(stored, nil)
+	mockRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	refreshRepo.On("BeginTransaction").Return(tx, refreshRepo)
+	refreshRepo.On("Revoke", stored).Return(nil)
+	refreshRepo.On("Create", mock.MatchedBy(func(token *models.RefreshToken) bool {
+		return token.FamilyID == "family" && token.TokenVersion == 2 && token.TokenHash != auth.HashToken("old-token")
+	})).Return(nil)
+	refreshRepo.On("CommitTransaction", tx).Return(nil)
+
+	response, err := authService.Refresh(dto.RefreshRequest{RefreshToken: "old-token"})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.Token)
+	assert.NotEqual(t, "old-token", response.RefreshToken)
+	assert.Equal(t, 900, response.ExpiresIn)
+	refreshRepo.AssertExpectations(t)
+}
+
+func TestRefresh_ReusedTokenRevokesFamily(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+	usedAt := time.Now().Add(-time.Minute)
+	stored := &models.RefreshToken{ID: 7, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &usedAt}
+
+	refreshRepo.On("GetByHash", auth.HashToken("old-token")).Return(stored, nil)
+	refreshRepo.On("RevokeFamily", "family").Return(nil)
+
+	_, err := authService.Refresh(dto.RefreshRequest{RefreshToken: "old-token"})
+
+	assert.ErrorIs(t, err, constants.ErrInvalidRefreshToken)
+	refreshRepo.AssertExpectations(t)
+	refreshRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRefresh_Refused(t *testing.T) {
+	testCases := []struct {
+		name   string
+		stored *models.RefreshToken
+		user   *models.User
+		err    error
+	}{
+		{name: "Unknown token", err: constants.ErrInvalidRefreshToken},
+		{name: "Expired", stored: &models.RefreshToken{UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}},
+		{name: "User deleted", stored: &models.RefreshToken{UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}},
+		{name: "Tokens revoked since", stored: &models.RefreshToken{UserID: 1, TokenVersion: 1, ExpiresAt: time.Now().Add(time.Hour)}, user: &models.User{TokenVersion: 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.UserRepositoryInterface)
+			refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+			authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+			refreshRepo.On("GetByHash", mock.Anything).Return(tc.stored, tc.err)
+			if tc.user != nil {
+				mockRepo.On("GetByID", uint(1), mock.Anything).Return(tc.user, nil)
+			} else {
+				mockRepo.On("GetByID", uint(1), mock.Anything).Return(nil, constants.ErrUserNotFound)
+			}
+
+			_, err := authService.Refresh(dto.RefreshRequest{RefreshToken: "token"})
+
+			assert.ErrorIs(t, err, constants.ErrInvalidRefreshToken)
+			refreshRepo.AssertNotCalled(t, "BeginTransaction")
+		})
+	}
+}
+
+func TestLogout_All(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+	refreshRepo.On("GetByHash", auth.HashToken("token")).Return(&models.RefreshToken{UserID: 1, FamilyID: "family", TokenVersion: 2, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	mockRepo.On("GetByID", uint(1), mock.Anything).Return(&models.User{TokenVersion: 2}, nil)
+	mockRepo.On("IncrementTokenVersion", uint(1)).Return(nil)
+	refreshRepo.On("RevokeAllForUser", uint(1)).Return(nil)
+
+	err := authService.Logout(dto.LogoutRequest{RefreshToken: "token", All: true})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	refreshRepo.AssertExpectations(t)
+}
+
+func TestLogout_InvalidToken(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Minute)
+	testCases := []struct {
+		name  string
+		token *models.RefreshToken
+	}{
+		{name: "Already rotated", token: &models.RefreshToken{UserID: 1, TokenVersion: 2, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}},
+		{name: "Expired", token: &models.RefreshToken{UserID: 1, TokenVersion: 2, ExpiresAt: time.Now().Add(-time.Minute)}},
+		{name: "Issued before the password changed", token: &models.RefreshToken{UserID: 1, TokenVersion: 1, ExpiresAt: time.Now().Add(time.Hour)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.UserRepositoryInterface)
+			refreshRepo := new(mocks.RefreshTokenRepositoryInterface)
+			authService := services.NewAuthService(mockRepo, refreshRepo, new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+			refreshRepo.On("GetByHash", auth.HashToken("token")).Return(tc.token, nil)
+			mockRepo.On("GetByID", uint(1), mock.Anything).Return(&models.User{TokenVersion: 2}, nil)
+
+			err := authService.Logout(dto.LogoutRequest{RefreshToken: "token", All: true})
+
+			assert.Equal(t, constants.ErrInvalidRefreshToken, err)
+			mockRepo.AssertNotCalled(t, "IncrementTokenVersion", mock.Anything)
+			refreshRepo.AssertNotCalled(t, "RevokeAllForUser", mock.Anything)
+		})
+	}
+}
+
+func TestVerifyAccessToken(t *testing.T) {
+	token, _ := auth.GenerateToken(1, string(constants.Admin), 3, time.Minute)
+
+	testCases := []struct {
+		name     string
+		user     *models.User
+		err      error
+		expected error
+	}{
+		{name: "Valid", user: &models.User{Role: string(constants.Member), TokenVersion: 3}},
+		{name: "Revoked", user: &models.User{Role: string(constants.Admin), TokenVersion: 4}, expected: constants.ErrTokenRevoked},
+		{name: "User deleted", err: constants.ErrUserNotFound, expected: constants.ErrTokenRevoked},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.UserRepositoryInterface)
+			authService := services.NewAuthService(mockRepo, new(mocks.RefreshTokenRepositoryInterface), new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+
+			mockRepo.On("GetByID", uint(1), mock.Anything).Return(tc.user, tc.err)
+
+			claims, err := authService.VerifyAccessToken(token)
+
+			if tc.expected != nil {
+				assert.ErrorIs(t, err, tc.expected)
+				return
+			}
+			assert.NoError(t, err)
+			// The role comes from the user, not the token
+			assert.Equal(t, string(constants.Member), claims.Role)
+		})
+	}
+}
+
+func TestVerifyAccessToken_Expired(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	authService := services.NewAuthService(mockRepo, new(mocks.RefreshTokenRepositoryInterface), new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface), testAuthConfig)
+	token, _ := auth.GenerateToken(1, string(constants.Member), 0, -time.Minute)
+
+	_, err := authService.VerifyAccessToken(token)
+
+	assert.ErrorIs(t, err, constants.ErrInvalidOrExpiredToken)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/repository"
+	"library-management/internal/utils/mappers"
+)
+
+type AuthorServiceInterface interface {
+	CreateAuthor(req dto.AuthorCreateRequest) (dto.AuthorResponse, error)
+	GetAuthor(id uint) (dto.AuthorResponse, error)
+	GetAllAuthors(name string, page, limit int) ([]dto.AuthorResponse, int64, error)
+	GetAuthorBooks(id uint, page, limit int) ([]dto.BookResponse, int64, error)
+	UpdateAuthor(id uint, req dto.AuthorUpdateRequest) (dto.AuthorResponse, error)
+	DeleteAuthor(id uint) error
+}
+
+type AuthorService struct {
+	Repo     repository.AuthorRepositoryInterface
+	BookRepo repository.BookRepositoryInterface
+}
+
+func NewAuthorService(repo repository.AuthorRepositoryInterface, bookRepo repository.BookRepositoryInterface) AuthorServiceInterface {
+	return &AuthorService{Repo: repo, BookRepo: bookRepo}
+}
+
+// Create Author
+func (s *AuthorService) CreateAuthor(req dto.AuthorCreateRequest) (dto.AuthorResponse, error) {
+	author := mappers.MapCreateRequestToAuthor(req)
+	if author.Name == "" {
+		return dto.AuthorResponse{}, constants.ErrInvalidInput
+	}
+
+	// Check if the author already exists
+	existingAuthor, _ := s.Repo.GetByName(author.Name)
+	if existingAuthor != nil {
+		return dto.AuthorResponse{}, constants.ErrAuthorExists
+	}
+
+	author, err := s.Repo.Create(author)
+	if err != nil {
+		return dto.AuthorResponse{}, err
+	}
+	return mappers.MapAuthorToResponse(author), nil
+}
+
+// Get Author by ID
+func (s *AuthorService) GetAuthor(id uint) (dto.AuthorResponse, error) {
+	author, err := s.Repo.GetByID(id)
+	if err != nil {
+		return dto.AuthorResponse{}, err
+	}
+	return mappers.MapAuthorToResponse(author), nil
+}
+
+// Get All Authors, optionally only those whose name contains the given one
+func (s *AuthorService) GetAllAuthors(name string, page, limit int) ([]dto.AuthorResponse, int64, error) {
+	authors, total, err := s.Repo.GetAll(mappers.NormalizeAuthorName(name), page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	authorResponses := make([]dto.AuthorResponse, len(authors))
+	for i, author := range authors {
+		authorResponses[i] = mappers.MapAuthorToResponse(&author)
+	}
+	return authorResponses, total, nil
+}
+
+// GetAuthorBooks lists the books credited to an author, newest first
+func (s *AuthorService) GetAuthorBooks(id uint, page, limit int) ([]dto.BookResponse, int64, error) {
+	if _, err := s.Repo.GetByID(id); err != nil {
+		return nil, 0, err
+	}
+
+	books, total, err := s.BookRepo.GetAll(dto.BookFilter{AuthorID: id}, page, limit, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bookResponses := make([]dto.BookResponse, len(books))
+	for i, book := range books {
+		bookResponses[i] = mappers.MapBookToResponse(&book)
+	}
+	return bookResponses, total, nil
+}
+
+// Update Author. Renaming an author also renames it on its books.
+func (s *AuthorService) UpdateAuthor(id uint, req dto.AuthorUpdateRequest) (dto.AuthorResponse, error) {
+	author, err := s.Repo.GetByID(id)
+	if err != nil {
+		return dto.AuthorResponse{}, err
+	}
+	previousName := author.Name
+
+	mappers.UpdateAuthorFromDTO(author, req)
+	if author.Name == "" {
+		return dto.AuthorResponse{}, constants.ErrInvalidInput
+	}
+
+	if author.Name != previousName {
+		// Check if the name is already used by another author
+		existingAuthor, _ := s.Repo.GetByName(author.Name)
+		if existingAuthor != nil && existingAuthor.ID != id {
+			return dto.AuthorResponse{}, constants.ErrAuthorExists
+		}
+	}
+
+	if err := s.Repo.Update(author); err != nil {
+		return dto.AuthorResponse{}, err
+	}
+	if author.Name != previousName {
+		if err := s.BookRepo.RefreshAuthorNames(id); err != nil {
+			return dto.AuthorResponse{}, err
+		}
+	}
+	return mappers.MapAuthorToResponse(author), nil
+}
+
+// Delete Author, unless books are still credited to it
+func (s *AuthorService) DeleteAuthor(id uint) error {
+	if _, err := s.Repo.GetByID(id); err != nil {
+		return err
+	}
+
+	books, err := s.Repo.CountBooks(id)
+	if err != nil {
+		return err
+	}
+	if books > 0 {
+		return constants.ErrAuthorHasBooks
+	}
+
+	return s.Repo.Delete(id)
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"library-management/config"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/repository"
+	"library-management/internal/utils/mappers"
+)
+
+type BookCopyServiceInterface interface {
+	AddCopy(bookID uint, req dto.BookCopyCreateRequest) (dto.BookCopyResponse, error)
+	GetBookCopies(bookID uint) ([]dto.BookCopyResponse, error)
+	GetCopyByBarcode(barcode string) (dto.BookCopyResponse, error)
+	UpdateCopy(id uint, req dto.BookCopyUpdateRequest) (dto.BookCopyResponse, error)
+	DeleteCopy(id uint) error
+}
+
+type BookCopyService struct {
+	CopyRepo    repository.BookCopyRepositoryInterface
+	BookRepo    repository.BookRepositoryInterface
+	HoldRepo    repository.HoldRepositoryInterface
+	Circulation config.CirculationConfig
+}
+
+func NewBookCopyService(copyRepo repository.BookCopyRepositoryInterface, bookRepo repository.BookRepositoryInterface, holdRepo repository.HoldRepositoryInterface, circulation config.CirculationConfig) BookCopyServiceInterface {
+	return &BookCopyService{
+		CopyRepo:    copyRepo,
+		BookRepo:    bookRepo,
+		HoldRepo:    holdRepo,
+		Circulation: circulation,
+	}
+}
+
+// AddCopy registers a new physical copy of a book. If members are waiting
+// for the book, the copy is set aside for the first one in line.
+func (s *BookCopyService) AddCopy(bookID uint, req dto.BookCopyCreateRequest) (dto.BookCopyResponse, error) {
+	// Check if the book exists
+	if _, err := s.BookRepo.GetByID(bookID, nil); err != nil {
+		return dto.BookCopyResponse{}, constants.ErrBookNotFound
+	}
+
+	// Check if the barcode is already in use
+	if existingCopy, _ := s.CopyRepo.GetByBarcode(req.Barcode); existingCopy != nil {
+		return dto.BookCopyResponse{}, constants.ErrBarcodeExists
+	}
+
+	bookCopy := mappers.MapCopyCreateRequestToCopy(req, bookID)
+
+	// Start transaction
+	tx, holdRepo := s.HoldRepo.BeginTransaction()
+	copyRepo := s.CopyRepo.WithTx(tx)
+
+	if err := copyRepo.Create(bookCopy); err != nil {
+		holdRepo.RollbackTransaction(tx)
+		return dto.BookCopyResponse{}, err
+	}
+	if err := releaseCopy(holdRepo, copyRepo, bookCopy.ID, s.Circulation.HoldPickupWindow); err != nil {
+		holdRepo.RollbackTransaction(tx)
+		return dto.BookCopyResponse{}, err
+	}
+
+	if err := holdRepo.CommitTransaction(tx); err != nil {
+		return dto.BookCopyResponse{}, err
+	}
+
+	return s.getCopy(bookCopy.ID)
+}
+
+// GetBookCopies lists all copies of a book
+func (s *BookCopyService) GetBookCopies(bookID uint) ([]dto.BookCopyResponse, error) {
+	// Check if the book exists
+	if _, err := s.BookRepo.GetByID(bookID, nil); err != nil {
+		return nil, constants.ErrBookNotFound
+	}
+
+	copies, err := s.CopyRepo.GetByBookID(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	copyResponses := make([]dto.BookCopyResponse, len(copies))
+	for i, bookCopy := range copies {
+		copyResponses[i] = mappers.MapCopyToResponse(&bookCopy)
+	}
+	return copyResponses, nil
+}
+
+// GetCopyByBarcode looks up the copy behind a scanned barcode
+func (s *BookCopyService) GetCopyByBarcode(barcode string) (dto.BookCopyResponse, error) {
+	bookCopy, err := s.CopyRepo.GetByBarcode(barcode)
+	if err != nil {
+		return dto.BookCopyResponse{}, err
+	}
+	return mappers.MapCopyToResponse(bookCopy), nil
+}
+
+// UpdateCopy updates a copy's details and shelf status. A copy that becomes
+// available again goes to the hold queue first, like a returned one.
+func (s *BookCopyService) UpdateCopy(id uint, req dto.BookCopyUpdateRequest) (dto.BookCopyResponse, error) {
+	bookCopy, err := s.CopyRepo.GetByID(id)
+	if err != nil {
+		return dto.BookCopyResponse{}, err
+	}
+
+	if req.Barcode != nil && *req.Barcode != bookCopy.Barcode {
+		// Check if the barcode is already in use by another copy
+		if existingCopy, _ := s.CopyRepo.GetByBarcode(*req.Barcode); existingCopy != nil {
+			return dto.BookCopyResponse{}, constants.ErrBarcodeExists
+		}
+	}
+
+	from := constants.CopyStatus(bookCopy.Status)
+	to := from
+	if req.Status != nil {
+		to = constants.CopyStatus(*req.Status)
+	}
+	// Loans and holds are closed through their own endpoints
+	if to != from && from.InCirculation() {
+		return dto.BookCopyResponse{}, constants.ErrCopyInCirculation
+	}
+
+	mappers.UpdateCopyFromDTO(bookCopy, req)
+
+	// Start transaction
+	tx, holdRepo := s.HoldRepo.BeginTransaction()
+	copyRepo := s.CopyRepo.WithTx(tx)
+
+	if err := copyRepo.Update(bookCopy); err != nil {
+		holdRepo.RollbackTransaction(tx)
+		return dto.BookCopyResponse{}, err
+	}
+
+	if to != from {
+		if to == constants.CopyAvailable {
+			err = releaseCopy(holdRepo, copyRepo, bookCopy.ID, s.Circulation.HoldPickupWindow)
+		} else {
+			bookCopy.Status = string(to)
+			err = copyRepo.UpdateStatus(bookCopy, from)
+		}
+		if err != nil {
+			holdRepo.RollbackTransaction(tx)
+			return dto.BookCopyResponse{}, err
+		}
+	}
+
+	if err := holdRepo.CommitTransaction(tx); err != nil {
+		return dto.BookCopyResponse{}, err
+	}
+
+	return s.getCopy(bookCopy.ID)
+}
+
+// DeleteCopy removes a copy that was registered by mistake.
+// Copies that leave the collection should be marked as withdrawn instead.
+func (s *BookCopyService) DeleteCopy(id uint) error {
+	if _, err := s.CopyRepo.GetByID(id); err != nil {
+		return err
+	}
+
+	return s.CopyRepo.Delete(id)
+}
+
+func (s *BookCopyService) getCopy(id uint) (dto.BookCopyResponse, error) {
+	bookCopy, err := s.CopyRepo.GetByID(id)
+	if err != nil {
+		return dto.BookCopyResponse{}, err
+	}
+	return mappers.MapCopyToResponse(bookCopy), nil
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/repository"
+	"library-management/internal/storage"
+	"library-management/internal/utils/imaging"
+	"library-management/internal/utils/mappers"
+	"log"
+	"net/http"
+
+	// Decoders for the cover image types
+	_ "image/gif"
+	_ "image/png"
+)
+
+type BookCoverServiceInterface interface {
+	UploadCover(bookID uint, content io.Reader) (dto.BookResponse, error)
+	OpenCover(bookID uint, thumbnail bool) (dto.CoverFile, error)
+	DeleteCover(bookID uint) error
+}
+
+// Cover thumbnails are scaled down to fit within these bounds and saved as JPEG
+const (
+	thumbnailWidth   = 200
+	thumbnailHeight  = 300
+	thumbnailQuality = 85
+)
+
+// maxCoverDimension keeps images that would take too much memory to decode out
+const maxCoverDimension = 4000
+
+// coverExtensions lists the image types accepted as covers, with the
+// extension their files are stored with
+var coverExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+type BookCoverService struct {
+	Repo    repository.BookRepositoryInterface
+	Storage storage.Storage
+}
+
+func NewBookCoverService(repo repository.BookRepositoryInterface, store storage.Storage) BookCoverServiceInterface {
+	return &BookCoverService{
+		Repo:    repo,
+		Storage: store,
+	}
+}
+
+// UploadCover sets the cover of a book, replacing its current one, and
+// stores a thumbnail of it along with it
+func (s *BookCoverService) UploadCover(bookID uint, content io.Reader) (dto.BookResponse, error) {
+	book, err := s.Repo.GetByID(bookID, []string{})
+	if err != nil {
+		return dto.BookResponse{}, err
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return dto.BookResponse{}, err
+	}
+
+	// Go by the content of the file, not by the type the client claims
+	contentType := http.DetectContentType(data)
+	if _, ok := coverExtensions[contentType]; !ok {
+		return dto.BookResponse{}, constants.ErrUnsupportedCoverType
+	}
+
+	// Check the size of the image before decoding all of it
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width > maxCoverDimension || config.Height > maxCoverDimension {
+		return dto.BookResponse{}, constants.ErrInvalidCoverImage
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return dto.BookResponse{}, constants.ErrInvalidCoverImage
+	}
+	var thumbnail bytes.Buffer
+	if err := jpeg.Encode(&thumbnail, imaging.Thumbnail(img, thumbnailWidth, thumbnailHeight), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return dto.BookResponse{}, err
+	}
+
+	previous := *book
+	sum := sha256.Sum256(data)
+	book.CoverVersion = hex.EncodeToString(sum[:8])
+	book.CoverContentType = contentType
+	// The same image uploaded again has the same files, which must be kept
+	isNew := book.CoverVersion != previous.CoverVersion
+
+	if err := s.Storage.Put(coverKey(book), bytes.NewReader(data)); err != nil {
+		return dto.BookResponse{}, err
+	}
+	if err := s.Storage.Put(thumbnailKey(book), &thumbnail); err != nil {
+		if isNew {
+			s.removeCoverFiles(book)
+		}
+		return dto.BookResponse{}, err
+	}
+	if err := s.Repo.UpdateCover(book); err != nil {
+		if isNew {
+			s.removeCoverFiles(book)
+		}
+		return dto.BookResponse{}, err
+	}
+	if isNew && previous.CoverVersion != "" {
+		s.removeCoverFiles(&previous)
+	}
+
+	// Map book to response DTO
+	bookResponse := mappers.MapBookToResponse(book)
+	return bookResponse, nil
+}
+
+// OpenCover opens the cover image of a book, or its thumbnail
+func (s *BookCoverService) OpenCover(bookID uint, thumbnail bool) (dto.CoverFile, error) {
+	book, err := s.Repo.GetByID(bookID, []string{})
+	if err != nil {
+		return dto.CoverFile{}, err
+	}
+	if book.CoverVersion == "" {
+		return dto.CoverFile{}, constants.ErrCoverNotFound
+	}
+
+	key, contentType := coverKey(book), book.CoverContentType
+	if thumbnail {
+		key, contentType = thumbnailKey(book), "image/jpeg"
+	}
+	content, err := s.Storage.Open(key)
+	if errors.Is(err, constants.ErrFileNotFound) {
+		log.Printf("⚠️ Cover %s of book %d is missing from storage", key, book.ID)
+		return dto.CoverFile{}, constants.ErrCoverNotFound
+	}
+	if err != nil {
+		return dto.CoverFile{}, err
+	}
+	return dto.CoverFile{Content: content, ContentType: contentType, Version: book.CoverVersion}, nil
+}
+
+// DeleteCover removes the cover of a book and its thumbnail
+func (s *BookCoverService) DeleteCover(bookID uint) error {
+	book, err := s.Repo.GetByID(bookID, []string{})
+	if err != nil {
+		return err
+	}
+	if book.CoverVersion == "" {
+		return constants.ErrCoverNotFound
+	}
+
+	previous := *book
+	book.CoverVersion = ""
+	book.CoverContentType = ""
+	if err := s.Repo.UpdateCover(book); err != nil {
+		return err
+	}
+	s.removeCoverFiles(&previous)
+	return nil
+}
+
+// removeCoverFiles deletes the files of a cover no book uses anymore. A file
+// left behind only takes up space, so failures are logged and not returned.
+func (s *BookCoverService) removeCoverFiles(book *models.Book) {
+	for _, key := range []string{coverKey(book), thumbnailKey(book)} {
+		if err := s.Storage.Delete(key); err != nil {
+			log.Printf("⚠️ Failed to delete cover %s: %v", key, err)
+		}
+	}
+}
+
+// coverKey is where the cover image of a book is stored, e.g. "covers/12/3f2a9c0d1e4b5a67.jpg"
+func coverKey(book *models.Book) string {
+	return fmt.Sprintf("covers/%d/%s.%s", book.ID, book.CoverVersion, coverExtensions[book.CoverContentType])
+}
+
+// thumbnailKey is where the thumbnail of a book's cover is stored
+func thumbnailKey(book *models.Book) string {
+	return fmt.Sprintf("covers/%d/%s-thumbnail.jpg", book.ID, book.CoverVersion)
+}
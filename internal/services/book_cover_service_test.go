@@ -0,0 +1,150 @@
+package services_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"library-management/internal/constants"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"library-management/internal/storage"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// coverPNG returns a PNG image of the given size
+func coverPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func newCoverService(t *testing.T) (services.BookCoverServiceInterface, *mocks.BookRepositoryInterface, storage.Storage) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := new(mocks.BookRepositoryInterface)
+	return services.NewBookCoverService(mockRepo, store), mockRepo, store
+}
+
+func TestUploadCover(t *testing.T) {
+	coverService, mockRepo, _ := newCoverService(t)
+
+	book := &models.Book{Model: gorm.Model{ID: 7}, Title: "The Go Programming Language"}
+	mockRepo.On("GetByID", uint(7), []string{}).Return(book, nil)
+	mockRepo.On("UpdateCover", mock.AnythingOfType("*models.Book")).Return(nil)
+
+	response, err := coverService.UploadCover(7, bytes.NewReader(coverPNG(t, 400, 600)))
+
+	require.NoError(t, err)
+	require.NotNil(t, response.Cover)
+	assert.Equal(t, "image/png", book.CoverContentType)
+	assert.Equal(t, "/books/7/cover?v="+book.CoverVersion, response.Cover.URL)
+	assert.Equal(t, "/books/7/cover/thumbnail?v="+book.CoverVersion, response.Cover.ThumbnailURL)
+
+	// The thumbnail is a JPEG scaled down to fit
+	file, err := coverService.OpenCover(7, true)
+	require.NoError(t, err)
+	defer file.Content.Close()
+	assert.Equal(t, "image/jpeg", file.ContentType)
+	config, err := jpeg.DecodeConfig(file.Content)
+	require.NoError(t, err)
+	assert.Equal(t, 200, config.Width)
+	assert.Equal(t, 300, config.Height)
+}
+
+func TestUploadCover_ReplacesPreviousFiles(t *testing.T) {
+	coverService, mockRepo, store := newCoverService(t)
+
+	book := &models.Book{Model: gorm.Model{ID: 7}}
+	mockRepo.On("GetByID", uint(7), []string{}).Return(book, nil)
+	mockRepo.On("UpdateCover", mock.AnythingOfType("*models.Book")).Return(nil)
+
+	_, err := coverService.UploadCover(7, bytes.NewReader(coverPNG(t, 40, 60)))
+	require.NoError(t, err)
+	firstKey := "covers/7/" + book.CoverVersion + ".png"
+
+	_, err = coverService.UploadCover(7, bytes.NewReader(coverPNG(t, 60, 40)))
+	require.NoError(t, err)
+
+	_, err = store.Open(firstKey)
+	assert.ErrorIs(t, err, constants.ErrFileNotFound)
+	file, err := store.Open("covers/7/" + book.CoverVersion + ".png")
+	require.NoError(t, err)
+	file.Close()
+}
+
+func TestUploadCover_Rejected(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  []byte
+		expected error
+	}{
+		{name: "Not an image", content: []byte("%PDF-1.7 a document"), expected: constants.ErrUnsupportedCoverType},
+		{name: "Unsupported image type", content: []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), expected: constants.ErrUnsupportedCoverType},
+		{name: "Broken image", content: coverPNG(t, 10, 10)[:40], expected: constants.ErrInvalidCoverImage},
+		{name: "Too many pixels", content: coverPNG(t, 4001, 1), expected: constants.ErrInvalidCoverImage},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			coverService, mockRepo, _ := newCoverService(t)
+			mockRepo.On("GetByID", uint(7), []string{}).Return(&models.Book{Model: gorm.Model{ID: 7}}, nil)
+
+			_, err := coverService.UploadCover(7, bytes.NewReader(tc.content))
+
+			assert.ErrorIs(t, err, tc.expected)
+			mockRepo.AssertNotCalled(t, "UpdateCover", mock.Anything)
+		})
+	}
+}
+
+func TestUploadCover_BookNotFound(t *testing.T) {
+	coverService, mockRepo, _ := newCoverService(t)
+	mockRepo.On("GetByID", uint(7), []string{}).Return(nil, constants.ErrBookNotFound)
+
+	_, err := coverService.UploadCover(7, strings.NewReader("whatever"))
+
+	assert.ErrorIs(t, err, constants.ErrBookNotFound)
+}
+
+func TestOpenCover_NoCover(t *testing.T) {
+	coverService, mockRepo, _ := newCoverService(t)
+	mockRepo.On("GetByID", uint(7), []string{}).Return(&models.Book{Model: gorm.Model{ID: 7}}, nil)
+
+	_, err := coverService.OpenCover(7, false)
+
+	assert.ErrorIs(t, err, constants.ErrCoverNotFound)
+}
+
+func TestDeleteCover(t *testing.T) {
+	coverService, mockRepo, store := newCoverService(t)
+
+	book := &models.Book{Model: gorm.Model{ID: 7}, CoverVersion: "0123456789abcdef", CoverContentType: "image/png"}
+	require.NoError(t, store.Put("covers/7/0123456789abcdef.png", strings.NewReader("cover")))
+	require.NoError(t, store.Put("covers/7/0123456789abcdef-thumbnail.jpg", strings.NewReader("thumbnail")))
+	mockRepo.On("GetByID", uint(7), []string{}).Return(book, nil)
+	mockRepo.On("UpdateCover", mock.MatchedBy(func(b *models.Book) bool { return b.CoverVersion == "" })).Return(nil)
+
+	err := coverService.DeleteCover(7)
+
+	require.NoError(t, err)
+	for _, key := range []string{"covers/7/0123456789abcdef.png", "covers/7/0123456789abcdef-thumbnail.jpg"} {
+		_, err := store.Open(key)
+		assert.ErrorIs(t, err, constants.ErrFileNotFound)
+	}
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"io"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/export"
+	"library-management/internal/models"
+)
+
+// exportBatchSize is the number of books loaded at a time during an export
+const exportBatchSize = 500
+
+// ExportBooks writes the books matching the filter to w in the given
+// format, in ID order. Books are loaded a batch at a time and written as
+// they come, so the whole catalog can be exported. Like the listing, a
+// query that is an ISBN is looked up directly.
+func (s *BookService) ExportBooks(filter dto.BookFilter, format constants.ExportFormat, w io.Writer) error {
+	writer, err := export.NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+	writeBooks := func(books []models.Book) error {
+		for i := range books {
+			if err := writer.Write(&books[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if isbn, ok := isbnSearchTerm(filter.Query); ok {
+		var books []models.Book
+		books, err = s.Repo.FindByISBN(isbn)
+		if err == nil {
+			err = writeBooks(books)
+		}
+	} else {
+		err = s.Repo.GetAllInBatches(filter, exportBatchSize, writeBooks)
+	}
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"bytes"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/marc"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportBooks_MARCXML(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), new(mocks.AuthorRepositoryInterface), new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	filter := dto.BookFilter{Tag: "go"}
+	batches := [][]models.Book{
+		{{Title: "The Go Programming Language", ISBN: "9780134190440"}},
+		{{Title: "Concurrency in Go", ISBN: "9781491941195"}},
+	}
+	mockRepo.On("GetAllInBatches", filter, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(books []models.Book) error)
+			for _, batch := range batches {
+				assert.NoError(t, fn(batch))
+			}
+		}).
+		Return(nil)
+
+	var buf bytes.Buffer
+	err := bookService.ExportBooks(filter, constants.ExportMARCXML, &buf)
+
+	assert.NoError(t, err)
+	records, err := marc.ReadXML(&buf)
+	assert.NoError(t, err)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "Concurrency in Go", marc.BookFromRecord(records[1]).Title)
+	}
+}
+
+func TestExportBooks_InvalidFormat(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), new(mocks.AuthorRepositoryInterface), new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	var buf bytes.Buffer
+	err := bookService.ExportBooks(dto.BookFilter{}, "pdf", &buf)
+
+	assert.Equal(t, constants.ErrInvalidExportFormat, err)
+	assert.Zero(t, buf.Len())
+	mockRepo.AssertNotCalled(t, "GetAllInBatches", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportBooks_ISBNQuery(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), new(mocks.AuthorRepositoryInterface), new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	mockRepo.On("FindByISBN", "9780134190440").Return([]models.Book{{Title: "The Go Programming Language", ISBN: "9780134190440"}}, nil)
+
+	var buf bytes.Buffer
+	err := bookService.ExportBooks(dto.BookFilter{Query: "0-13-419044-0"}, constants.ExportRIS, &buf)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "TI  - The Go Programming Language\r\n")
+	mockRepo.AssertNotCalled(t, "GetAllInBatches", mock.Anything, mock.Anything, mock.Anything)
+}
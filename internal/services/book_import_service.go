@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/utils/handlers"
+	"log"
+)
+
+// importBatchSize is the number of books saved per transaction during an import
+const importBatchSize = 100
+
+// ImportBooks adds the books read from an import file to the catalog.
+// Every row is checked first, with the same rules as a single new book.
+// Rows with an ISBN that is in the catalog already, or on an earlier row,
+// are skipped as duplicates, so a file can be imported again safely. If any
+// row is invalid nothing is written at all, and a dry run never writes.
+// The books are saved in batches, each in a transaction of its own; should
+// one still fail, it is rolled back and the import stops there, with the
+// report telling which rows made it in.
+func (s *BookService) ImportBooks(rows []dto.BookImportRow, dryRun bool) (dto.BookImportReport, error) {
+	results := make([]dto.BookImportResult, len(rows))
+	books := make([]*models.Book, len(rows))
+	isbns := make(map[string]bool, len(rows))
+	barcodes := make(map[string]bool)
+	var valid []int
+	invalid := false
+
+	for i, row := range rows {
+		results[i] = dto.BookImportResult{Line: row.Line, ISBN: row.Book.ISBN, Title: row.Book.Title}
+		book, err := s.checkImportRow(row, isbns, barcodes)
+		switch {
+		case errors.Is(err, constants.ErrISBNExists), errors.Is(err, constants.ErrISBNRepeated):
+			results[i].Status = constants.ImportDuplicateISBN
+			results[i].Error = err.Error()
+		case err != nil:
+			results[i].Status = constants.ImportInvalid
+			results[i].Error = err.Error()
+			invalid = true
+		default:
+			results[i].ISBN = book.ISBN
+			results[i].Status = constants.ImportCreated
+			books[i] = book
+			valid = append(valid, i)
+		}
+	}
+
+	// Leave the whole file out rather than part of it
+	if !dryRun && invalid {
+		markNotImported(results, valid)
+		valid = nil
+	}
+
+	for !dryRun && len(valid) > 0 {
+		batch := valid[:min(importBatchSize, len(valid))]
+		valid = valid[len(batch):]
+
+		failed, err := s.importBatch(rows, books, batch)
+		if err != nil {
+			markNotImported(results, batch)
+			markNotImported(results, valid)
+			switch {
+			case errors.Is(err, constants.ErrISBNExists):
+				results[failed].Status = constants.ImportDuplicateISBN
+				results[failed].Error = err.Error()
+			case errors.Is(err, constants.ErrBarcodeExists):
+				results[failed].Status = constants.ImportInvalid
+				results[failed].Error = err.Error()
+			default:
+				log.Printf("⚠️ Import stopped at line %d: %v", rows[failed].Line, err)
+				results[failed].Error = constants.ErrImportInterrupted.Error()
+			}
+			break
+		}
+		for _, i := range batch {
+			results[i].BookID = books[i].ID
+		}
+	}
+
+	report := dto.BookImportReport{DryRun: dryRun, Total: len(rows), Rows: results}
+	for _, result := range results {
+		switch result.Status {
+		case constants.ImportCreated:
+			report.Created++
+		case constants.ImportDuplicateISBN:
+			report.Duplicates++
+		case constants.ImportInvalid:
+			report.Invalid++
+		case constants.ImportNotImported:
+			report.NotImported++
+		}
+	}
+	return report, nil
+}
+
+// checkImportRow checks a row of an import file like a new book, and that
+// its ISBN and barcodes aren't taken by an earlier row either
+func (s *BookService) checkImportRow(row dto.BookImportRow, isbns, barcodes map[string]bool) (*models.Book, error) {
+	if row.Err != nil {
+		return nil, row.Err
+	}
+	if err := handlers.Validate(&row.Book); err != nil {
+		return nil, err
+	}
+
+	book, err := s.checkNewBook(row.Book)
+	if err != nil {
+		return nil, err
+	}
+	if isbns[book.ISBN] {
+		return nil, constants.ErrISBNRepeated
+	}
+	for _, bookCopy := range book.Copies {
+		if barcodes[bookCopy.Barcode] {
+			return nil, constants.ErrBarcodeExists
+		}
+	}
+
+	isbns[book.ISBN] = true
+	for _, bookCopy := range book.Copies {
+		barcodes[bookCopy.Barcode] = true
+	}
+	return book, nil
+}
+
+// importBatch saves the books of a batch in one transaction. When one of
+// them can't be saved, nothing of the batch is, and the index of that
+// book is returned with the error.
+func (s *BookService) importBatch(rows []dto.BookImportRow, books []*models.Book, batch []int) (int, error) {
+	tx, repo := s.Repo.BeginTransaction()
+	authorRepo := s.AuthorRepo.WithTx(tx)
+	tagRepo := s.TagRepo.WithTx(tx)
+
+	for _, i := range batch {
+		if err := saveNewBook(repo, authorRepo, tagRepo, books[i], rows[i].Book); err != nil {
+			repo.RollbackTransaction(tx)
+			return i, err
+		}
+	}
+	return batch[0], repo.CommitTransaction(tx)
+}
+
+// markNotImported marks the given rows as left out of the import
+func markNotImported(results []dto.BookImportResult, rows []int) {
+	for _, i := range rows {
+		results[i].Status = constants.ImportNotImported
+	}
+}
@@ -0,0 +1,145 @@
+package services_test
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func importRow(line int, title, isbn string) dto.BookImportRow {
+	return dto.BookImportRow{Line: line, Book: dto.BookCreateRequest{
+		Title:       title,
+		Authors:     []string{"Jane Doe"},
+		ISBN:        isbn,
+		PublishedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}
+}
+
+func importStatuses(report dto.BookImportReport) []constants.ImportStatus {
+	statuses := make([]constants.ImportStatus, len(report.Rows))
+	for i, row := range report.Rows {
+		statuses[i] = row.Status
+	}
+	return statuses
+}
+
+func TestImportBooks_DryRun(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), new(mocks.AuthorRepositoryInterface), new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	mockRepo.On("GetByISBN", "9780134190440").Return(nil, constants.ErrBookNotFound)
+	mockRepo.On("GetByISBN", "9780132350884").Return(&models.Book{ISBN: "9780132350884"}, nil)
+
+	rows := []dto.BookImportRow{
+		importRow(2, "The Go Programming Language", "0-13-419044-0"),
+		importRow(3, "Clean Code", "9780132350884"),
+		importRow(4, "", "9780134190440"),
+		importRow(5, "The Go Programming Language, again", "9780134190440"),
+		{Line: 6, Err: constants.ErrInvalidDate},
+	}
+	report, err := bookService.ImportBooks(rows, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []constants.ImportStatus{
+		constants.ImportCreated,
+		constants.ImportDuplicateISBN,
+		constants.ImportInvalid,
+		constants.ImportDuplicateISBN,
+		constants.ImportInvalid,
+	}, importStatuses(report))
+	assert.Equal(t, "9780134190440", report.Rows[0].ISBN)
+	assert.Equal(t, "title is required", report.Rows[2].Error)
+	assert.Equal(t, []int{5, 1, 2, 2}, []int{report.Total, report.Created, report.Duplicates, report.Invalid})
+	mockRepo.AssertNotCalled(t, "BeginTransaction")
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestImportBooks_InvalidRowWritesNothing(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), new(mocks.AuthorRepositoryInterface), new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	mockRepo.On("GetByISBN", "9780134190440").Return(nil, constants.ErrBookNotFound)
+
+	rows := []dto.BookImportRow{
+		importRow(2, "The Go Programming Language", "9780134190440"),
+		importRow(3, "Bad ISBN", "9780134190441"),
+	}
+	report, err := bookService.ImportBooks(rows, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []constants.ImportStatus{constants.ImportNotImported, constants.ImportInvalid}, importStatuses(report))
+	assert.Equal(t, 0, report.Created)
+	mockRepo.AssertNotCalled(t, "BeginTransaction")
+}
+
+func TestImportBooks_SavesInATransaction(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	authorRepo := new(mocks.AuthorRepositoryInterface)
+	tagRepo := new(mocks.TagRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), authorRepo, new(mocks.CategoryRepositoryInterface), tagRepo)
+
+	tx := &gorm.DB{}
+	mockRepo.On("GetByISBN", mock.Anything).Return(nil, constants.ErrBookNotFound)
+	mockRepo.On("BeginTransaction").Return(tx, mockRepo)
+	authorRepo.On("WithTx", tx).Return(authorRepo)
+	tagRepo.On("WithTx", tx).Return(tagRepo)
+	authorRepo.On("FindOrCreateByNames", []string{"Jane Doe"}).Return([]models.Author{{Name: "Jane Doe"}}, nil)
+	nextID := uint(10)
+	mockRepo.On("Create", mock.Anything).Return(func(book *models.Book) (*models.Book, error) {
+		nextID++
+		book.ID = nextID
+		return book, nil
+	})
+	mockRepo.On("CommitTransaction", tx).Return(nil)
+
+	rows := []dto.BookImportRow{
+		importRow(2, "The Go Programming Language", "9780134190440"),
+		importRow(3, "Clean Code", "9780132350884"),
+	}
+	report, err := bookService.ImportBooks(rows, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, uint(11), report.Rows[0].BookID)
+	assert.Equal(t, uint(12), report.Rows[1].BookID)
+	mockRepo.AssertNumberOfCalls(t, "BeginTransaction", 1)
+	mockRepo.AssertNotCalled(t, "RollbackTransaction", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportBooks_FailedBatchIsRolledBack(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	authorRepo := new(mocks.AuthorRepositoryInterface)
+	tagRepo := new(mocks.TagRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), authorRepo, new(mocks.CategoryRepositoryInterface), tagRepo)
+
+	tx := &gorm.DB{}
+	mockRepo.On("GetByISBN", mock.Anything).Return(nil, constants.ErrBookNotFound)
+	mockRepo.On("BeginTransaction").Return(tx, mockRepo)
+	authorRepo.On("WithTx", tx).Return(authorRepo)
+	tagRepo.On("WithTx", tx).Return(tagRepo)
+	authorRepo.On("FindOrCreateByNames", []string{"Jane Doe"}).Return([]models.Author{{Name: "Jane Doe"}}, nil)
+	// Another import got the second book in first
+	mockRepo.On("Create", mock.MatchedBy(func(book *models.Book) bool { return book.ISBN == "9780134190440" })).Return(&models.Book{}, nil)
+	mockRepo.On("Create", mock.Anything).Return(nil, constants.ErrISBNExists)
+	mockRepo.On("RollbackTransaction", tx).Return()
+
+	rows := []dto.BookImportRow{
+		importRow(2, "The Go Programming Language", "9780134190440"),
+		importRow(3, "Clean Code", "9780132350884"),
+	}
+	report, err := bookService.ImportBooks(rows, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []constants.ImportStatus{constants.ImportNotImported, constants.ImportDuplicateISBN}, importStatuses(report))
+	mockRepo.AssertNotCalled(t, "CommitTransaction", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
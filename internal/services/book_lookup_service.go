@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/metadata"
+	"library-management/internal/repository"
+	"library-management/internal/utils/mappers"
+	"library-management/internal/utils/validation"
+	"unicode/utf8"
+)
+
+type BookLookupServiceInterface interface {
+	LookupISBN(ctx context.Context, isbn string) (dto.BookCreateRequest, error)
+}
+
+// Subjects make the tags of a looked up book, as long as they fit in a tag
+const (
+	maxLookupTags     = 5
+	maxLookupTagChars = 50
+)
+
+type BookLookupService struct {
+	Repo     repository.BookRepositoryInterface
+	Provider metadata.MetadataProvider
+}
+
+func NewBookLookupService(repo repository.BookRepositoryInterface, provider metadata.MetadataProvider) BookLookupServiceInterface {
+	return &BookLookupService{
+		Repo:     repo,
+		Provider: provider,
+	}
+}
+
+// LookupISBN looks a book up in the bibliographic catalog and returns a
+// create request filled in with what the catalog knows about it. Whatever
+// it doesn't know is left empty for staff to fill in; nothing is saved.
+func (s *BookLookupService) LookupISBN(ctx context.Context, isbn string) (dto.BookCreateRequest, error) {
+	isbn, err := validation.NormalizeISBN(isbn)
+	if err != nil {
+		return dto.BookCreateRequest{}, err
+	}
+
+	// No need to fill in a book the catalog already has
+	if existingBook, _ := s.Repo.GetByISBN(isbn); existingBook != nil {
+		return dto.BookCreateRequest{}, constants.ErrISBNExists
+	}
+
+	book, err := s.Provider.LookupISBN(ctx, isbn)
+	if err != nil {
+		return dto.BookCreateRequest{}, err
+	}
+
+	req := dto.BookCreateRequest{
+		Title:       book.Title,
+		Authors:     mappers.NormalizeAuthorNames(book.Authors),
+		ISBN:        isbn,
+		PublishedAt: book.PublishedAt,
+		ItemType:    string(constants.ItemBook),
+		Tags:        []string{},
+	}
+	for _, subject := range mappers.NormalizeTagNames(book.Subjects) {
+		if len(req.Tags) == maxLookupTags {
+			break
+		}
+		if utf8.RuneCountInString(subject) <= maxLookupTagChars {
+			req.Tags = append(req.Tags, subject)
+		}
+	}
+	return req, nil
+}
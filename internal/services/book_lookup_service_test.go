@@ -0,0 +1,101 @@
+package services_test
+
+import (
+	"context"
+	"library-management/internal/constants"
+	"library-management/internal/metadata"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubProvider answers lookups from a map of ISBNs to books
+type stubProvider struct {
+	books   map[string]*metadata.Book
+	err     error
+	lookups []string
+}
+
+func (p *stubProvider) LookupISBN(ctx context.Context, isbn string) (*metadata.Book, error) {
+	p.lookups = append(p.lookups, isbn)
+	if p.err != nil {
+		return nil, p.err
+	}
+	book, ok := p.books[isbn]
+	if !ok {
+		return nil, constants.ErrMetadataNotFound
+	}
+	return book, nil
+}
+
+func TestLookupISBN(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	provider := &stubProvider{books: map[string]*metadata.Book{
+		"9780134190440": {
+			Title:       "The Go Programming Language",
+			Authors:     []string{"Alan A. A. Donovan", " Brian W.  Kernighan "},
+			PublishedAt: time.Date(2015, 10, 26, 0, 0, 0, 0, time.UTC),
+			Subjects: []string{
+				"Go (Computer program language)",
+				"go (computer program language)",
+				"Computer programming -- Handbooks, manuals, etc. -- United States",
+				"Programming", "Open source software", "Languages", "Software", "Compilers",
+			},
+		},
+	}}
+	lookupService := services.NewBookLookupService(mockRepo, provider)
+
+	mockRepo.On("GetByISBN", "9780134190440").Return(nil, constants.ErrBookNotFound)
+
+	req, err := lookupService.LookupISBN(context.Background(), "0-13-419044-0")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"9780134190440"}, provider.lookups)
+	assert.Equal(t, "The Go Programming Language", req.Title)
+	assert.Equal(t, "9780134190440", req.ISBN)
+	assert.Equal(t, []string{"Alan A. A. Donovan", "Brian W. Kernighan"}, req.Authors)
+	assert.Equal(t, string(constants.ItemBook), req.ItemType)
+	assert.True(t, req.PublishedAt.Equal(time.Date(2015, 10, 26, 0, 0, 0, 0, time.UTC)))
+	// Repeated and overlong subjects are left out, and only the first five kept
+	assert.Equal(t, []string{"Go (Computer program language)", "Programming", "Open source software", "Languages", "Software"}, req.Tags)
+}
+
+func TestLookupISBN_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		isbn     string
+		existing *models.Book
+		err      error
+		expected error
+	}{
+		{name: "Invalid ISBN", isbn: "0-13-419044-1", expected: constants.ErrInvalidISBN},
+		{name: "Already in the catalog", isbn: "9780134190440", existing: &models.Book{Title: "The Go Programming Language"}, expected: constants.ErrISBNExists},
+		{name: "Unknown ISBN", isbn: "9780134190440", expected: constants.ErrMetadataNotFound},
+		{name: "Provider down", isbn: "9780134190440", err: constants.ErrMetadataUnavailable, expected: constants.ErrMetadataUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.BookRepositoryInterface)
+			provider := &stubProvider{err: tc.err}
+			lookupService := services.NewBookLookupService(mockRepo, provider)
+
+			if tc.existing != nil {
+				mockRepo.On("GetByISBN", tc.isbn).Return(tc.existing, nil)
+			} else {
+				mockRepo.On("GetByISBN", tc.isbn).Return(nil, constants.ErrBookNotFound)
+			}
+
+			_, err := lookupService.LookupISBN(context.Background(), tc.isbn)
+
+			assert.ErrorIs(t, err, tc.expected)
+			if tc.expected == constants.ErrInvalidISBN || tc.expected == constants.ErrISBNExists {
+				assert.Empty(t, provider.lookups)
+			}
+		})
+	}
+}
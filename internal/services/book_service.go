@@ -1,46 +1,131 @@
 package services
 
 import (
+	"io"
 	"library-management/internal/constants"
 	"library-management/internal/dto"
+	"library-management/internal/models"
 	"library-management/internal/repository"
 	"library-management/internal/utils/mappers"
+	"library-management/internal/utils/validation"
+	"slices"
 )
 
 type BookServiceInterface interface {
 	CreateBook(req dto.BookCreateRequest) (dto.BookResponse, error)
 	GetBook(id uint, fields []string) (dto.BookResponse, error)
-	GetAllBooks(page, limit int, fields []string) ([]dto.BookResponse, int64, error)
+	GetAllBooks(filter dto.BookFilter, page, limit int, fields []string) ([]dto.BookResponse, int64, error)
+	SearchBooks(filter dto.BookFilter, page, limit int) ([]dto.BookResponse, int64, error)
+	GetBookFacets(filter dto.BookFilter) (map[string][]dto.BookFacetValue, error)
 	UpdateBook(id uint, req dto.BookUpdateRequest) (dto.BookResponse, error)
 	DeleteBook(id uint) error
+	ImportBooks(rows []dto.BookImportRow, dryRun bool) (dto.BookImportReport, error)
+	ExportBooks(filter dto.BookFilter, format constants.ExportFormat, w io.Writer) error
 }
 
+// facetSize is the number of values returned per facet
+const facetSize = 20
+
 type BookService struct {
-	Repo repository.BookRepositoryInterface
+	Repo         repository.BookRepositoryInterface
+	CopyRepo     repository.BookCopyRepositoryInterface
+	AuthorRepo   repository.AuthorRepositoryInterface
+	CategoryRepo repository.CategoryRepositoryInterface
+	TagRepo      repository.TagRepositoryInterface
 }
 
-func NewBookService(repo repository.BookRepositoryInterface) BookServiceInterface {
-	return &BookService{Repo: repo}
+func NewBookService(repo repository.BookRepositoryInterface, copyRepo repository.BookCopyRepositoryInterface, authorRepo repository.AuthorRepositoryInterface, categoryRepo repository.CategoryRepositoryInterface, tagRepo repository.TagRepositoryInterface) BookServiceInterface {
+	return &BookService{
+		Repo:         repo,
+		CopyRepo:     copyRepo,
+		AuthorRepo:   authorRepo,
+		CategoryRepo: categoryRepo,
+		TagRepo:      tagRepo,
+	}
 }
 
 // Create Book
 func (s *BookService) CreateBook(req dto.BookCreateRequest) (dto.BookResponse, error) {
+	// Older clients send the author credit as text
+	if req.Author != "" {
+		req.Authors = mappers.SplitAuthorNames(req.Author)
+	}
+	book, err := s.checkNewBook(req)
+	if err != nil {
+		return dto.BookResponse{}, err
+	}
+
+	// Save book in the database, together with its copies, authors, categories and tags
+	if err := saveNewBook(s.Repo, s.AuthorRepo, s.TagRepo, book, req); err != nil {
+		return dto.BookResponse{}, err
+	}
+	book.CopiesAvailable = len(book.Copies)
+	// Map book to response DTO
+	bookResponse := mappers.MapBookToResponse(book)
+	return bookResponse, nil
+}
+
+// checkNewBook maps a create request to a book and checks it can be added
+// to the catalog, without writing anything. The authors and tags are left
+// for saveNewBook, as the missing ones get created.
+func (s *BookService) checkNewBook(req dto.BookCreateRequest) (*models.Book, error) {
 	book := mappers.MapCreateRequestToBook(req)
 
-	// Check if the ISBN already exists
+	// Store the ISBN as an ISBN-13, so both forms of it are the same book
+	isbn, err := validation.NormalizeISBN(book.ISBN)
+	if err != nil {
+		return nil, err
+	}
+	book.ISBN = isbn
+
+	// Check if the ISBN already exists. The unique index has the final say.
 	existingBook, _ := s.Repo.GetByISBN(book.ISBN)
 	if existingBook != nil {
-		return dto.BookResponse{}, constants.ErrISBNExists
+		return nil, constants.ErrISBNExists
+	}
+
+	// Check that the barcodes of the new copies are unique
+	barcodes := make(map[string]bool, len(book.Copies))
+	for _, bookCopy := range book.Copies {
+		if barcodes[bookCopy.Barcode] {
+			return nil, constants.ErrBarcodeExists
+		}
+		barcodes[bookCopy.Barcode] = true
+		if existingCopy, _ := s.CopyRepo.GetByBarcode(bookCopy.Barcode); existingCopy != nil {
+			return nil, constants.ErrBarcodeExists
+		}
+	}
+
+	if len(mappers.NormalizeAuthorNames(req.Authors)) == 0 {
+		return nil, constants.ErrAuthorRequired
 	}
 
-	// Save book in the database
-	book, err := s.Repo.Create(book)
+	// File the book under its categories
+	book.Categories, err = s.getCategories(req.CategoryIDs)
 	if err != nil {
-		return dto.BookResponse{}, err
+		return nil, err
 	}
-	// Map book to response DTO
-	bookResponse := mappers.MapBookToResponse(book)
-	return bookResponse, nil
+	return book, nil
+}
+
+// saveNewBook credits a checked book to its authors and tags it, adding the
+// authors and tags we don't know yet, then saves it with its copies. The
+// repositories may be bound to a transaction.
+func saveNewBook(repo repository.BookRepositoryInterface, authorRepo repository.AuthorRepositoryInterface, tagRepo repository.TagRepositoryInterface, book *models.Book, req dto.BookCreateRequest) error {
+	authors, err := findOrCreateAuthors(authorRepo, req.Authors)
+	if err != nil {
+		return err
+	}
+	for i, author := range authors {
+		book.BookAuthors = append(book.BookAuthors, models.BookAuthor{AuthorID: author.ID, Position: i, Author: author})
+	}
+	book.Tags, err = findOrCreateTags(tagRepo, req.Tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.Create(book)
+	return err
 }
 
 // Get Book by ID
@@ -55,10 +140,10 @@ func (s *BookService) GetBook(id uint, fields []string) (dto.BookResponse, error
 	return bookResponse, nil
 }
 
-// Get All Books
-func (s *BookService) GetAllBooks(page, limit int, fields []string) ([]dto.BookResponse, int64, error) {
+// Get All Books matching the filter
+func (s *BookService) GetAllBooks(filter dto.BookFilter, page, limit int, fields []string) ([]dto.BookResponse, int64, error) {
 	// Fetch books from the repository
-	books, total, err := s.Repo.GetAll(page, limit, fields)
+	books, total, err := s.Repo.GetAll(filter, page, limit, fields)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -72,6 +157,93 @@ func (s *BookService) GetAllBooks(page, limit int, fields []string) ([]dto.BookR
 	return bookResponses, total, nil
 }
 
+// SearchBooks searches the catalog by title and author, best matches first.
+// Queries that look like an ISBN are looked up directly instead.
+func (s *BookService) SearchBooks(filter dto.BookFilter, page, limit int) ([]dto.BookResponse, int64, error) {
+	if isbn, ok := isbnSearchTerm(filter.Query); ok {
+		books, err := s.Repo.FindByISBN(isbn)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		total := int64(len(books))
+		// Pagination logic
+		offset := (page - 1) * limit
+		if offset >= len(books) {
+			return []dto.BookResponse{}, total, nil
+		}
+		books = books[offset:min(offset+limit, len(books))]
+
+		bookResponses := make([]dto.BookResponse, len(books))
+		for i, book := range books {
+			bookResponses[i] = mappers.MapBookToResponse(&book)
+			bookResponses[i].Highlights = &dto.BookHighlights{ISBN: "<mark>" + book.ISBN + "</mark>"}
+		}
+		return bookResponses, total, nil
+	}
+
+	results, total, err := s.Repo.Search(filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bookResponses := make([]dto.BookResponse, len(results))
+	for i, result := range results {
+		bookResponses[i] = mappers.MapBookSearchResultToResponse(&result)
+	}
+	return bookResponses, total, nil
+}
+
+// GetBookFacets counts the books matching the filter per author and item type
+func (s *BookService) GetBookFacets(filter dto.BookFilter) (map[string][]dto.BookFacetValue, error) {
+	// An ISBN lookup has at most a handful of results, nothing to narrow down
+	if _, ok := isbnSearchTerm(filter.Query); ok {
+		return map[string][]dto.BookFacetValue{}, nil
+	}
+
+	facets, err := s.Repo.GetFacets(filter, facetSize)
+	if err != nil {
+		return nil, err
+	}
+
+	facetValues := make(map[string][]dto.BookFacetValue, len(facets))
+	for name, counts := range facets {
+		facetValues[name] = make([]dto.BookFacetValue, len(counts))
+		for i, count := range counts {
+			facetValues[name][i] = dto.BookFacetValue{
+				ID:        count.ID,
+				Value:     count.Value,
+				Count:     count.Count,
+				Available: count.Available,
+			}
+		}
+	}
+	return facetValues, nil
+}
+
+// isbnSearchTerm reports whether a search query is an ISBN-10 or ISBN-13,
+// and returns it the way ISBNs are stored: as an ISBN-13, or just without
+// hyphens and spaces when its check digit is wrong.
+func isbnSearchTerm(query string) (string, bool) {
+	if isbn, err := validation.NormalizeISBN(query); err == nil {
+		return isbn, true
+	}
+	isbn := validation.CleanISBN(query)
+	if len(isbn) != 10 && len(isbn) != 13 {
+		return "", false
+	}
+	for i, r := range isbn {
+		// Only the check digit of an ISBN-10 can be an X
+		if r == 'X' && len(isbn) == 10 && i == 9 {
+			continue
+		}
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return isbn, true
+}
+
 // Update Book
 func (s *BookService) UpdateBook(id uint, req dto.BookUpdateRequest) (dto.BookResponse, error) {
 	book, err := s.Repo.GetByID(id, []string{})
@@ -79,20 +251,76 @@ func (s *BookService) UpdateBook(id uint, req dto.BookUpdateRequest) (dto.BookRe
 		return dto.BookResponse{}, constants.ErrBookNotFound
 	}
 
+	// Older clients send the author credit as text
+	if req.Author != nil {
+		req.Authors = mappers.SplitAuthorNames(*req.Author)
+		if len(req.Authors) == 0 {
+			return dto.BookResponse{}, constants.ErrAuthorRequired
+		}
+	}
+
 	// Update book fields
 	mappers.UpdateBookFromDTO(book, req)
 
 	if req.ISBN != nil {
+		book.ISBN, err = validation.NormalizeISBN(book.ISBN)
+		if err != nil {
+			return dto.BookResponse{}, err
+		}
+
 		// Check if the isbn is already in use by another book
 		existingBook, _ := s.Repo.GetByISBN(book.ISBN)
 		if existingBook != nil && existingBook.ID != id {
 			return dto.BookResponse{}, constants.ErrISBNExists
 		}
+	}
 
+	var categories []models.Category
+	if req.CategoryIDs != nil {
+		categories, err = s.getCategories(req.CategoryIDs)
+		if err != nil {
+			return dto.BookResponse{}, err
+		}
 	}
 
-	err = s.Repo.Update(book)
-	if err != nil {
+	// Start transaction. The links and the fields are saved together, so a
+	// failed update leaves the book as it was.
+	tx, repo := s.Repo.BeginTransaction()
+
+	if req.Authors != nil {
+		authors, err := findOrCreateAuthors(s.AuthorRepo.WithTx(tx), req.Authors)
+		if err != nil {
+			repo.RollbackTransaction(tx)
+			return dto.BookResponse{}, err
+		}
+		if err := repo.ReplaceAuthors(book, authors); err != nil {
+			repo.RollbackTransaction(tx)
+			return dto.BookResponse{}, err
+		}
+	}
+	if req.CategoryIDs != nil {
+		if err := repo.ReplaceCategories(book, categories); err != nil {
+			repo.RollbackTransaction(tx)
+			return dto.BookResponse{}, err
+		}
+	}
+	if req.Tags != nil {
+		tags, err := findOrCreateTags(s.TagRepo.WithTx(tx), req.Tags)
+		if err != nil {
+			repo.RollbackTransaction(tx)
+			return dto.BookResponse{}, err
+		}
+		if err := repo.ReplaceTags(book, tags); err != nil {
+			repo.RollbackTransaction(tx)
+			return dto.BookResponse{}, err
+		}
+	}
+
+	if err := repo.Update(book); err != nil {
+		repo.RollbackTransaction(tx)
+		return dto.BookResponse{}, err
+	}
+	if err := repo.CommitTransaction(tx); err != nil {
 		return dto.BookResponse{}, err
 	}
 
@@ -101,6 +329,44 @@ func (s *BookService) UpdateBook(id uint, req dto.BookUpdateRequest) (dto.BookRe
 	return bookResponse, nil
 }
 
+// findOrCreateAuthors returns the authors with the given names, in order,
+// creating the ones that don't exist yet
+func findOrCreateAuthors(authorRepo repository.AuthorRepositoryInterface, names []string) ([]models.Author, error) {
+	names = mappers.NormalizeAuthorNames(names)
+	if len(names) == 0 {
+		return nil, constants.ErrAuthorRequired
+	}
+	return authorRepo.FindOrCreateByNames(names)
+}
+
+// getCategories loads the categories with the given IDs, all of which must exist
+func (s *BookService) getCategories(ids []uint) ([]models.Category, error) {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	if len(ids) == 0 {
+		return []models.Category{}, nil
+	}
+	categories, err := s.CategoryRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) != len(ids) {
+		return nil, constants.ErrCategoryNotFound
+	}
+	return categories, nil
+}
+
+// findOrCreateTags returns the tags with the given names, creating the ones
+// that don't exist yet
+func findOrCreateTags(tagRepo repository.TagRepositoryInterface, names []string) ([]models.Tag, error) {
+	names = mappers.NormalizeTagNames(names)
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+	return tagRepo.FindOrCreateByNames(names)
+}
+
 // Delete Book
 func (s *BookService) DeleteBook(id uint) error {
 	_, err := s.Repo.GetByID(id, []string{})
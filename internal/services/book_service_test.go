@@ -0,0 +1,217 @@
+package services_test
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestSearchBooks_ISBNFastPath(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		isbn  string
+	}{
+		{name: "Hyphenated ISBN-13", query: "978-0-13-419044-0", isbn: "9780134190440"},
+		{name: "ISBN-10 with check digit X", query: "0-8044-2957-x", isbn: "9780804429573"},
+		{name: "Wrong check digit", query: "978-0-13-419044-1", isbn: "9780134190441"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.BookRepositoryInterface)
+			bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), new(mocks.AuthorRepositoryInterface), new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+			book := models.Book{Title: "The Go Programming Language", ISBN: tc.query}
+			mockRepo.On("FindByISBN", tc.isbn).Return([]models.Book{book}, nil)
+
+			books, total, err := bookService.SearchBooks(dto.BookFilter{Query: tc.query}, 1, 10)
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), total)
+			assert.Equal(t, "<mark>"+tc.query+"</mark>", books[0].Highlights.ISBN)
+			mockRepo.AssertNotCalled(t, "Search")
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearchBooks_FullText(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), new(mocks.AuthorRepositoryInterface), new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	result := models.BookSearchResult{
+		Book:            models.Book{Title: "The Go Programming Language", Author: "Alan A. A. Donovan"},
+		Rank:            0.6,
+		TitleHighlight:  "The " + models.HighlightStart + "Go" + models.HighlightStop + " Programming Language",
+		AuthorHighlight: "Alan A. A. Donovan",
+	}
+	filter := dto.BookFilter{Query: "go 9780134", AvailableOnly: true}
+	mockRepo.On("Search", filter, 1, 10).Return([]models.BookSearchResult{result}, int64(1), nil)
+
+	books, total, err := bookService.SearchBooks(filter, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "The <mark>Go</mark> Programming Language", books[0].Highlights.Title)
+	assert.Equal(t, result.AuthorHighlight, books[0].Highlights.Author)
+	mockRepo.AssertNotCalled(t, "FindByISBN")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateBook_CreditsAuthorsInOrder(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	authorRepo := new(mocks.AuthorRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), authorRepo, new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	donovan := models.Author{Name: "Alan A. A. Donovan"}
+	donovan.ID = 7
+	kernighan := models.Author{Name: "Brian W. Kernighan"}
+	kernighan.ID = 3
+
+	mockRepo.On("GetByISBN", "9780134190440").Return(nil, constants.ErrBookNotFound)
+	authorRepo.On("FindOrCreateByNames", []string{"Alan A. A. Donovan", "brian w. kernighan"}).
+		Return([]models.Author{donovan, kernighan}, nil)
+	creditsInOrder := mock.MatchedBy(func(book *models.Book) bool {
+		return len(book.BookAuthors) == 2 &&
+			book.BookAuthors[0].AuthorID == 7 && book.BookAuthors[0].Position == 0 &&
+			book.BookAuthors[1].AuthorID == 3 && book.BookAuthors[1].Position == 1
+	})
+	mockRepo.On("Create", creditsInOrder).Return(func(book *models.Book) (*models.Book, error) {
+		return book, nil
+	})
+
+	req := dto.BookCreateRequest{
+		Title:   "The Go Programming Language",
+		Authors: []string{" Alan  A. A. Donovan", "brian w. kernighan", "Alan A. A. Donovan"},
+		ISBN:    "9780134190440",
+	}
+	response, err := bookService.CreateBook(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.AuthorResponse{{ID: 7, Name: "Alan A. A. Donovan"}, {ID: 3, Name: "Brian W. Kernighan"}}, response.Authors)
+	mockRepo.AssertExpectations(t)
+	authorRepo.AssertExpectations(t)
+}
+
+func TestCreateBook_AuthorText(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	authorRepo := new(mocks.AuthorRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), authorRepo, new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	tolkien := models.Author{Name: "Tolkien, J. R. R."}
+	tolkien.ID = 5
+	mockRepo.On("GetByISBN", "9780261103344").Return(nil, constants.ErrBookNotFound)
+	authorRepo.On("FindOrCreateByNames", []string{"Tolkien, J. R. R."}).Return([]models.Author{tolkien}, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*models.Book")).Return(func(book *models.Book) (*models.Book, error) {
+		return book, nil
+	})
+
+	// Clients from before the authors list send the credit as text
+	response, err := bookService.CreateBook(dto.BookCreateRequest{Title: "The Hobbit", Author: "Tolkien, J. R. R.", ISBN: "9780261103344"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.AuthorResponse{{ID: 5, Name: "Tolkien, J. R. R."}}, response.Authors)
+	authorRepo.AssertExpectations(t)
+}
+
+func TestUpdateBook_AuthorText(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	authorRepo := new(mocks.AuthorRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), authorRepo, new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	book := &models.Book{Title: "The C Programming Language", ISBN: "9780131103627"}
+	book.ID = 1
+	kernighan := models.Author{Name: "Brian W. Kernighan"}
+	kernighan.ID = 3
+	ritchie := models.Author{Name: "Dennis M. Ritchie"}
+	ritchie.ID = 4
+	tx := &gorm.DB{}
+
+	mockRepo.On("GetByID", uint(1), mock.Anything).Return(book, nil)
+	mockRepo.On("BeginTransaction").Return(tx, mockRepo)
+	authorRepo.On("WithTx", tx).Return(authorRepo)
+	authorRepo.On("FindOrCreateByNames", []string{"Brian W. Kernighan", "Dennis M. Ritchie"}).Return([]models.Author{kernighan, ritchie}, nil)
+	mockRepo.On("ReplaceAuthors", book, []models.Author{kernighan, ritchie}).Return(nil)
+	mockRepo.On("Update", book).Return(nil)
+	mockRepo.On("CommitTransaction", tx).Return(nil)
+
+	// The authors are replaced, rather than the text being ignored
+	author := "Brian W. Kernighan and Dennis M. Ritchie"
+	_, err := bookService.UpdateBook(1, dto.BookUpdateRequest{Author: &author})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	authorRepo.AssertExpectations(t)
+}
+
+func TestCreateBook_BlankAuthors(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	authorRepo := new(mocks.AuthorRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), authorRepo, new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	mockRepo.On("GetByISBN", "9780134190440").Return(nil, constants.ErrBookNotFound)
+
+	_, err := bookService.CreateBook(dto.BookCreateRequest{Title: "Untitled", Authors: []string{"  "}, ISBN: "9780134190440"})
+
+	assert.Equal(t, constants.ErrAuthorRequired, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	authorRepo.AssertNotCalled(t, "FindOrCreateByNames", mock.Anything)
+}
+
+func TestCreateBook_ISBN10MatchesExistingISBN13(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), new(mocks.AuthorRepositoryInterface), new(mocks.CategoryRepositoryInterface), new(mocks.TagRepositoryInterface))
+
+	mockRepo.On("GetByISBN", "9780134190440").Return(&models.Book{ISBN: "9780134190440"}, nil)
+
+	_, err := bookService.CreateBook(dto.BookCreateRequest{Title: "The Go Programming Language", Authors: []string{"Alan A. A. Donovan"}, ISBN: "0-13-419044-0"})
+
+	assert.Equal(t, constants.ErrISBNExists, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateBook_FailureKeepsLinks(t *testing.T) {
+	mockRepo := new(mocks.BookRepositoryInterface)
+	authorRepo := new(mocks.AuthorRepositoryInterface)
+	tagRepo := new(mocks.TagRepositoryInterface)
+	bookService := services.NewBookService(mockRepo, new(mocks.BookCopyRepositoryInterface), authorRepo, new(mocks.CategoryRepositoryInterface), tagRepo)
+
+	book := &models.Book{Title: "The Go Programming Language", ISBN: "9780134190440"}
+	book.ID = 1
+	author := models.Author{Name: "Brian W. Kernighan"}
+	author.ID = 3
+	tag := models.Tag{Name: "go"}
+	tag.ID = 4
+	tx := &gorm.DB{}
+
+	mockRepo.On("GetByID", uint(1), mock.Anything).Return(book, nil)
+	mockRepo.On("BeginTransaction").Return(tx, mockRepo)
+	authorRepo.On("WithTx", tx).Return(authorRepo)
+	authorRepo.On("FindOrCreateByNames", []string{"Brian W. Kernighan"}).Return([]models.Author{author}, nil)
+	mockRepo.On("ReplaceAuthors", book, []models.Author{author}).Return(nil)
+	tagRepo.On("WithTx", tx).Return(tagRepo)
+	tagRepo.On("FindOrCreateByNames", []string{"go"}).Return([]models.Tag{tag}, nil)
+	mockRepo.On("ReplaceTags", book, []models.Tag{tag}).Return(nil)
+	mockRepo.On("Update", book).Return(errors.New("connection reset"))
+	mockRepo.On("RollbackTransaction", tx).Return()
+
+	title := "The C Programming Language"
+	_, err := bookService.UpdateBook(1, dto.BookUpdateRequest{Title: &title, Authors: []string{"Brian W. Kernighan"}, Tags: []string{"go"}})
+
+	// The new authors and tags are rolled back with the failed update
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "CommitTransaction", mock.Anything)
+	mockRepo.AssertExpectations(t)
+	authorRepo.AssertExpectations(t)
+	tagRepo.AssertExpectations(t)
+}
@@ -0,0 +1,225 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/policy"
+	"library-management/internal/repository"
+	"library-management/internal/services"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testModels are the tables the services tests work on
+var testModels = []interface{}{&models.User{}, &models.Author{}, &models.Category{}, &models.Tag{}, &models.Book{}, &models.BookAuthor{}, &models.BookCopy{}, &models.Borrow{}, &models.BorrowRenewal{}, &models.Hold{}, &models.Fine{}, &models.FineTransaction{}}
+
+// openTestDB opens a throwaway SQLite database. Transactions take the write
+// lock up front, so concurrent ones queue up and run one at a time: tests
+// on it check the services' logic, not how they hold up to a real race.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "library.db") + "?_journal_mode=WAL&_busy_timeout=10000&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Skipf("sqlite unavailable: %v", err)
+	}
+	require.NoError(t, db.AutoMigrate(testModels...))
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// openPostgresTestDB opens the PostgreSQL database in TEST_DATABASE_DSN, in
+// a schema of its own that is dropped afterwards. Unlike SQLite, it runs
+// transactions side by side, so the row locks the services take are what
+// keeps concurrent checkouts apart. Tests using it are skipped without one.
+func openPostgresTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	schema := fmt.Sprintf("services_test_%d", time.Now().UnixNano())
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+schema).Error)
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	// Every connection of the pool has to use the schema
+	switch {
+	case !strings.Contains(dsn, "://"):
+		dsn += " search_path=" + schema
+	case strings.Contains(dsn, "?"):
+		dsn += "&search_path=" + schema
+	default:
+		dsn += "?search_path=" + schema
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	require.NoError(t, db.AutoMigrate(testModels...))
+	return db
+}
+
+// runOnTestDBs runs a test on SQLite, where it only checks the logic one
+// transaction at a time, and on PostgreSQL when there is one to race on
+func runOnTestDBs(t *testing.T, test func(t *testing.T, db *gorm.DB)) {
+	t.Run("SQLite", func(t *testing.T) { test(t, openTestDB(t)) })
+	t.Run("PostgreSQL", func(t *testing.T) { test(t, openPostgresTestDB(t)) })
+}
+
+func TestBorrowBook_ConcurrentCheckoutsOfLastCopy(t *testing.T) {
+	runOnTestDBs(t, func(t *testing.T, db *gorm.DB) {
+		const members = 20
+		users := make([]models.User, members)
+		for i := range users {
+			users[i] = models.User{Name: fmt.Sprintf("Member %d", i), Email: fmt.Sprintf("member%d@example.com", i), Password: "secret", Role: string(constants.Member)}
+		}
+		require.NoError(t, db.Create(&users).Error)
+		book := models.Book{
+			Title:  "The Last Copy",
+			Author: "Jane Doe",
+			ISBN:   "9780000000001",
+			Copies: []models.BookCopy{{Barcode: "LIB-000001", Status: string(constants.CopyAvailable), AcquiredAt: time.Now()}},
+		}
+		require.NoError(t, db.Create(&book).Error)
+
+		bookRepo := repository.NewBookRepository(db)
+		borrowService := services.NewBorrowService(
+			repository.NewBorrowRepository(db),
+			bookRepo,
+			repository.NewBookCopyRepository(db),
+			repository.NewUserRepository(db),
+			repository.NewHoldRepository(db),
+			repository.NewFineRepository(db),
+			testCirculation,
+			policy.DefaultCirculationPolicy(),
+		)
+
+		var wg sync.WaitGroup
+		errs := make([]error, members)
+		start := make(chan struct{})
+		for i := range users {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				errs[i] = borrowService.BorrowBook(dto.BorrowCreateRequest{BookID: book.ID}, users[i].ID, string(constants.Member))
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			assert.True(t, errors.Is(err, constants.ErrBookNotAvailable), "unexpected error: %v", err)
+		}
+		assert.Equal(t, 1, succeeded)
+
+		stored, err := bookRepo.GetByID(book.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, stored.CopiesAvailable)
+
+		var loans []models.Borrow
+		require.NoError(t, db.Where("book_id = ?", book.ID).Find(&loans).Error)
+		require.Len(t, loans, 1)
+		require.NotNil(t, loans[0].CopyID)
+		assert.Equal(t, book.Copies[0].ID, *loans[0].CopyID)
+
+		var bookCopy models.BookCopy
+		require.NoError(t, db.First(&bookCopy, book.Copies[0].ID).Error)
+		assert.Equal(t, string(constants.CopyOnLoan), bookCopy.Status)
+	})
+}
+
+func TestBorrowBook_ConcurrentCheckoutsOverLoanLimit(t *testing.T) {
+	runOnTestDBs(t, func(t *testing.T, db *gorm.DB) {
+		user := models.User{Name: "Member", Email: "member@example.com", Password: "secret", Role: string(constants.Member)}
+		require.NoError(t, db.Create(&user).Error)
+		const titles = 10
+		books := make([]models.Book, titles)
+		for i := range books {
+			books[i] = models.Book{
+				Title:  fmt.Sprintf("Book %d", i),
+				Author: "Jane Doe",
+				ISBN:   fmt.Sprintf("97800000001%02d", i),
+				Copies: []models.BookCopy{{Barcode: fmt.Sprintf("LIB-1%05d", i), Status: string(constants.CopyAvailable), AcquiredAt: time.Now()}},
+			}
+		}
+		require.NoError(t, db.Create(&books).Error)
+
+		circulationPolicy := policy.DefaultCirculationPolicy()
+		borrowService := services.NewBorrowService(
+			repository.NewBorrowRepository(db),
+			repository.NewBookRepository(db),
+			repository.NewBookCopyRepository(db),
+			repository.NewUserRepository(db),
+			repository.NewHoldRepository(db),
+			repository.NewFineRepository(db),
+			testCirculation,
+			circulationPolicy,
+		)
+
+		var wg sync.WaitGroup
+		errs := make([]error, titles)
+		start := make(chan struct{})
+		for i := range books {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				errs[i] = borrowService.BorrowBook(dto.BorrowCreateRequest{BookID: books[i].ID}, user.ID, string(constants.Member))
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		limit := circulationPolicy.For(string(constants.Member)).MaxConcurrentLoans
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			assert.True(t, errors.Is(err, constants.ErrLoanLimitReached), "unexpected error: %v", err)
+		}
+		assert.Equal(t, limit, succeeded)
+
+		var loans int64
+		require.NoError(t, db.Model(&models.Borrow{}).Where("user_id = ?", user.ID).Count(&loans).Error)
+		assert.Equal(t, int64(limit), loans)
+	})
+}
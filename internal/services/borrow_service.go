@@ -1,43 +1,143 @@
 package services
 
 import (
+	"errors"
+	"library-management/config"
 	"library-management/internal/constants"
 	"library-management/internal/dto"
 	"library-management/internal/models"
+	"library-management/internal/policy"
 	"library-management/internal/repository"
+	"library-management/internal/utils/mappers"
+	"time"
 )
 
 type BorrowServiceInterface interface {
-	BorrowBook(req dto.BorrowCreateRequest, userIDUint uint) error
+	BorrowBook(req dto.BorrowCreateRequest, userIDUint uint, role string) error
 	ReturnBook(req dto.ReturnRequest, userIDUint uint) error
-	GetBorrowRecords(page, limit int) ([]dto.BorrowResponse, int64, error)
-	GetUserBorrows(userID uint, page, limit int) ([]dto.BorrowResponse, int64, error)
+	CheckOut(req dto.BorrowCreateRequest, userID uint) error
+	CheckIn(borrowID, staffID uint) error
+	ForceReturn(borrowID uint) error
+	RenewBorrow(borrowID, userIDUint uint) (dto.BorrowResponse, error)
+	GetBorrowRecords(filter dto.BorrowFilter, page, limit int) ([]dto.BorrowResponse, int64, error)
+	GetUserBorrows(userID uint, status constants.BorrowStatus, page, limit int) ([]dto.BorrowResponse, int64, error)
 }
 
 type BorrowService struct {
-	BorrowRepo repository.BorrowRepositoryInterface
-	BookRepo   repository.BookRepositoryInterface
-	UserRepo   repository.UserRepositoryInterface
+	BorrowRepo  repository.BorrowRepositoryInterface
+	BookRepo    repository.BookRepositoryInterface
+	CopyRepo    repository.BookCopyRepositoryInterface
+	UserRepo    repository.UserRepositoryInterface
+	HoldRepo    repository.HoldRepositoryInterface
+	FineRepo    repository.FineRepositoryInterface
+	Circulation config.CirculationConfig
+	Policy      *policy.CirculationPolicy
 }
 
-func NewBorrowService(borrowRepo repository.BorrowRepositoryInterface, bookRepo repository.BookRepositoryInterface, userRepo repository.UserRepositoryInterface) BorrowServiceInterface {
+func NewBorrowService(borrowRepo repository.BorrowRepositoryInterface, bookRepo repository.BookRepositoryInterface, copyRepo repository.BookCopyRepositoryInterface, userRepo repository.UserRepositoryInterface, holdRepo repository.HoldRepositoryInterface, fineRepo repository.FineRepositoryInterface, circulation config.CirculationConfig, loanPolicy *policy.CirculationPolicy) BorrowServiceInterface {
 	return &BorrowService{
-		BorrowRepo: borrowRepo,
-		BookRepo:   bookRepo,
-		UserRepo:   userRepo,
+		BorrowRepo:  borrowRepo,
+		BookRepo:    bookRepo,
+		CopyRepo:    copyRepo,
+		UserRepo:    userRepo,
+		HoldRepo:    holdRepo,
+		FineRepo:    fineRepo,
+		Circulation: circulation,
+		Policy:      loanPolicy,
 	}
 }
 
-// BorrowBook handles borrowing a book
-func (s *BorrowService) BorrowBook(req dto.BorrowCreateRequest, userIDUint uint) error {
+// BorrowBook handles borrowing a book. The due date comes from the circulation
+// policy of the user's role, unless staff chose one explicitly.
+func (s *BorrowService) BorrowBook(req dto.BorrowCreateRequest, userIDUint uint, role string) error {
+	rolePolicy := s.Policy.For(role)
+	if req.DueDate != nil {
+		if !rolePolicy.CanOverrideDueDate {
+			return constants.ErrDueDateOverride
+		}
+		if !req.DueDate.After(time.Now()) {
+			return constants.ErrInvalidDueDate
+		}
+	}
+
+	// Members may have to prove they own their email first
+	if s.Circulation.RequireVerifiedEmail {
+		user, err := s.UserRepo.GetByID(userIDUint, []string{"id", "email_verified_at"})
+		if err != nil {
+			return constants.ErrUserNotFound
+		}
+		if user.EmailVerifiedAt == nil {
+			return constants.ErrEmailNotVerified
+		}
+	}
+	return s.lend(req, userIDUint, role)
+}
+
+// CheckOut lends a book to a user at the circulation desk. The loan follows
+// the circulation policy of the borrower's role; staff may set the due date.
+func (s *BorrowService) CheckOut(req dto.BorrowCreateRequest, userID uint) error {
+	user, err := s.UserRepo.GetByID(userID, []string{"id", "role"})
+	if err != nil {
+		return constants.ErrUserNotFound
+	}
+	if req.DueDate != nil && !req.DueDate.After(time.Now()) {
+		return constants.ErrInvalidDueDate
+	}
+	return s.lend(req, user.ID, user.Role)
+}
+
+// lend checks the user may borrow the book and records the loan. The due
+// date request has been checked by the caller.
+func (s *BorrowService) lend(req dto.BorrowCreateRequest, userIDUint uint, role string) error {
+	rolePolicy := s.Policy.For(role)
+
+	// Members with too much unpaid fines can't borrow
+	balance, err := s.FineRepo.GetOutstandingBalance(userIDUint)
+	if err != nil {
+		return err
+	}
+	if balance > s.Circulation.FineBlockThreshold {
+		return constants.ErrOutstandingFines
+	}
+
+	// Enforce the maximum number of concurrent loans. Fail fast here; the
+	// count taken with the user locked below is what really guards it.
+	activeLoans, err := s.BorrowRepo.CountActiveLoans(userIDUint)
+	if err != nil {
+		return err
+	}
+	if activeLoans >= int64(rolePolicy.MaxConcurrentLoans) {
+		return constants.ErrLoanLimitReached
+	}
+
+	// Pass copies whose pickup window ran out on to the next member in line
+	if err := expireHolds(s.HoldRepo, s.CopyRepo, s.Circulation.HoldPickupWindow); err != nil {
+		return err
+	}
+
 	// Check if the book exists
 	book, err := s.BookRepo.GetByID(req.BookID, nil)
 	if err != nil {
 		return constants.ErrBookNotFound
 	}
 
-	// Ensure the book has available copies
-	if book.CopiesAvailable <= 0 {
+	dueDate, err := s.Policy.DueDate(role, constants.ItemType(book.ItemType), time.Now())
+	if err != nil {
+		return err
+	}
+	if req.DueDate != nil {
+		dueDate = *req.DueDate
+	}
+
+	// A copy set aside for the user's hold doesn't come from the general pool
+	hold, err := s.HoldRepo.GetActiveHold(userIDUint, req.BookID)
+	if err != nil && !errors.Is(err, constants.ErrHoldNotFound) {
+		return err
+	}
+	pickingUp := hold != nil && hold.Status == string(constants.HoldReady) && hold.CopyID != nil
+
+	// Fail fast when the book is out; claiming a copy below is what really guards the stock
+	if !pickingUp && book.CopiesAvailable <= 0 {
 		return constants.ErrBookNotAvailable
 	}
 
@@ -51,27 +151,67 @@ func (s *BorrowService) BorrowBook(req dto.BorrowCreateRequest, userIDUint uint)
 	borrow := &models.Borrow{
 		UserID:  userIDUint,
 		BookID:  req.BookID,
-		DueDate: req.DueDate,
+		DueDate: dueDate,
 	}
 
-	// Start transaction
+	// Start transaction. Taking the copy, fulfilling the hold and recording
+	// the loan either all happen or none of them do.
 	tx, borrowRepo := s.BorrowRepo.BeginTransaction()
+	copyRepo := s.CopyRepo.WithTx(tx)
 
-	if err := s.BorrowRepo.Create(borrow); err != nil {
+	// Count the loans again with the user locked, so concurrent checkouts
+	// for the same user can't take them over the limit together
+	if err := s.UserRepo.WithTx(tx).LockForUpdate(userIDUint); err != nil {
+		borrowRepo.RollbackTransaction(tx)
+		return err
+	}
+	activeLoans, err = borrowRepo.CountActiveLoans(userIDUint)
+	if err != nil {
+		borrowRepo.RollbackTransaction(tx)
+		return err
+	}
+	if activeLoans >= int64(rolePolicy.MaxConcurrentLoans) {
+		borrowRepo.RollbackTransaction(tx)
+		return constants.ErrLoanLimitReached
+	}
+
+	// Hand out the copy set aside for the hold, or any copy on the shelf
+	var bookCopy *models.BookCopy
+	if pickingUp {
+		bookCopy = &models.BookCopy{Status: string(constants.CopyOnLoan)}
+		bookCopy.ID = *hold.CopyID
+		err = copyRepo.UpdateStatus(bookCopy, constants.CopyOnHold)
+	} else {
+		bookCopy, err = copyRepo.CheckOutAvailable(req.BookID)
+	}
+	if err != nil {
 		borrowRepo.RollbackTransaction(tx)
 		return err
 	}
+	borrow.CopyID = &bookCopy.ID
 
-	if err := s.BookRepo.DecreaseBookCopies(req.BookID); err != nil {
+	// Borrowing the book fulfills the user's hold on it
+	if hold != nil {
+		from := constants.HoldStatus(hold.Status)
+		now := time.Now()
+		hold.Status = string(constants.HoldFulfilled)
+		hold.ClosedAt = &now
+		if err := s.HoldRepo.WithTx(tx).UpdateStatus(hold, from); err != nil {
+			borrowRepo.RollbackTransaction(tx)
+			return err
+		}
+	}
+
+	if err := borrowRepo.Create(borrow); err != nil {
 		borrowRepo.RollbackTransaction(tx)
 		return err
 	}
 
-	borrowRepo.CommitTransaction(tx)
-	return nil
+	return borrowRepo.CommitTransaction(tx)
 }
 
-// ReturnBook handles returning a borrowed book
+// ReturnBook handles returning a borrowed book.
+// The borrow record is kept as loan history and marked as returned.
 func (s *BorrowService) ReturnBook(req dto.ReturnRequest, userIDUint uint) error {
 
 	// Check if borrow record exists
@@ -79,64 +219,136 @@ func (s *BorrowService) ReturnBook(req dto.ReturnRequest, userIDUint uint) error
 	if err != nil {
 		return constants.ErrBorrowNotFound
 	}
+	return s.closeLoan(borrow, &userIDUint)
+}
+
+// CheckIn closes any user's loan at the circulation desk, recording the
+// staff member who took the item back
+func (s *BorrowService) CheckIn(borrowID, staffID uint) error {
+	borrow, err := s.BorrowRepo.GetByID(borrowID)
+	if err != nil {
+		return constants.ErrBorrowNotFound
+	}
+	return s.closeLoan(borrow, &staffID)
+}
+
+// ForceReturn closes any user's loan, e.g. for an item handed in at the
+// desk. It is an operator action outside of any account, so the loan is
+// left without a ReturnedBy. Fines and holds apply as usual.
+func (s *BorrowService) ForceReturn(borrowID uint) error {
+	borrow, err := s.BorrowRepo.GetByID(borrowID)
+	if err != nil {
+		return constants.ErrBorrowNotFound
+	}
+	return s.closeLoan(borrow, nil)
+}
+
+// closeLoan marks a loan returned, fines it if it is late and passes its
+// copy on to the next hold or back to the shelf, all in one transaction
+func (s *BorrowService) closeLoan(borrow *models.Borrow, returnedBy *uint) error {
+	if borrow.Returned {
+		return constants.ErrAlreadyReturned
+	}
 
 	// Start transaction
 	tx, borrowRepo := s.BorrowRepo.BeginTransaction()
 
-	// Delete the borrow record
-	if err := s.BorrowRepo.Delete(borrow); err != nil {
+	// Close the loan
+	now := time.Now()
+	borrow.Returned = true
+	borrow.ReturnedAt = &now
+	borrow.ReturnedBy = returnedBy
+	if err := borrowRepo.MarkReturned(borrow); err != nil {
 		borrowRepo.RollbackTransaction(tx)
 		return err
 	}
 
-	// Increase book copies only if it was borrowed
-	if err := s.BookRepo.IncreaseBookCopies(borrow.BookID); err != nil {
-		borrowRepo.RollbackTransaction(tx)
-		return err
+	// Late returns accrue an overdue fine
+	daysOverdue, amount := CalculateOverdueFine(borrow.DueDate, now, s.Circulation.FinePerDay, s.Circulation.MaxFinePerLoan)
+	if amount > 0 {
+		fine := &models.Fine{
+			UserID:      borrow.UserID,
+			BorrowID:    borrow.ID,
+			DaysOverdue: daysOverdue,
+			Amount:      amount,
+			Status:      string(constants.FineUnpaid),
+		}
+		if err := s.FineRepo.WithTx(tx).Create(fine); err != nil {
+			borrowRepo.RollbackTransaction(tx)
+			return err
+		}
+	}
+
+	// The copy goes to the next hold in line, or back on the shelf
+	if borrow.CopyID != nil {
+		if err := releaseCopy(s.HoldRepo.WithTx(tx), s.CopyRepo.WithTx(tx), *borrow.CopyID, s.Circulation.HoldPickupWindow); err != nil {
+			borrowRepo.RollbackTransaction(tx)
+			return err
+		}
 	}
 
-	borrowRepo.CommitTransaction(tx)
-	return nil
+	return borrowRepo.CommitTransaction(tx)
 }
 
-// GetBorrowRecords retrieves all borrow records with pagination
-func (s *BorrowService) GetBorrowRecords(page, limit int) ([]dto.BorrowResponse, int64, error) {
-	// Fetch borrows from the repository
-	borrows, total, err := s.BorrowRepo.GetAll(page, limit)
+// RenewBorrow pushes the due date of one of the user's loans out by the renewal period
+func (s *BorrowService) RenewBorrow(borrowID, userIDUint uint) (dto.BorrowResponse, error) {
+	borrow, err := s.BorrowRepo.GetBorrowRecord(userIDUint, borrowID)
 	if err != nil {
-		return nil, 0, err
+		return dto.BorrowResponse{}, constants.ErrBorrowNotFound
+	}
+	if borrow.Returned {
+		return dto.BorrowResponse{}, constants.ErrAlreadyReturned
 	}
 
-	// Map each models.Borrow to dto.BorrowResponse
-	borrowResponses := make([]dto.BorrowResponse, len(borrows))
-	for i, borrow := range borrows {
-		borrowResponses[i] = dto.BorrowResponse{
-			ID:      borrow.ID,
-			DueDate: borrow.DueDate,
-			User: &dto.UserResponse{
-				ID:    borrow.User.ID,
-				Name:  borrow.User.Name,
-				Email: borrow.User.Email,
-				Role:  borrow.User.Role,
-			},
-			Book: &dto.BookResponse{
-				ID:              borrow.Book.ID,
-				Title:           borrow.Book.Title,
-				Author:          borrow.Book.Author,
-				ISBN:            borrow.Book.ISBN,
-				CopiesAvailable: borrow.Book.CopiesAvailable,
-				PublishedAt:     borrow.Book.PublishedAt,
-			},
-		}
+	// Apply the renewal policy
+	if borrow.RenewalCount >= s.Circulation.MaxRenewals {
+		return dto.BorrowResponse{}, constants.ErrRenewalLimitReached
+	}
+	now := time.Now()
+	if now.After(borrow.DueDate.Add(s.Circulation.RenewalGracePeriod)) {
+		return dto.BorrowResponse{}, constants.ErrLoanTooOverdue
+	}
+	// Members waiting for the book get it first
+	waiting, err := s.HoldRepo.CountActiveHolds(borrow.BookID, userIDUint)
+	if err != nil {
+		return dto.BorrowResponse{}, err
+	}
+	if waiting > 0 {
+		return dto.BorrowResponse{}, constants.ErrBookOnHold
 	}
 
-	return borrowResponses, total, nil
+	renewal := &models.BorrowRenewal{
+		BorrowID:        borrow.ID,
+		RenewedAt:       now,
+		PreviousDueDate: borrow.DueDate,
+		NewDueDate:      borrow.DueDate.Add(s.Circulation.RenewalPeriod),
+	}
+
+	// Start transaction
+	tx, borrowRepo := s.BorrowRepo.BeginTransaction()
+
+	if err := borrowRepo.Renew(borrow, renewal.NewDueDate); err != nil {
+		borrowRepo.RollbackTransaction(tx)
+		return dto.BorrowResponse{}, err
+	}
+	if err := borrowRepo.CreateRenewal(renewal); err != nil {
+		borrowRepo.RollbackTransaction(tx)
+		return dto.BorrowResponse{}, err
+	}
+
+	if err := borrowRepo.CommitTransaction(tx); err != nil {
+		return dto.BorrowResponse{}, err
+	}
+	borrow.DueDate = renewal.NewDueDate
+	borrow.RenewalCount++
+	borrow.Renewals = append(borrow.Renewals, *renewal)
+	return mappers.MapBorrowToResponse(borrow), nil
 }
 
-// GetUserBorrows retrieves borrow records for a specific user
-func (s *BorrowService) GetUserBorrows(userID uint, page, limit int) ([]dto.BorrowResponse, int64, error) {
+// GetBorrowRecords retrieves borrow records matching the filter with pagination
+func (s *BorrowService) GetBorrowRecords(filter dto.BorrowFilter, page, limit int) ([]dto.BorrowResponse, int64, error) {
 	// Fetch borrows from the repository
-	borrows, total, err := s.BorrowRepo.GetBorrowsByUserID(userID, page, limit)
+	borrows, total, err := s.BorrowRepo.GetAll(filter, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -144,19 +356,13 @@ func (s *BorrowService) GetUserBorrows(userID uint, page, limit int) ([]dto.Borr
 	// Map each models.Borrow to dto.BorrowResponse
 	borrowResponses := make([]dto.BorrowResponse, len(borrows))
 	for i, borrow := range borrows {
-		borrowResponses[i] = dto.BorrowResponse{
-			ID:      borrow.ID,
-			DueDate: borrow.DueDate,
-			Book: &dto.BookResponse{
-				ID:              borrow.Book.ID,
-				Title:           borrow.Book.Title,
-				Author:          borrow.Book.Author,
-				ISBN:            borrow.Book.ISBN,
-				CopiesAvailable: borrow.Book.CopiesAvailable,
-				PublishedAt:     borrow.Book.PublishedAt,
-			},
-		}
+		borrowResponses[i] = mappers.MapBorrowToResponse(&borrow)
 	}
 
 	return borrowResponses, total, nil
 }
+
+// GetUserBorrows retrieves the loan history of a specific user
+func (s *BorrowService) GetUserBorrows(userID uint, status constants.BorrowStatus, page, limit int) ([]dto.BorrowResponse, int64, error) {
+	return s.GetBorrowRecords(dto.BorrowFilter{UserID: userID, Status: status}, page, limit)
+}
@@ -0,0 +1,356 @@
+package services_test
+
+import (
+	"library-management/config"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/policy"
+	"library-management/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var testCirculation = config.CirculationConfig{
+	HoldPickupWindow:   3 * 24 * time.Hour,
+	RenewalPeriod:      14 * 24 * time.Hour,
+	MaxRenewals:        2,
+	RenewalGracePeriod: 3 * 24 * time.Hour,
+	FinePerDay:         25,
+	MaxFinePerLoan:     1000,
+	FineBlockThreshold: 500,
+}
+
+func newTestBorrowService() (services.BorrowServiceInterface, *mocks.BorrowRepositoryInterface, *mocks.HoldRepositoryInterface) {
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	holdRepo := new(mocks.HoldRepositoryInterface)
+	borrowService := services.NewBorrowService(borrowRepo, new(mocks.BookRepositoryInterface), new(mocks.BookCopyRepositoryInterface), new(mocks.UserRepositoryInterface), holdRepo, new(mocks.FineRepositoryInterface), testCirculation, policy.DefaultCirculationPolicy())
+	return borrowService, borrowRepo, holdRepo
+}
+
+func TestRenewBorrow_Success(t *testing.T) {
+	borrowService, borrowRepo, holdRepo := newTestBorrowService()
+
+	dueDate := time.Now().Add(2 * 24 * time.Hour)
+	borrow := &models.Borrow{UserID: 1, BookID: 2, DueDate: dueDate, RenewalCount: 1}
+	borrow.ID = 3
+	tx := &gorm.DB{}
+
+	borrowRepo.On("GetBorrowRecord", uint(1), uint(3)).Return(borrow, nil)
+	holdRepo.On("CountActiveHolds", uint(2), uint(1)).Return(int64(0), nil)
+	borrowRepo.On("BeginTransaction").Return(tx, borrowRepo)
+	borrowRepo.On("Renew", borrow, dueDate.Add(testCirculation.RenewalPeriod)).Return(nil)
+	borrowRepo.On("CreateRenewal", mock.AnythingOfType("*models.BorrowRenewal")).Return(nil)
+	borrowRepo.On("CommitTransaction", tx).Return(nil)
+
+	response, err := borrowService.RenewBorrow(3, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.RenewalCount)
+	assert.True(t, response.DueDate.Equal(dueDate.Add(testCirculation.RenewalPeriod)))
+	assert.Len(t, response.Renewals, 1)
+	assert.True(t, response.Renewals[0].PreviousDueDate.Equal(dueDate))
+	borrowRepo.AssertExpectations(t)
+	holdRepo.AssertExpectations(t)
+}
+
+func TestRenewBorrow_ChangedMeanwhile(t *testing.T) {
+	borrowService, borrowRepo, holdRepo := newTestBorrowService()
+
+	dueDate := time.Now().Add(2 * 24 * time.Hour)
+	borrow := &models.Borrow{UserID: 1, BookID: 2, DueDate: dueDate, RenewalCount: 1}
+	borrow.ID = 3
+	tx := &gorm.DB{}
+
+	// Returned or renewed by another request since it was read
+	borrowRepo.On("GetBorrowRecord", uint(1), uint(3)).Return(borrow, nil)
+	holdRepo.On("CountActiveHolds", uint(2), uint(1)).Return(int64(0), nil)
+	borrowRepo.On("BeginTransaction").Return(tx, borrowRepo)
+	borrowRepo.On("Renew", borrow, mock.AnythingOfType("time.Time")).Return(constants.ErrLoanChanged)
+	borrowRepo.On("RollbackTransaction", tx).Return()
+
+	_, err := borrowService.RenewBorrow(3, 1)
+
+	assert.Equal(t, constants.ErrLoanChanged, err)
+	borrowRepo.AssertNotCalled(t, "CreateRenewal", mock.Anything)
+	borrowRepo.AssertExpectations(t)
+}
+
+func TestRenewBorrow_Refused(t *testing.T) {
+	testCases := []struct {
+		name        string
+		borrow      models.Borrow
+		activeHolds int64
+		expectedErr error
+		checksHolds bool
+	}{
+		{
+			name:        "Renewal limit reached",
+			borrow:      models.Borrow{DueDate: time.Now().Add(24 * time.Hour), RenewalCount: 2},
+			expectedErr: constants.ErrRenewalLimitReached,
+		},
+		{
+			name:        "Overdue past the grace period",
+			borrow:      models.Borrow{DueDate: time.Now().Add(-4 * 24 * time.Hour)},
+			expectedErr: constants.ErrLoanTooOverdue,
+		},
+		{
+			name:        "Another member holds the book",
+			borrow:      models.Borrow{DueDate: time.Now().Add(-24 * time.Hour)},
+			activeHolds: 1,
+			expectedErr: constants.ErrBookOnHold,
+			checksHolds: true,
+		},
+		{
+			name:        "Already returned",
+			borrow:      models.Borrow{DueDate: time.Now(), Returned: true},
+			expectedErr: constants.ErrAlreadyReturned,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			borrowService, borrowRepo, holdRepo := newTestBorrowService()
+
+			borrow := tc.borrow
+			borrow.UserID = 1
+			borrow.BookID = 2
+			borrowRepo.On("GetBorrowRecord", uint(1), uint(3)).Return(&borrow, nil)
+			if tc.checksHolds {
+				holdRepo.On("CountActiveHolds", uint(2), uint(1)).Return(tc.activeHolds, nil)
+			}
+
+			_, err := borrowService.RenewBorrow(3, 1)
+
+			assert.Equal(t, tc.expectedErr, err)
+			borrowRepo.AssertNotCalled(t, "BeginTransaction")
+			holdRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBorrowBook_MemberCannotOverrideDueDate(t *testing.T) {
+	borrowService, borrowRepo, _ := newTestBorrowService()
+
+	dueDate := time.Now().Add(365 * 24 * time.Hour)
+	err := borrowService.BorrowBook(dto.BorrowCreateRequest{BookID: 2, DueDate: &dueDate}, 1, string(constants.Member))
+
+	assert.Equal(t, constants.ErrDueDateOverride, err)
+	borrowRepo.AssertNotCalled(t, "BeginTransaction")
+}
+
+func TestBorrowBook_LoanLimitReached(t *testing.T) {
+	fineRepo := new(mocks.FineRepositoryInterface)
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	borrowService := services.NewBorrowService(borrowRepo, new(mocks.BookRepositoryInterface), new(mocks.BookCopyRepositoryInterface), new(mocks.UserRepositoryInterface), new(mocks.HoldRepositoryInterface), fineRepo, testCirculation, policy.DefaultCirculationPolicy())
+
+	fineRepo.On("GetOutstandingBalance", uint(1)).Return(int64(0), nil)
+	borrowRepo.On("CountActiveLoans", uint(1)).Return(int64(5), nil)
+
+	err := borrowService.BorrowBook(dto.BorrowCreateRequest{BookID: 2}, 1, string(constants.Member))
+
+	assert.Equal(t, constants.ErrLoanLimitReached, err)
+	borrowRepo.AssertNotCalled(t, "BeginTransaction")
+	borrowRepo.AssertExpectations(t)
+}
+
+func TestBorrowBook_LoanLimitReachedMeanwhile(t *testing.T) {
+	fineRepo := new(mocks.FineRepositoryInterface)
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	bookRepo := new(mocks.BookRepositoryInterface)
+	copyRepo := new(mocks.BookCopyRepositoryInterface)
+	userRepo := new(mocks.UserRepositoryInterface)
+	holdRepo := new(mocks.HoldRepositoryInterface)
+	borrowService := services.NewBorrowService(borrowRepo, bookRepo, copyRepo, userRepo, holdRepo, fineRepo, testCirculation, policy.DefaultCirculationPolicy())
+
+	book := &models.Book{ItemType: string(constants.ItemBook), CopiesAvailable: 1}
+	book.ID = 2
+	tx := &gorm.DB{}
+
+	fineRepo.On("GetOutstandingBalance", uint(1)).Return(int64(0), nil)
+	// Another checkout for the user went through since the first count
+	borrowRepo.On("CountActiveLoans", uint(1)).Return(int64(4), nil).Once()
+	holdRepo.On("GetExpiredReady", mock.AnythingOfType("time.Time")).Return([]models.Hold{}, nil)
+	bookRepo.On("GetByID", uint(2), mock.Anything).Return(book, nil)
+	holdRepo.On("GetActiveHold", uint(1), uint(2)).Return(nil, constants.ErrHoldNotFound)
+	borrowRepo.On("BeginTransaction").Return(tx, borrowRepo)
+	copyRepo.On("WithTx", tx).Return(copyRepo)
+	userRepo.On("WithTx", tx).Return(userRepo)
+	userRepo.On("LockForUpdate", uint(1)).Return(nil)
+	borrowRepo.On("CountActiveLoans", uint(1)).Return(int64(5), nil).Once()
+	borrowRepo.On("RollbackTransaction", tx).Return()
+
+	err := borrowService.BorrowBook(dto.BorrowCreateRequest{BookID: 2}, 1, string(constants.Member))
+
+	assert.Equal(t, constants.ErrLoanLimitReached, err)
+	copyRepo.AssertNotCalled(t, "CheckOutAvailable", mock.Anything)
+	borrowRepo.AssertNotCalled(t, "Create", mock.Anything)
+	borrowRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+}
+
+func TestBorrowBook_EmailNotVerified(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	circulation := testCirculation
+	circulation.RequireVerifiedEmail = true
+	borrowService := services.NewBorrowService(borrowRepo, new(mocks.BookRepositoryInterface), new(mocks.BookCopyRepositoryInterface), userRepo, new(mocks.HoldRepositoryInterface), new(mocks.FineRepositoryInterface), circulation, policy.DefaultCirculationPolicy())
+
+	user := &models.User{}
+	user.ID = 1
+	userRepo.On("GetByID", uint(1), []string{"id", "email_verified_at"}).Return(user, nil)
+
+	err := borrowService.BorrowBook(dto.BorrowCreateRequest{BookID: 2}, 1, string(constants.Member))
+
+	assert.Equal(t, constants.ErrEmailNotVerified, err)
+	borrowRepo.AssertNotCalled(t, "BeginTransaction")
+}
+
+func TestReturnBook_SetsCopyAsideForNextHold(t *testing.T) {
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	copyRepo := new(mocks.BookCopyRepositoryInterface)
+	holdRepo := new(mocks.HoldRepositoryInterface)
+	borrowService := services.NewBorrowService(borrowRepo, new(mocks.BookRepositoryInterface), copyRepo, new(mocks.UserRepositoryInterface), holdRepo, new(mocks.FineRepositoryInterface), testCirculation, policy.DefaultCirculationPolicy())
+
+	copyID := uint(5)
+	borrow := &models.Borrow{UserID: 1, BookID: 2, CopyID: &copyID, DueDate: time.Now().Add(24 * time.Hour)}
+	borrow.ID = 3
+	bookCopy := &models.BookCopy{BookID: 2, Status: string(constants.CopyOnLoan)}
+	bookCopy.ID = copyID
+	next := &models.Hold{UserID: 4, BookID: 2, Status: string(constants.HoldPending)}
+	tx := &gorm.DB{}
+
+	borrowRepo.On("GetBorrowRecord", uint(1), uint(3)).Return(borrow, nil)
+	borrowRepo.On("BeginTransaction").Return(tx, borrowRepo)
+	borrowRepo.On("MarkReturned", borrow).Return(nil)
+	holdRepo.On("WithTx", tx).Return(holdRepo)
+	copyRepo.On("WithTx", tx).Return(copyRepo)
+	copyRepo.On("GetByID", copyID).Return(bookCopy, nil)
+	holdRepo.On("GetNextPending", uint(2)).Return(next, nil)
+	holdRepo.On("UpdateStatus", next, constants.HoldPending).Return(nil)
+	copyRepo.On("UpdateStatus", bookCopy, constants.CopyOnLoan).Return(nil)
+	borrowRepo.On("CommitTransaction", tx).Return(nil)
+
+	err := borrowService.ReturnBook(dto.ReturnRequest{BorrowID: 3}, 1)
+
+	assert.NoError(t, err)
+	assert.True(t, borrow.Returned)
+	assert.Equal(t, string(constants.HoldReady), next.Status)
+	assert.Equal(t, &copyID, next.CopyID)
+	assert.Equal(t, string(constants.CopyOnHold), bookCopy.Status)
+	borrowRepo.AssertExpectations(t)
+	holdRepo.AssertExpectations(t)
+	copyRepo.AssertExpectations(t)
+}
+
+func TestForceReturn(t *testing.T) {
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	copyRepo := new(mocks.BookCopyRepositoryInterface)
+	holdRepo := new(mocks.HoldRepositoryInterface)
+	borrowService := services.NewBorrowService(borrowRepo, new(mocks.BookRepositoryInterface), copyRepo, new(mocks.UserRepositoryInterface), holdRepo, new(mocks.FineRepositoryInterface), testCirculation, policy.DefaultCirculationPolicy())
+
+	copyID := uint(5)
+	borrow := &models.Borrow{UserID: 1, BookID: 2, CopyID: &copyID, DueDate: time.Now().Add(24 * time.Hour)}
+	borrow.ID = 3
+	bookCopy := &models.BookCopy{BookID: 2, Status: string(constants.CopyOnLoan)}
+	bookCopy.ID = copyID
+	tx := &gorm.DB{}
+
+	borrowRepo.On("GetByID", uint(3)).Return(borrow, nil)
+	borrowRepo.On("BeginTransaction").Return(tx, borrowRepo)
+	borrowRepo.On("MarkReturned", borrow).Return(nil)
+	holdRepo.On("WithTx", tx).Return(holdRepo)
+	copyRepo.On("WithTx", tx).Return(copyRepo)
+	copyRepo.On("GetByID", copyID).Return(bookCopy, nil)
+	holdRepo.On("GetNextPending", uint(2)).Return(nil, constants.ErrHoldNotFound)
+	copyRepo.On("UpdateStatus", bookCopy, constants.CopyOnLoan).Return(nil)
+	borrowRepo.On("CommitTransaction", tx).Return(nil)
+
+	err := borrowService.ForceReturn(3)
+
+	assert.NoError(t, err)
+	assert.True(t, borrow.Returned)
+	assert.Nil(t, borrow.ReturnedBy)
+	assert.Equal(t, string(constants.CopyAvailable), bookCopy.Status)
+	borrowRepo.AssertExpectations(t)
+	copyRepo.AssertExpectations(t)
+}
+
+func TestForceReturn_AlreadyReturned(t *testing.T) {
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	borrowService := services.NewBorrowService(borrowRepo, new(mocks.BookRepositoryInterface), new(mocks.BookCopyRepositoryInterface), new(mocks.UserRepositoryInterface), new(mocks.HoldRepositoryInterface), new(mocks.FineRepositoryInterface), testCirculation, policy.DefaultCirculationPolicy())
+
+	borrowRepo.On("GetByID", uint(3)).Return(&models.Borrow{Returned: true}, nil)
+
+	err := borrowService.ForceReturn(3)
+
+	assert.ErrorIs(t, err, constants.ErrAlreadyReturned)
+	borrowRepo.AssertNotCalled(t, "BeginTransaction")
+}
+
+func TestCheckOut_Refused(t *testing.T) {
+	past := time.Now().Add(-24 * time.Hour)
+	testCases := []struct {
+		name        string
+		userID      uint
+		req         dto.BorrowCreateRequest
+		expectedErr error
+	}{
+		{
+			name:        "Unknown user",
+			userID:      9,
+			req:         dto.BorrowCreateRequest{BookID: 2},
+			expectedErr: constants.ErrUserNotFound,
+		},
+		{
+			name:        "Due date in the past",
+			userID:      1,
+			req:         dto.BorrowCreateRequest{BookID: 2, DueDate: &past},
+			expectedErr: constants.ErrInvalidDueDate,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			borrowRepo := new(mocks.BorrowRepositoryInterface)
+			userRepo := new(mocks.UserRepositoryInterface)
+			borrowService := services.NewBorrowService(borrowRepo, new(mocks.BookRepositoryInterface), new(mocks.BookCopyRepositoryInterface), userRepo, new(mocks.HoldRepositoryInterface), new(mocks.FineRepositoryInterface), testCirculation, policy.DefaultCirculationPolicy())
+
+			member := &models.User{Role: string(constants.Member)}
+			member.ID = 1
+			userRepo.On("GetByID", uint(1), mock.Anything).Return(member, nil).Maybe()
+			userRepo.On("GetByID", uint(9), mock.Anything).Return(nil, constants.ErrUserNotFound).Maybe()
+
+			err := borrowService.CheckOut(tc.req, tc.userID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			borrowRepo.AssertNotCalled(t, "BeginTransaction")
+		})
+	}
+}
+
+func TestCheckIn_RecordsStaff(t *testing.T) {
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	borrowService := services.NewBorrowService(borrowRepo, new(mocks.BookRepositoryInterface), new(mocks.BookCopyRepositoryInterface), new(mocks.UserRepositoryInterface), new(mocks.HoldRepositoryInterface), new(mocks.FineRepositoryInterface), testCirculation, policy.DefaultCirculationPolicy())
+
+	borrow := &models.Borrow{UserID: 1, BookID: 2, DueDate: time.Now().Add(24 * time.Hour)}
+	borrow.ID = 3
+	tx := &gorm.DB{}
+
+	borrowRepo.On("GetByID", uint(3)).Return(borrow, nil)
+	borrowRepo.On("BeginTransaction").Return(tx, borrowRepo)
+	borrowRepo.On("MarkReturned", borrow).Return(nil)
+	borrowRepo.On("CommitTransaction", tx).Return(nil)
+
+	err := borrowService.CheckIn(3, 7)
+
+	assert.NoError(t, err)
+	assert.True(t, borrow.Returned)
+	assert.Equal(t, uint(7), *borrow.ReturnedBy)
+	borrowRepo.AssertExpectations(t)
+}
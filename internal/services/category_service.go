@@ -0,0 +1,150 @@
+package services
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/repository"
+	"library-management/internal/utils/mappers"
+	"slices"
+	"strings"
+)
+
+type CategoryServiceInterface interface {
+	CreateCategory(req dto.CategoryCreateRequest) (dto.CategoryResponse, error)
+	GetCategory(id uint) (dto.CategoryResponse, error)
+	GetCategoryTree() ([]dto.CategoryResponse, error)
+	UpdateCategory(id uint, req dto.CategoryUpdateRequest) (dto.CategoryResponse, error)
+	DeleteCategory(id uint) error
+}
+
+type CategoryService struct {
+	Repo repository.CategoryRepositoryInterface
+}
+
+func NewCategoryService(repo repository.CategoryRepositoryInterface) CategoryServiceInterface {
+	return &CategoryService{Repo: repo}
+}
+
+// Create Category, at the top level or under a parent category
+func (s *CategoryService) CreateCategory(req dto.CategoryCreateRequest) (dto.CategoryResponse, error) {
+	category := mappers.MapCreateRequestToCategory(req)
+	if category.Name == "" {
+		return dto.CategoryResponse{}, constants.ErrInvalidInput
+	}
+
+	// Check if the parent exists
+	if category.ParentID != nil {
+		if _, err := s.Repo.GetByID(*category.ParentID); err != nil {
+			return dto.CategoryResponse{}, err
+		}
+	}
+
+	// Sibling categories must have different names
+	existingCategory, _ := s.Repo.GetByName(category.ParentID, category.Name)
+	if existingCategory != nil {
+		return dto.CategoryResponse{}, constants.ErrCategoryExists
+	}
+
+	category, err := s.Repo.Create(category)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+	return mappers.MapCategoryToResponse(category), nil
+}
+
+// GetCategory returns a category with all the categories below it
+func (s *CategoryService) GetCategory(id uint) (dto.CategoryResponse, error) {
+	ids, err := s.Repo.GetSubtreeIDs(id)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+	if len(ids) == 0 {
+		return dto.CategoryResponse{}, constants.ErrCategoryNotFound
+	}
+
+	categories, err := s.Repo.GetByIDs(ids)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+	for _, root := range mappers.BuildCategoryTree(categories) {
+		if root.ID == id {
+			return root, nil
+		}
+	}
+	return dto.CategoryResponse{}, constants.ErrCategoryNotFound
+}
+
+// GetCategoryTree returns the whole taxonomy, top-level categories first
+func (s *CategoryService) GetCategoryTree() ([]dto.CategoryResponse, error) {
+	categories, err := s.Repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return mappers.BuildCategoryTree(categories), nil
+}
+
+// Update Category. It can be renamed and moved, but not below itself.
+func (s *CategoryService) UpdateCategory(id uint, req dto.CategoryUpdateRequest) (dto.CategoryResponse, error) {
+	category, err := s.Repo.GetByID(id)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+
+	if req.Name != nil {
+		category.Name = strings.Join(strings.Fields(*req.Name), " ")
+		if category.Name == "" {
+			return dto.CategoryResponse{}, constants.ErrInvalidInput
+		}
+	}
+
+	if req.ParentID != nil {
+		if *req.ParentID == 0 {
+			category.ParentID = nil
+		} else {
+			if _, err := s.Repo.GetByID(*req.ParentID); err != nil {
+				return dto.CategoryResponse{}, err
+			}
+			// The new parent can't be the category itself or one of its descendants
+			subtree, err := s.Repo.GetSubtreeIDs(id)
+			if err != nil {
+				return dto.CategoryResponse{}, err
+			}
+			if slices.Contains(subtree, *req.ParentID) {
+				return dto.CategoryResponse{}, constants.ErrCategoryCycle
+			}
+			category.ParentID = req.ParentID
+		}
+	}
+
+	// Check if a sibling already has the name
+	existingCategory, _ := s.Repo.GetByName(category.ParentID, category.Name)
+	if existingCategory != nil && existingCategory.ID != id {
+		return dto.CategoryResponse{}, constants.ErrCategoryExists
+	}
+
+	if err := s.Repo.Update(category); err != nil {
+		return dto.CategoryResponse{}, err
+	}
+	return mappers.MapCategoryToResponse(category), nil
+}
+
+// Delete Category, unless it still has subcategories or books
+func (s *CategoryService) DeleteCategory(id uint) error {
+	if _, err := s.Repo.GetByID(id); err != nil {
+		return err
+	}
+
+	children, err := s.Repo.CountChildren(id)
+	if err != nil {
+		return err
+	}
+	books, err := s.Repo.CountBooks(id)
+	if err != nil {
+		return err
+	}
+	if children > 0 || books > 0 {
+		return constants.ErrCategoryInUse
+	}
+
+	return s.Repo.Delete(id)
+}
@@ -0,0 +1,58 @@
+package services_test
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateCategory_CannotMoveBelowItself(t *testing.T) {
+	testCases := []struct {
+		name     string
+		parentID uint
+	}{
+		{name: "Under itself", parentID: 1},
+		{name: "Under a subcategory", parentID: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.CategoryRepositoryInterface)
+			categoryService := services.NewCategoryService(mockRepo)
+
+			science := &models.Category{Name: "Science"}
+			science.ID = 1
+			parent := &models.Category{Name: "Parent"}
+			parent.ID = tc.parentID
+
+			mockRepo.On("GetByID", uint(1)).Return(science, nil).Once()
+			mockRepo.On("GetByID", tc.parentID).Return(parent, nil)
+			mockRepo.On("GetSubtreeIDs", uint(1)).Return([]uint{1, 2, 3}, nil)
+
+			_, err := categoryService.UpdateCategory(1, dto.CategoryUpdateRequest{ParentID: &tc.parentID})
+
+			assert.Equal(t, constants.ErrCategoryCycle, err)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+		})
+	}
+}
+
+func TestDeleteCategory_InUse(t *testing.T) {
+	mockRepo := new(mocks.CategoryRepositoryInterface)
+	categoryService := services.NewCategoryService(mockRepo)
+
+	mockRepo.On("GetByID", uint(1)).Return(&models.Category{Name: "Science"}, nil)
+	mockRepo.On("CountChildren", uint(1)).Return(int64(0), nil)
+	mockRepo.On("CountBooks", uint(1)).Return(int64(2), nil)
+
+	err := categoryService.DeleteCategory(1)
+
+	assert.Equal(t, constants.ErrCategoryInUse, err)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"fmt"
+	"library-management/config"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/notify"
+	"library-management/internal/repository"
+	"library-management/internal/utils/auth"
+	"time"
+)
+
+type EmailVerificationServiceInterface interface {
+	SendVerification(userID uint) error
+	VerifyEmail(req dto.VerifyEmailRequest) error
+}
+
+type EmailVerificationService struct {
+	UserRepo   repository.UserRepositoryInterface
+	VerifyRepo repository.EmailVerificationRepositoryInterface
+	Notifier   notify.Notifier
+	Config     config.EmailVerificationConfig
+}
+
+func NewEmailVerificationService(userRepo repository.UserRepositoryInterface, verifyRepo repository.EmailVerificationRepositoryInterface, notifier notify.Notifier, verificationConfig config.EmailVerificationConfig) EmailVerificationServiceInterface {
+	return &EmailVerificationService{UserRepo: userRepo, VerifyRepo: verifyRepo, Notifier: notifier, Config: verificationConfig}
+}
+
+// SendVerification emails a verification link to the user's current email.
+// Only the latest link sent works.
+func (s *EmailVerificationService) SendVerification(userID uint) error {
+	user, err := s.UserRepo.GetByID(userID, []string{"id", "email", "email_verified_at"})
+	if err != nil {
+		return constants.ErrUserNotFound
+	}
+	if user.EmailVerifiedAt != nil {
+		return constants.ErrEmailAlreadyVerified
+	}
+
+	sent, err := s.VerifyRepo.CountSince(user.ID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if sent >= int64(s.Config.MaxEmailsPerHour) {
+		return constants.ErrTooManyRequests
+	}
+
+	if err := s.VerifyRepo.InvalidateForUser(user.ID); err != nil {
+		return err
+	}
+	token, err := auth.NewRandomToken()
+	if err != nil {
+		return err
+	}
+	err = s.VerifyRepo.Create(&models.EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: time.Now().Add(s.Config.TokenTTL),
+	})
+	if err != nil {
+		return err
+	}
+	return s.Notifier.Send(s.verificationMessage(user.Email, token))
+}
+
+// VerifyEmail marks the email a token was sent to verified, as long as the
+// user still has that email
+func (s *EmailVerificationService) VerifyEmail(req dto.VerifyEmailRequest) error {
+	token, err := s.VerifyRepo.GetByHash(auth.HashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	if token.UsedAt != nil || !time.Now().Before(token.ExpiresAt) {
+		return constants.ErrInvalidVerificationToken
+	}
+
+	user, err := s.UserRepo.GetByID(token.UserID, []string{"id", "email"})
+	if err != nil || user.Email != token.Email {
+		return constants.ErrInvalidVerificationToken
+	}
+
+	// The token is only spent if the email is marked verified
+	tx, verifyRepo := s.VerifyRepo.BeginTransaction()
+	if err := verifyRepo.Use(token); err != nil {
+		verifyRepo.RollbackTransaction(tx)
+		return err
+	}
+	now := time.Now()
+	if err := s.UserRepo.WithTx(tx).SetEmailVerifiedAt(user.ID, &now); err != nil {
+		verifyRepo.RollbackTransaction(tx)
+		return err
+	}
+	return verifyRepo.CommitTransaction(tx)
+}
+
+// verificationMessage writes the email carrying a verification token
+func (s *EmailVerificationService) verificationMessage(email, token string) notify.Message {
+	return notify.Message{
+		To:      email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Welcome to the library! Please confirm this is your email address.\n\n%s\n\nIt works for the next %d hours. If you didn't sign up, you can ignore this email.",
+			tokenInstructions(s.Config.URL, token, "verify your email"), int(s.Config.TokenTTL.Hours())),
+	}
+}
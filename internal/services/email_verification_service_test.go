@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"errors"
+	"library-management/config"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/repository"
+	"library-management/internal/services"
+	"library-management/internal/utils/auth"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testVerificationConfig = config.EmailVerificationConfig{
+	TokenTTL:         48 * time.Hour,
+	MaxEmailsPerHour: 3,
+	URL:              "https://library.example.com/verify-email",
+}
+
+func TestSendVerification_SendsLink(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	verifyRepo := new(mocks.EmailVerificationRepositoryInterface)
+	notifier := &fakeNotifier{}
+	verificationService := services.NewEmailVerificationService(userRepo, verifyRepo, notifier, testVerificationConfig)
+
+	user := &models.User{Email: "test@example.com"}
+	user.ID = 1
+	var stored *models.EmailVerificationToken
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	verifyRepo.On("CountSince", uint(1), mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+	verifyRepo.On("InvalidateForUser", uint(1)).Return(nil)
+	verifyRepo.On("Create", mock.AnythingOfType("*models.EmailVerificationToken")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*models.EmailVerificationToken) }).Return(nil)
+
+	err := verificationService.SendVerification(1)
+
+	assert.NoError(t, err)
+	assert.Len(t, notifier.sent, 1)
+	assert.Equal(t, "test@example.com", notifier.sent[0].To)
+
+	// The token is tied to the email it was sent to, and only its hash is stored
+	_, token, found := strings.Cut(notifier.sent[0].Body, testVerificationConfig.URL+"?token=")
+	assert.True(t, found)
+	token = strings.Fields(token)[0]
+	assert.Equal(t, auth.HashToken(token), stored.TokenHash)
+	assert.Equal(t, "test@example.com", stored.Email)
+	verifyRepo.AssertExpectations(t)
+}
+
+func TestSendVerification_Refused(t *testing.T) {
+	verifiedAt := time.Now()
+	testCases := []struct {
+		name        string
+		verifiedAt  *time.Time
+		sent        int64
+		expectedErr error
+	}{
+		{name: "Already verified", verifiedAt: &verifiedAt, expectedErr: constants.ErrEmailAlreadyVerified},
+		{name: "Too many emails", sent: 3, expectedErr: constants.ErrTooManyRequests},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo := new(mocks.UserRepositoryInterface)
+			verifyRepo := new(mocks.EmailVerificationRepositoryInterface)
+			notifier := &fakeNotifier{}
+			verificationService := services.NewEmailVerificationService(userRepo, verifyRepo, notifier, testVerificationConfig)
+
+			user := &models.User{Email: "test@example.com", EmailVerifiedAt: tc.verifiedAt}
+			user.ID = 1
+			userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+			verifyRepo.On("CountSince", uint(1), mock.AnythingOfType("time.Time")).Return(tc.sent, nil)
+
+			err := verificationService.SendVerification(1)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Empty(t, notifier.sent)
+			verifyRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestVerifyEmail_Success(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	verifyRepo := new(mocks.EmailVerificationRepositoryInterface)
+	verificationService := services.NewEmailVerificationService(userRepo, verifyRepo, &fakeNotifier{}, testVerificationConfig)
+
+	token := &models.EmailVerificationToken{UserID: 1, Email: "test@example.com", ExpiresAt: time.Now().Add(time.Hour)}
+	user := &models.User{Email: "test@example.com"}
+	user.ID = 1
+	tx := &gorm.DB{}
+	verifyRepo.On("GetByHash", auth.HashToken("secret")).Return(token, nil)
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	verifyRepo.On("BeginTransaction").Return(tx, verifyRepo)
+	verifyRepo.On("Use", token).Return(nil)
+	userRepo.On("WithTx", tx).Return(userRepo)
+	userRepo.On("SetEmailVerifiedAt", uint(1), mock.AnythingOfType("*time.Time")).Return(nil)
+	verifyRepo.On("CommitTransaction", tx).Return(nil)
+
+	err := verificationService.VerifyEmail(dto.VerifyEmailRequest{Token: "secret"})
+
+	assert.NoError(t, err)
+	verifyRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+}
+
+func TestVerifyEmail_UpdateFailed(t *testing.T) {
+	db := openTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.EmailVerificationToken{}))
+	user := models.User{Name: "Test", Email: "test@example.com", Password: "secret", Role: string(constants.Member)}
+	require.NoError(t, db.Create(&user).Error)
+	token := models.EmailVerificationToken{UserID: user.ID, Email: user.Email, TokenHash: auth.HashToken("secret"), ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, db.Create(&token).Error)
+
+	userRepo := new(mocks.UserRepositoryInterface)
+	verificationService := services.NewEmailVerificationService(userRepo, repository.NewEmailVerificationRepository(db), &fakeNotifier{}, testVerificationConfig)
+	userRepo.On("GetByID", user.ID, mock.Anything).Return(&user, nil)
+	userRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(userRepo)
+	userRepo.On("SetEmailVerifiedAt", user.ID, mock.AnythingOfType("*time.Time")).Return(errors.New("connection lost"))
+
+	err := verificationService.VerifyEmail(dto.VerifyEmailRequest{Token: "secret"})
+
+	// Using the token is rolled back with the update, so the link still works
+	assert.Error(t, err)
+	var stored models.EmailVerificationToken
+	require.NoError(t, db.First(&stored, token.ID).Error)
+	assert.Nil(t, stored.UsedAt)
+}
+
+func TestVerifyEmail_InvalidToken(t *testing.T) {
+	usedAt := time.Now().Add(-time.Minute)
+	testCases := []struct {
+		name  string
+		token *models.EmailVerificationToken
+	}{
+		{name: "Used", token: &models.EmailVerificationToken{UserID: 1, Email: "test@example.com", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}},
+		{name: "Expired", token: &models.EmailVerificationToken{UserID: 1, Email: "test@example.com", ExpiresAt: time.Now().Add(-time.Minute)}},
+		{name: "Email changed since", token: &models.EmailVerificationToken{UserID: 1, Email: "old@example.com", ExpiresAt: time.Now().Add(time.Hour)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo := new(mocks.UserRepositoryInterface)
+			verifyRepo := new(mocks.EmailVerificationRepositoryInterface)
+			verificationService := services.NewEmailVerificationService(userRepo, verifyRepo, &fakeNotifier{}, testVerificationConfig)
+
+			user := &models.User{Email: "test@example.com"}
+			user.ID = 1
+			verifyRepo.On("GetByHash", auth.HashToken("secret")).Return(tc.token, nil)
+			userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+
+			err := verificationService.VerifyEmail(dto.VerifyEmailRequest{Token: "secret"})
+
+			assert.Equal(t, constants.ErrInvalidVerificationToken, err)
+			verifyRepo.AssertNotCalled(t, "Use", mock.Anything)
+			userRepo.AssertNotCalled(t, "SetEmailVerifiedAt", mock.Anything, mock.Anything)
+		})
+	}
+}
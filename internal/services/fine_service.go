@@ -0,0 +1,141 @@
+package services
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/repository"
+	"library-management/internal/utils/mappers"
+	"time"
+)
+
+type FineServiceInterface interface {
+	GetFines(filter dto.FineFilter, page, limit int) ([]dto.FineResponse, int64, error)
+	GetBalance(userID uint) (int64, error)
+	RecordPayment(fineID, recordedBy uint, req dto.FinePaymentRequest) (dto.FineResponse, error)
+	WaiveFine(fineID, waivedBy uint, req dto.FineWaiveRequest) (dto.FineResponse, error)
+}
+
+type FineService struct {
+	Repo repository.FineRepositoryInterface
+}
+
+func NewFineService(repo repository.FineRepositoryInterface) FineServiceInterface {
+	return &FineService{Repo: repo}
+}
+
+// CalculateOverdueFine returns how many days late a loan came back and the fine
+// it accrued. Every started day counts, and the fine never exceeds maxFine.
+func CalculateOverdueFine(dueDate, returnedAt time.Time, perDay, maxFine int64) (int, int64) {
+	if !returnedAt.After(dueDate) {
+		return 0, 0
+	}
+
+	late := returnedAt.Sub(dueDate)
+	days := int(late / (24 * time.Hour))
+	if late%(24*time.Hour) != 0 {
+		days++
+	}
+
+	amount := int64(days) * perDay
+	if amount > maxFine {
+		amount = maxFine
+	}
+	return days, amount
+}
+
+// GetFines retrieves fines matching the filter with pagination
+func (s *FineService) GetFines(filter dto.FineFilter, page, limit int) ([]dto.FineResponse, int64, error) {
+	fines, total, err := s.Repo.GetAll(filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	fineResponses := make([]dto.FineResponse, len(fines))
+	for i, fine := range fines {
+		fineResponses[i] = mappers.MapFineToResponse(&fine)
+	}
+
+	return fineResponses, total, nil
+}
+
+// GetBalance returns the total unpaid fines of a user, in cents
+func (s *FineService) GetBalance(userID uint) (int64, error) {
+	return s.Repo.GetOutstandingBalance(userID)
+}
+
+// RecordPayment records a payment against a fine and settles it once fully paid
+func (s *FineService) RecordPayment(fineID, recordedBy uint, req dto.FinePaymentRequest) (dto.FineResponse, error) {
+	return s.settle(fineID, func(fine *models.Fine) (*models.FineTransaction, error) {
+		if req.Amount > fine.Balance() {
+			return nil, constants.ErrPaymentExceedsBalance
+		}
+		fine.AmountPaid += req.Amount
+		if fine.Balance() == 0 {
+			fine.Status = string(constants.FinePaid)
+		}
+		return &models.FineTransaction{
+			Type:       string(constants.FinePayment),
+			Amount:     req.Amount,
+			RecordedBy: recordedBy,
+			Note:       req.Note,
+		}, nil
+	})
+}
+
+// WaiveFine forgives whatever is left to pay on a fine
+func (s *FineService) WaiveFine(fineID, waivedBy uint, req dto.FineWaiveRequest) (dto.FineResponse, error) {
+	return s.settle(fineID, func(fine *models.Fine) (*models.FineTransaction, error) {
+		waived := fine.Balance()
+		fine.AmountWaived += waived
+		fine.Status = string(constants.FineWaived)
+		return &models.FineTransaction{
+			Type:       string(constants.FineWaiver),
+			Amount:     waived,
+			RecordedBy: waivedBy,
+			Note:       req.Reason,
+		}, nil
+	})
+}
+
+// settle applies a change to an unpaid fine and records it in the ledger.
+// The fine is locked while it is checked and changed, so only one of two
+// concurrent payments or waivers sees it unpaid.
+func (s *FineService) settle(fineID uint, apply func(fine *models.Fine) (*models.FineTransaction, error)) (dto.FineResponse, error) {
+	// Start transaction
+	tx, fineRepo := s.Repo.BeginTransaction()
+
+	fine, err := fineRepo.GetByIDForUpdate(fineID)
+	if err != nil {
+		fineRepo.RollbackTransaction(tx)
+		return dto.FineResponse{}, err
+	}
+	if fine.Status != string(constants.FineUnpaid) {
+		fineRepo.RollbackTransaction(tx)
+		return dto.FineResponse{}, constants.ErrFineSettled
+	}
+
+	transaction, err := apply(fine)
+	if err != nil {
+		fineRepo.RollbackTransaction(tx)
+		return dto.FineResponse{}, err
+	}
+	transaction.FineID = fine.ID
+	transaction.UserID = fine.UserID
+
+	if err := fineRepo.Update(fine); err != nil {
+		fineRepo.RollbackTransaction(tx)
+		return dto.FineResponse{}, err
+	}
+	if err := fineRepo.CreateTransaction(transaction); err != nil {
+		fineRepo.RollbackTransaction(tx)
+		return dto.FineResponse{}, err
+	}
+
+	if err := fineRepo.CommitTransaction(tx); err != nil {
+		return dto.FineResponse{}, err
+	}
+
+	fine.Transactions = append(fine.Transactions, *transaction)
+	return mappers.MapFineToResponse(fine), nil
+}
@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/policy"
+	"library-management/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCalculateOverdueFine(t *testing.T) {
+	dueDate := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		returnedAt     time.Time
+		expectedDays   int
+		expectedAmount int64
+	}{
+		{"Returned on time", dueDate.Add(-time.Hour), 0, 0},
+		{"Returned exactly at the due date", dueDate, 0, 0},
+		{"A started day counts as a full day", dueDate.Add(time.Hour), 1, 25},
+		{"Several days late", dueDate.Add(3 * 24 * time.Hour), 3, 75},
+		{"Fine is capped per loan", dueDate.Add(100 * 24 * time.Hour), 100, 1000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			days, amount := services.CalculateOverdueFine(dueDate, tc.returnedAt, 25, 1000)
+
+			assert.Equal(t, tc.expectedDays, days)
+			assert.Equal(t, tc.expectedAmount, amount)
+		})
+	}
+}
+
+func TestRecordPayment_SettlesFine(t *testing.T) {
+	mockRepo := new(mocks.FineRepositoryInterface)
+	fineService := services.NewFineService(mockRepo)
+
+	fine := &models.Fine{UserID: 1, BorrowID: 2, Amount: 300, AmountPaid: 100, Status: string(constants.FineUnpaid)}
+	fine.ID = 5
+	tx := &gorm.DB{}
+
+	mockRepo.On("BeginTransaction").Return(tx, mockRepo)
+	mockRepo.On("GetByIDForUpdate", uint(5)).Return(fine, nil)
+	mockRepo.On("Update", fine).Return(nil)
+	mockRepo.On("CreateTransaction", mock.AnythingOfType("*models.FineTransaction")).Return(nil)
+	mockRepo.On("CommitTransaction", tx).Return(nil)
+
+	response, err := fineService.RecordPayment(5, 9, dto.FinePaymentRequest{Amount: 200})
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(constants.FinePaid), response.Status)
+	assert.Equal(t, int64(0), response.Balance)
+	assert.Len(t, response.Transactions, 1)
+	assert.Equal(t, uint(9), response.Transactions[0].RecordedBy)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecordPayment_ExceedsBalance(t *testing.T) {
+	mockRepo := new(mocks.FineRepositoryInterface)
+	fineService := services.NewFineService(mockRepo)
+
+	fine := &models.Fine{Amount: 300, Status: string(constants.FineUnpaid)}
+	tx := &gorm.DB{}
+	mockRepo.On("BeginTransaction").Return(tx, mockRepo)
+	mockRepo.On("GetByIDForUpdate", uint(5)).Return(fine, nil)
+	mockRepo.On("RollbackTransaction", tx).Return()
+
+	_, err := fineService.RecordPayment(5, 9, dto.FinePaymentRequest{Amount: 500})
+
+	assert.Equal(t, constants.ErrPaymentExceedsBalance, err)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateTransaction", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWaiveFine_AlreadySettled(t *testing.T) {
+	mockRepo := new(mocks.FineRepositoryInterface)
+	fineService := services.NewFineService(mockRepo)
+
+	// Paid by a concurrent request while this one waited for the lock
+	fine := &models.Fine{Amount: 300, AmountPaid: 300, Status: string(constants.FinePaid)}
+	tx := &gorm.DB{}
+	mockRepo.On("BeginTransaction").Return(tx, mockRepo)
+	mockRepo.On("GetByIDForUpdate", uint(5)).Return(fine, nil)
+	mockRepo.On("RollbackTransaction", tx).Return()
+
+	_, err := fineService.WaiveFine(5, 9, dto.FineWaiveRequest{Reason: "first offence"})
+
+	assert.Equal(t, constants.ErrFineSettled, err)
+	mockRepo.AssertNotCalled(t, "CreateTransaction", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBorrowBook_BlockedByOutstandingFines(t *testing.T) {
+	fineRepo := new(mocks.FineRepositoryInterface)
+	borrowService := services.NewBorrowService(new(mocks.BorrowRepositoryInterface), new(mocks.BookRepositoryInterface), new(mocks.BookCopyRepositoryInterface), new(mocks.UserRepositoryInterface), new(mocks.HoldRepositoryInterface), fineRepo, testCirculation, policy.DefaultCirculationPolicy())
+
+	fineRepo.On("GetOutstandingBalance", uint(1)).Return(testCirculation.FineBlockThreshold+1, nil)
+
+	err := borrowService.BorrowBook(dto.BorrowCreateRequest{BookID: 2}, 1, string(constants.Member))
+
+	assert.Equal(t, constants.ErrOutstandingFines, err)
+	fineRepo.AssertExpectations(t)
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"errors"
+	"library-management/config"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/repository"
+	"library-management/internal/utils/mappers"
+	"time"
+)
+
+type HoldServiceInterface interface {
+	PlaceHold(req dto.HoldCreateRequest, userID uint) (dto.HoldResponse, error)
+	GetUserHolds(userID uint, status constants.HoldStatus, page, limit int) ([]dto.HoldResponse, int64, error)
+	GetBookQueue(bookID uint, page, limit int) ([]dto.HoldResponse, int64, error)
+	CancelHold(holdID, userID uint) error
+}
+
+type HoldService struct {
+	HoldRepo    repository.HoldRepositoryInterface
+	BorrowRepo  repository.BorrowRepositoryInterface
+	BookRepo    repository.BookRepositoryInterface
+	CopyRepo    repository.BookCopyRepositoryInterface
+	Circulation config.CirculationConfig
+}
+
+func NewHoldService(holdRepo repository.HoldRepositoryInterface, borrowRepo repository.BorrowRepositoryInterface, bookRepo repository.BookRepositoryInterface, copyRepo repository.BookCopyRepositoryInterface, circulation config.CirculationConfig) HoldServiceInterface {
+	return &HoldService{
+		HoldRepo:    holdRepo,
+		BorrowRepo:  borrowRepo,
+		BookRepo:    bookRepo,
+		CopyRepo:    copyRepo,
+		Circulation: circulation,
+	}
+}
+
+// PlaceHold puts the user in the queue for a book that has no copies available
+func (s *HoldService) PlaceHold(req dto.HoldCreateRequest, userID uint) (dto.HoldResponse, error) {
+	if err := expireHolds(s.HoldRepo, s.CopyRepo, s.Circulation.HoldPickupWindow); err != nil {
+		return dto.HoldResponse{}, err
+	}
+
+	// Check if the book exists
+	book, err := s.BookRepo.GetByID(req.BookID, nil)
+	if err != nil {
+		return dto.HoldResponse{}, constants.ErrBookNotFound
+	}
+
+	// Holds are only for books that can't be borrowed right away
+	if book.CopiesAvailable > 0 {
+		return dto.HoldResponse{}, constants.ErrBookAvailable
+	}
+
+	// A hold would only hand the member a second copy of what they have
+	if borrowed, err := s.BorrowRepo.HasActiveLoan(userID, req.BookID); err != nil {
+		return dto.HoldResponse{}, err
+	} else if borrowed {
+		return dto.HoldResponse{}, constants.ErrAlreadyBorrowed
+	}
+
+	// A member only gets one place in a book's queue; the database refuses
+	// a second one placed at the same time with ErrHoldExists too
+	if _, err := s.HoldRepo.GetActiveHold(userID, req.BookID); err == nil {
+		return dto.HoldResponse{}, constants.ErrHoldExists
+	} else if !errors.Is(err, constants.ErrHoldNotFound) {
+		return dto.HoldResponse{}, err
+	}
+
+	hold := &models.Hold{
+		UserID: userID,
+		BookID: req.BookID,
+		Status: string(constants.HoldPending),
+	}
+	if err := s.HoldRepo.Create(hold); err != nil {
+		return dto.HoldResponse{}, err
+	}
+
+	return s.toResponse(hold)
+}
+
+// GetUserHolds retrieves the holds placed by a user
+func (s *HoldService) GetUserHolds(userID uint, status constants.HoldStatus, page, limit int) ([]dto.HoldResponse, int64, error) {
+	return s.getHolds(dto.HoldFilter{UserID: userID, Status: status}, page, limit)
+}
+
+// GetBookQueue retrieves the active holds on a book in queue order
+func (s *HoldService) GetBookQueue(bookID uint, page, limit int) ([]dto.HoldResponse, int64, error) {
+	return s.getHolds(dto.HoldFilter{BookID: bookID, ActiveOnly: true}, page, limit)
+}
+
+// CancelHold removes the user from a book's queue. If a copy was already set
+// aside for them, it goes to the next member in line.
+func (s *HoldService) CancelHold(holdID, userID uint) error {
+	hold, err := s.HoldRepo.GetByID(holdID)
+	if err != nil || hold.UserID != userID {
+		return constants.ErrHoldNotFound
+	}
+
+	from := constants.HoldStatus(hold.Status)
+	if !from.IsActive() {
+		return constants.ErrHoldNotActive
+	}
+
+	// Start transaction
+	tx, holdRepo := s.HoldRepo.BeginTransaction()
+
+	now := time.Now()
+	hold.Status = string(constants.HoldCancelled)
+	hold.ClosedAt = &now
+	if err := holdRepo.UpdateStatus(hold, from); err != nil {
+		holdRepo.RollbackTransaction(tx)
+		return err
+	}
+
+	if from == constants.HoldReady && hold.CopyID != nil {
+		if err := releaseCopy(holdRepo, s.CopyRepo.WithTx(tx), *hold.CopyID, s.Circulation.HoldPickupWindow); err != nil {
+			holdRepo.RollbackTransaction(tx)
+			return err
+		}
+	}
+
+	return holdRepo.CommitTransaction(tx)
+}
+
+func (s *HoldService) getHolds(filter dto.HoldFilter, page, limit int) ([]dto.HoldResponse, int64, error) {
+	if err := expireHolds(s.HoldRepo, s.CopyRepo, s.Circulation.HoldPickupWindow); err != nil {
+		return nil, 0, err
+	}
+
+	holds, total, err := s.HoldRepo.GetAll(filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	holdResponses := make([]dto.HoldResponse, len(holds))
+	for i, hold := range holds {
+		holdResponses[i], err = s.toResponse(&hold)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return holdResponses, total, nil
+}
+
+// toResponse maps a hold and fills in its place in the queue while pending
+func (s *HoldService) toResponse(hold *models.Hold) (dto.HoldResponse, error) {
+	response := mappers.MapHoldToResponse(hold)
+	if hold.Status == string(constants.HoldPending) {
+		position, err := s.HoldRepo.GetQueuePosition(hold)
+		if err != nil {
+			return dto.HoldResponse{}, err
+		}
+		response.Position = position
+	}
+	return response, nil
+}
+
+// releaseCopy hands a copy that just became free to the next member in the
+// book's hold queue, setting it aside for the pickup window. The copy only
+// goes back on the shelf when nobody is waiting.
+func releaseCopy(holdRepo repository.HoldRepositoryInterface, copyRepo repository.BookCopyRepositoryInterface, copyID uint, pickupWindow time.Duration) error {
+	bookCopy, err := copyRepo.GetByID(copyID)
+	if err != nil {
+		return err
+	}
+	from := constants.CopyStatus(bookCopy.Status)
+
+	for {
+		next, err := holdRepo.GetNextPending(bookCopy.BookID)
+		if errors.Is(err, constants.ErrHoldNotFound) {
+			if from == constants.CopyAvailable {
+				return nil // already on the shelf
+			}
+			bookCopy.Status = string(constants.CopyAvailable)
+			return copyRepo.UpdateStatus(bookCopy, from)
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		expiresAt := now.Add(pickupWindow)
+		next.Status = string(constants.HoldReady)
+		next.ReadyAt = &now
+		next.ExpiresAt = &expiresAt
+		next.CopyID = &bookCopy.ID
+		err = holdRepo.UpdateStatus(next, constants.HoldPending)
+		if errors.Is(err, constants.ErrHoldNotActive) {
+			// Cancelled or served concurrently, try the next one in line
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		bookCopy.Status = string(constants.CopyOnHold)
+		return copyRepo.UpdateStatus(bookCopy, from)
+	}
+}
+
+// expireHolds closes ready holds whose pickup window has passed and passes
+// their copies on down the queue.
+func expireHolds(holdRepo repository.HoldRepositoryInterface, copyRepo repository.BookCopyRepositoryInterface, pickupWindow time.Duration) error {
+	now := time.Now()
+	holds, err := holdRepo.GetExpiredReady(now)
+	if err != nil {
+		return err
+	}
+
+	for _, hold := range holds {
+		if err := expireHold(holdRepo, copyRepo, &hold, now, pickupWindow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expireHold(holdRepo repository.HoldRepositoryInterface, copyRepo repository.BookCopyRepositoryInterface, hold *models.Hold, now time.Time, pickupWindow time.Duration) error {
+	tx, txHoldRepo := holdRepo.BeginTransaction()
+
+	hold.Status = string(constants.HoldExpired)
+	hold.ClosedAt = &now
+	err := txHoldRepo.UpdateStatus(hold, constants.HoldReady)
+	if errors.Is(err, constants.ErrHoldNotActive) {
+		// Already picked up or cancelled in the meantime
+		txHoldRepo.RollbackTransaction(tx)
+		return nil
+	}
+	if err != nil {
+		txHoldRepo.RollbackTransaction(tx)
+		return err
+	}
+
+	if hold.CopyID != nil {
+		if err := releaseCopy(txHoldRepo, copyRepo.WithTx(tx), *hold.CopyID, pickupWindow); err != nil {
+			txHoldRepo.RollbackTransaction(tx)
+			return err
+		}
+	}
+
+	return txHoldRepo.CommitTransaction(tx)
+}
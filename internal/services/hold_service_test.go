@@ -0,0 +1,233 @@
+package services_test
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newTestHoldService() (services.HoldServiceInterface, *mocks.HoldRepositoryInterface, *mocks.BorrowRepositoryInterface, *mocks.BookRepositoryInterface, *mocks.BookCopyRepositoryInterface) {
+	holdRepo := new(mocks.HoldRepositoryInterface)
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	bookRepo := new(mocks.BookRepositoryInterface)
+	copyRepo := new(mocks.BookCopyRepositoryInterface)
+	return services.NewHoldService(holdRepo, borrowRepo, bookRepo, copyRepo, testCirculation), holdRepo, borrowRepo, bookRepo, copyRepo
+}
+
+func TestPlaceHold_Success(t *testing.T) {
+	holdService, holdRepo, borrowRepo, bookRepo, _ := newTestHoldService()
+
+	book := &models.Book{Title: "The Hobbit", CopiesAvailable: 0}
+	book.ID = 2
+	holdRepo.On("GetExpiredReady", mock.AnythingOfType("time.Time")).Return([]models.Hold{}, nil)
+	bookRepo.On("GetByID", uint(2), mock.Anything).Return(book, nil)
+	borrowRepo.On("HasActiveLoan", uint(1), uint(2)).Return(false, nil)
+	holdRepo.On("GetActiveHold", uint(1), uint(2)).Return(nil, constants.ErrHoldNotFound)
+	holdRepo.On("Create", mock.AnythingOfType("*models.Hold")).Return(nil)
+	holdRepo.On("GetQueuePosition", mock.AnythingOfType("*models.Hold")).Return(int64(3), nil)
+
+	response, err := holdService.PlaceHold(dto.HoldCreateRequest{BookID: 2}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(constants.HoldPending), response.Status)
+	assert.Equal(t, int64(3), response.Position)
+	holdRepo.AssertExpectations(t)
+}
+
+func TestPlaceHold_Refused(t *testing.T) {
+	testCases := []struct {
+		name        string
+		book        *models.Book
+		onLoan      bool
+		activeHold  *models.Hold
+		expectedErr error
+	}{
+		{name: "Book not found", expectedErr: constants.ErrBookNotFound},
+		{name: "Copies available", book: &models.Book{CopiesAvailable: 1}, expectedErr: constants.ErrBookAvailable},
+		{name: "Already on loan", book: &models.Book{}, onLoan: true, expectedErr: constants.ErrAlreadyBorrowed},
+		{name: "Already in the queue", book: &models.Book{}, activeHold: &models.Hold{UserID: 1, BookID: 2}, expectedErr: constants.ErrHoldExists},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			holdService, holdRepo, borrowRepo, bookRepo, _ := newTestHoldService()
+
+			holdRepo.On("GetExpiredReady", mock.AnythingOfType("time.Time")).Return([]models.Hold{}, nil)
+			if tc.book != nil {
+				bookRepo.On("GetByID", uint(2), mock.Anything).Return(tc.book, nil)
+			} else {
+				bookRepo.On("GetByID", uint(2), mock.Anything).Return(nil, constants.ErrBookNotFound)
+			}
+			borrowRepo.On("HasActiveLoan", uint(1), uint(2)).Return(tc.onLoan, nil)
+			if tc.activeHold != nil {
+				holdRepo.On("GetActiveHold", uint(1), uint(2)).Return(tc.activeHold, nil)
+			} else {
+				holdRepo.On("GetActiveHold", uint(1), uint(2)).Return(nil, constants.ErrHoldNotFound)
+			}
+
+			_, err := holdService.PlaceHold(dto.HoldCreateRequest{BookID: 2}, 1)
+
+			assert.Equal(t, tc.expectedErr, err)
+			holdRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestPlaceHold_PlacedMeanwhile(t *testing.T) {
+	holdService, holdRepo, borrowRepo, bookRepo, _ := newTestHoldService()
+
+	holdRepo.On("GetExpiredReady", mock.AnythingOfType("time.Time")).Return([]models.Hold{}, nil)
+	bookRepo.On("GetByID", uint(2), mock.Anything).Return(&models.Book{}, nil)
+	borrowRepo.On("HasActiveLoan", uint(1), uint(2)).Return(false, nil)
+	holdRepo.On("GetActiveHold", uint(1), uint(2)).Return(nil, constants.ErrHoldNotFound)
+	// A second request got its hold in after the check
+	holdRepo.On("Create", mock.AnythingOfType("*models.Hold")).Return(constants.ErrHoldExists)
+
+	_, err := holdService.PlaceHold(dto.HoldCreateRequest{BookID: 2}, 1)
+
+	assert.Equal(t, constants.ErrHoldExists, err)
+	holdRepo.AssertNotCalled(t, "GetQueuePosition", mock.Anything)
+}
+
+func TestPlaceHold_ExpiredHoldPassesCopyOn(t *testing.T) {
+	holdService, holdRepo, borrowRepo, bookRepo, copyRepo := newTestHoldService()
+
+	copyID := uint(5)
+	expiredAt := time.Now().Add(-time.Hour)
+	expired := models.Hold{UserID: 3, BookID: 2, Status: string(constants.HoldReady), CopyID: &copyID, ExpiresAt: &expiredAt}
+	expired.ID = 6
+	bookCopy := &models.BookCopy{BookID: 2, Status: string(constants.CopyOnHold)}
+	bookCopy.ID = copyID
+	next := &models.Hold{UserID: 4, BookID: 2, Status: string(constants.HoldPending)}
+	next.ID = 7
+	tx := &gorm.DB{}
+	var closed *models.Hold
+
+	holdRepo.On("GetExpiredReady", mock.AnythingOfType("time.Time")).Return([]models.Hold{expired}, nil)
+	holdRepo.On("BeginTransaction").Return(tx, holdRepo)
+	holdRepo.On("UpdateStatus", mock.AnythingOfType("*models.Hold"), constants.HoldReady).
+		Run(func(args mock.Arguments) { closed = args.Get(0).(*models.Hold) }).Return(nil)
+	copyRepo.On("WithTx", tx).Return(copyRepo)
+	copyRepo.On("GetByID", copyID).Return(bookCopy, nil)
+	holdRepo.On("GetNextPending", uint(2)).Return(next, nil)
+	holdRepo.On("UpdateStatus", next, constants.HoldPending).Return(nil)
+	copyRepo.On("UpdateStatus", bookCopy, constants.CopyOnHold).Return(nil)
+	holdRepo.On("CommitTransaction", tx).Return(nil)
+	bookRepo.On("GetByID", uint(2), mock.Anything).Return(&models.Book{}, nil)
+	borrowRepo.On("HasActiveLoan", uint(1), uint(2)).Return(false, nil)
+	holdRepo.On("GetActiveHold", uint(1), uint(2)).Return(nil, constants.ErrHoldNotFound)
+	holdRepo.On("Create", mock.AnythingOfType("*models.Hold")).Return(nil)
+	holdRepo.On("GetQueuePosition", mock.AnythingOfType("*models.Hold")).Return(int64(1), nil)
+
+	_, err := holdService.PlaceHold(dto.HoldCreateRequest{BookID: 2}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(constants.HoldExpired), closed.Status)
+	assert.NotNil(t, closed.ClosedAt)
+	// The next member in line gets the copy for a new pickup window
+	assert.Equal(t, string(constants.HoldReady), next.Status)
+	assert.Equal(t, &copyID, next.CopyID)
+	assert.WithinDuration(t, time.Now().Add(testCirculation.HoldPickupWindow), *next.ExpiresAt, time.Minute)
+	assert.Equal(t, string(constants.CopyOnHold), bookCopy.Status)
+	holdRepo.AssertExpectations(t)
+	copyRepo.AssertExpectations(t)
+}
+
+func TestCancelHold_ReadyHoldPassesCopyOn(t *testing.T) {
+	holdService, holdRepo, _, _, copyRepo := newTestHoldService()
+
+	copyID := uint(5)
+	hold := &models.Hold{UserID: 1, BookID: 2, Status: string(constants.HoldReady), CopyID: &copyID}
+	hold.ID = 6
+	bookCopy := &models.BookCopy{BookID: 2, Status: string(constants.CopyOnHold)}
+	bookCopy.ID = copyID
+	// The first in line was cancelled in the meantime, so it goes to the second
+	gone := &models.Hold{UserID: 3, BookID: 2, Status: string(constants.HoldPending)}
+	gone.ID = 7
+	next := &models.Hold{UserID: 4, BookID: 2, Status: string(constants.HoldPending)}
+	next.ID = 8
+	tx := &gorm.DB{}
+
+	holdRepo.On("GetByID", uint(6)).Return(hold, nil)
+	holdRepo.On("BeginTransaction").Return(tx, holdRepo)
+	holdRepo.On("UpdateStatus", hold, constants.HoldReady).Return(nil)
+	copyRepo.On("WithTx", tx).Return(copyRepo)
+	copyRepo.On("GetByID", copyID).Return(bookCopy, nil)
+	holdRepo.On("GetNextPending", uint(2)).Return(gone, nil).Once()
+	holdRepo.On("UpdateStatus", gone, constants.HoldPending).Return(constants.ErrHoldNotActive)
+	holdRepo.On("GetNextPending", uint(2)).Return(next, nil).Once()
+	holdRepo.On("UpdateStatus", next, constants.HoldPending).Return(nil)
+	copyRepo.On("UpdateStatus", bookCopy, constants.CopyOnHold).Return(nil)
+	holdRepo.On("CommitTransaction", tx).Return(nil)
+
+	err := holdService.CancelHold(6, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(constants.HoldCancelled), hold.Status)
+	assert.NotNil(t, hold.ClosedAt)
+	assert.Equal(t, string(constants.HoldReady), next.Status)
+	assert.Equal(t, &copyID, next.CopyID)
+	assert.Equal(t, string(constants.CopyOnHold), bookCopy.Status)
+	holdRepo.AssertExpectations(t)
+	copyRepo.AssertExpectations(t)
+}
+
+func TestCancelHold_ReadyHoldFreesCopy(t *testing.T) {
+	holdService, holdRepo, _, _, copyRepo := newTestHoldService()
+
+	copyID := uint(5)
+	hold := &models.Hold{UserID: 1, BookID: 2, Status: string(constants.HoldReady), CopyID: &copyID}
+	hold.ID = 6
+	bookCopy := &models.BookCopy{BookID: 2, Status: string(constants.CopyOnHold)}
+	bookCopy.ID = copyID
+	tx := &gorm.DB{}
+
+	holdRepo.On("GetByID", uint(6)).Return(hold, nil)
+	holdRepo.On("BeginTransaction").Return(tx, holdRepo)
+	holdRepo.On("UpdateStatus", hold, constants.HoldReady).Return(nil)
+	copyRepo.On("WithTx", tx).Return(copyRepo)
+	copyRepo.On("GetByID", copyID).Return(bookCopy, nil)
+	holdRepo.On("GetNextPending", uint(2)).Return(nil, constants.ErrHoldNotFound)
+	copyRepo.On("UpdateStatus", bookCopy, constants.CopyOnHold).Return(nil)
+	holdRepo.On("CommitTransaction", tx).Return(nil)
+
+	err := holdService.CancelHold(6, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(constants.HoldCancelled), hold.Status)
+	assert.Equal(t, string(constants.CopyAvailable), bookCopy.Status)
+	holdRepo.AssertExpectations(t)
+	copyRepo.AssertExpectations(t)
+}
+
+func TestCancelHold_Refused(t *testing.T) {
+	testCases := []struct {
+		name        string
+		hold        *models.Hold
+		expectedErr error
+	}{
+		{name: "Someone else's hold", hold: &models.Hold{UserID: 3, Status: string(constants.HoldPending)}, expectedErr: constants.ErrHoldNotFound},
+		{name: "Already closed", hold: &models.Hold{UserID: 1, Status: string(constants.HoldCancelled)}, expectedErr: constants.ErrHoldNotActive},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			holdService, holdRepo, _, _, _ := newTestHoldService()
+
+			holdRepo.On("GetByID", uint(6)).Return(tc.hold, nil)
+
+			err := holdService.CancelHold(6, 1)
+
+			assert.Equal(t, tc.expectedErr, err)
+			holdRepo.AssertNotCalled(t, "BeginTransaction")
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// tokenInstructions tells the user how to use a token sent to them: as a
+// link to the frontend page when there is one, or else as a code
+func tokenInstructions(pageURL, token, action string) string {
+	if pageURL != "" {
+		return fmt.Sprintf("Open this link to %s:\n\n%s?token=%s", action, pageURL, url.QueryEscape(token))
+	}
+	return fmt.Sprintf("Use this code to %s:\n\n%s", action, token)
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"library-management/config"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/notify"
+	"library-management/internal/repository"
+	"library-management/internal/utils/auth"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+type PasswordResetServiceInterface interface {
+	ForgotPassword(req dto.ForgotPasswordRequest) error
+	ResetPassword(req dto.ResetPasswordRequest) error
+}
+
+type PasswordResetService struct {
+	UserRepo  repository.UserRepositoryInterface
+	ResetRepo repository.PasswordResetRepositoryInterface
+	Notifier  notify.Notifier
+	Config    config.PasswordResetConfig
+
+	pending sync.WaitGroup
+}
+
+func NewPasswordResetService(userRepo repository.UserRepositoryInterface, resetRepo repository.PasswordResetRepositoryInterface, notifier notify.Notifier, resetConfig config.PasswordResetConfig) PasswordResetServiceInterface {
+	return &PasswordResetService{UserRepo: userRepo, ResetRepo: resetRepo, Notifier: notifier, Config: resetConfig}
+}
+
+// ForgotPassword emails a reset link to the account with the email. Whether
+// there is such an account is never told: the request is handled in the
+// background, so unknown emails, accounts that got too many links lately,
+// database errors and failed deliveries all answer at once like a sent link.
+func (s *PasswordResetService) ForgotPassword(req dto.ForgotPasswordRequest) error {
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		s.sendResetLink(strings.ToLower(req.Email))
+	}()
+	return nil
+}
+
+// Wait blocks until the reset requests still being handled are done
+func (s *PasswordResetService) Wait() {
+	s.pending.Wait()
+}
+
+// sendResetLink does the work of ForgotPassword. Errors are only logged,
+// since the client was already answered.
+func (s *PasswordResetService) sendResetLink(email string) {
+	user, err := s.UserRepo.GetByEmail(email, []string{"id", "email"})
+	if err != nil {
+		if !errors.Is(err, constants.ErrUserNotFound) {
+			log.Printf("⚠️ Failed to look up the user asking for a password reset: %v", err)
+		}
+		return
+	}
+
+	sent, err := s.ResetRepo.CountSince(user.ID, time.Now().Add(-time.Hour))
+	if err != nil {
+		log.Printf("⚠️ Failed to count the password reset emails of user %d: %v", user.ID, err)
+		return
+	}
+	if sent >= int64(s.Config.MaxEmailsPerHour) {
+		return
+	}
+
+	// Only the latest link works
+	if err := s.ResetRepo.InvalidateForUser(user.ID); err != nil {
+		log.Printf("⚠️ Failed to invalidate the password reset links of user %d: %v", user.ID, err)
+		return
+	}
+	token, err := auth.NewRandomToken()
+	if err != nil {
+		log.Printf("⚠️ Failed to generate a password reset token for user %d: %v", user.ID, err)
+		return
+	}
+	err = s.ResetRepo.Create(&models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: time.Now().Add(s.Config.TokenTTL),
+	})
+	if err != nil {
+		log.Printf("⚠️ Failed to store the password reset token of user %d: %v", user.ID, err)
+		return
+	}
+
+	if err := s.Notifier.Send(s.resetMessage(user.Email, token)); err != nil {
+		log.Printf("⚠️ Failed to send the password reset email to user %d: %v", user.ID, err)
+	}
+}
+
+// ResetPassword sets a new password with the token of a reset link. The
+// token stops working, and so do the user's sessions.
+func (s *PasswordResetService) ResetPassword(req dto.ResetPasswordRequest) error {
+	token, err := s.ResetRepo.GetByHash(auth.HashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	if token.UsedAt != nil || !time.Now().Before(token.ExpiresAt) {
+		return constants.ErrInvalidResetToken
+	}
+
+	hashedPassword, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+	user := &models.User{Password: hashedPassword}
+	user.ID = token.UserID
+
+	// The token is only spent if the password is changed
+	tx, resetRepo := s.ResetRepo.BeginTransaction()
+	if err := resetRepo.Use(token); err != nil {
+		resetRepo.RollbackTransaction(tx)
+		return err
+	}
+	userRepo := s.UserRepo.WithTx(tx)
+	if err := userRepo.Update(user); err != nil {
+		resetRepo.RollbackTransaction(tx)
+		return err
+	}
+	if err := userRepo.IncrementTokenVersion(token.UserID); err != nil {
+		resetRepo.RollbackTransaction(tx)
+		return err
+	}
+	return resetRepo.CommitTransaction(tx)
+}
+
+// resetMessage writes the email carrying a reset token
+func (s *PasswordResetService) resetMessage(email, token string) notify.Message {
+	instructions := tokenInstructions(s.Config.URL, token, "choose a new password")
+	return notify.Message{
+		To:      email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Someone asked to reset the password of your library account.\n\n%s\n\nIt works once, for the next %d minutes. If you didn't ask for it, you can ignore this email.",
+			instructions, int(s.Config.TokenTTL.Minutes())),
+	}
+}
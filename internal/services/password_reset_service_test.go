@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"errors"
+	"library-management/config"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/notify"
+	"library-management/internal/services"
+	"library-management/internal/utils/auth"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var testResetConfig = config.PasswordResetConfig{
+	TokenTTL:         time.Hour,
+	MaxEmailsPerHour: 3,
+	URL:              "https://library.example.com/reset-password",
+}
+
+// fakeNotifier keeps the messages sent through it
+type fakeNotifier struct {
+	sent []notify.Message
+	err  error
+}
+
+func (n *fakeNotifier) Send(msg notify.Message) error {
+	n.sent = append(n.sent, msg)
+	return n.err
+}
+
+func TestForgotPassword_SendsLink(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	resetRepo := new(mocks.PasswordResetRepositoryInterface)
+	notifier := &fakeNotifier{}
+	resetService := services.NewPasswordResetService(userRepo, resetRepo, notifier, testResetConfig)
+
+	user := &models.User{Email: "test@example.com"}
+	user.ID = 1
+	var stored *models.PasswordResetToken
+	userRepo.On("GetByEmail", "test@example.com", mock.Anything).Return(user, nil)
+	resetRepo.On("CountSince", uint(1), mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+	resetRepo.On("InvalidateForUser", uint(1)).Return(nil)
+	resetRepo.On("Create", mock.AnythingOfType("*models.PasswordResetToken")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*models.PasswordResetToken) }).Return(nil)
+
+	err := resetService.ForgotPassword(dto.ForgotPasswordRequest{Email: "Test@Example.com"})
+	resetService.(*services.PasswordResetService).Wait()
+
+	assert.NoError(t, err)
+	assert.Len(t, notifier.sent, 1)
+	assert.Equal(t, "test@example.com", notifier.sent[0].To)
+
+	// The link carries the token, and only its hash is stored
+	_, token, found := strings.Cut(notifier.sent[0].Body, testResetConfig.URL+"?token=")
+	assert.True(t, found)
+	token = strings.Fields(token)[0]
+	assert.Equal(t, auth.HashToken(token), stored.TokenHash)
+	resetRepo.AssertExpectations(t)
+}
+
+func TestForgotPassword_RevealsNothing(t *testing.T) {
+	testCases := []struct {
+		name      string
+		email     string
+		sent      int64
+		countErr  error
+		notifyErr error
+	}{
+		{name: "Unknown email", email: "nobody@example.com"},
+		{name: "Too many emails", email: "test@example.com", sent: 3},
+		{name: "Database error", email: "test@example.com", countErr: errors.New("connection refused")},
+		{name: "Delivery failed", email: "test@example.com", notifyErr: errors.New("mail server down")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo := new(mocks.UserRepositoryInterface)
+			resetRepo := new(mocks.PasswordResetRepositoryInterface)
+			resetService := services.NewPasswordResetService(userRepo, resetRepo, &fakeNotifier{err: tc.notifyErr}, testResetConfig)
+
+			user := &models.User{Email: "test@example.com"}
+			user.ID = 1
+			userRepo.On("GetByEmail", "test@example.com", mock.Anything).Return(user, nil).Maybe()
+			userRepo.On("GetByEmail", "nobody@example.com", mock.Anything).Return(nil, constants.ErrUserNotFound).Maybe()
+			resetRepo.On("CountSince", uint(1), mock.AnythingOfType("time.Time")).Return(tc.sent, tc.countErr).Maybe()
+			resetRepo.On("InvalidateForUser", uint(1)).Return(nil).Maybe()
+			resetRepo.On("Create", mock.Anything).Return(nil).Maybe()
+
+			err := resetService.ForgotPassword(dto.ForgotPasswordRequest{Email: tc.email})
+			resetService.(*services.PasswordResetService).Wait()
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	resetRepo := new(mocks.PasswordResetRepositoryInterface)
+	resetService := services.NewPasswordResetService(userRepo, resetRepo, &fakeNotifier{}, testResetConfig)
+
+	token := &models.PasswordResetToken{ID: 4, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	tx := &gorm.DB{}
+	var updated *models.User
+	resetRepo.On("GetByHash", auth.HashToken("reset-token")).Return(token, nil)
+	resetRepo.On("BeginTransaction").Return(tx, resetRepo)
+	resetRepo.On("Use", token).Return(nil)
+	userRepo.On("WithTx", tx).Return(userRepo)
+	userRepo.On("Update", mock.AnythingOfType("*models.User")).
+		Run(func(args mock.Arguments) { updated = args.Get(0).(*models.User) }).Return(nil)
+	userRepo.On("IncrementTokenVersion", uint(1)).Return(nil)
+	resetRepo.On("CommitTransaction", tx).Return(nil)
+
+	err := resetService.ResetPassword(dto.ResetPasswordRequest{Token: "reset-token", NewPassword: "Bb12789@"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), updated.ID)
+	assert.True(t, auth.CheckPasswordHash("Bb12789@", updated.Password))
+	userRepo.AssertExpectations(t)
+	resetRepo.AssertExpectations(t)
+}
+
+func TestResetPassword_UpdateFailed(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	resetRepo := new(mocks.PasswordResetRepositoryInterface)
+	resetService := services.NewPasswordResetService(userRepo, resetRepo, &fakeNotifier{}, testResetConfig)
+
+	token := &models.PasswordResetToken{ID: 4, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	tx := &gorm.DB{}
+	resetRepo.On("GetByHash", auth.HashToken("reset-token")).Return(token, nil)
+	resetRepo.On("BeginTransaction").Return(tx, resetRepo)
+	resetRepo.On("Use", token).Return(nil)
+	userRepo.On("WithTx", tx).Return(userRepo)
+	userRepo.On("Update", mock.AnythingOfType("*models.User")).Return(errors.New("connection lost"))
+	resetRepo.On("RollbackTransaction", tx).Return()
+
+	err := resetService.ResetPassword(dto.ResetPasswordRequest{Token: "reset-token", NewPassword: "Bb12789@"})
+
+	// Using the token is rolled back with the update, so the link still works
+	assert.Error(t, err)
+	resetRepo.AssertCalled(t, "RollbackTransaction", tx)
+	resetRepo.AssertNotCalled(t, "CommitTransaction", mock.Anything)
+	userRepo.AssertNotCalled(t, "IncrementTokenVersion", mock.Anything)
+}
+
+func TestResetPassword_Refused(t *testing.T) {
+	usedAt := time.Now().Add(-time.Minute)
+	testCases := []struct {
+		name  string
+		token *models.PasswordResetToken
+	}{
+		{name: "Used", token: &models.PasswordResetToken{ID: 4, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}},
+		{name: "Expired", token: &models.PasswordResetToken{ID: 4, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo := new(mocks.UserRepositoryInterface)
+			resetRepo := new(mocks.PasswordResetRepositoryInterface)
+			resetService := services.NewPasswordResetService(userRepo, resetRepo, &fakeNotifier{}, testResetConfig)
+
+			resetRepo.On("GetByHash", auth.HashToken("reset-token")).Return(tc.token, nil)
+
+			err := resetService.ResetPassword(dto.ResetPasswordRequest{Token: "reset-token", NewPassword: "Bb12789@"})
+
+			assert.ErrorIs(t, err, constants.ErrInvalidResetToken)
+			userRepo.AssertNotCalled(t, "Update", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"fmt"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/repository"
+	"library-management/internal/utils/mappers"
+	"strings"
+)
+
+type RoleServiceInterface interface {
+	CreateRole(req dto.RoleCreateRequest) (dto.RoleResponse, error)
+	GetRole(id uint) (dto.RoleResponse, error)
+	GetAllRoles() ([]dto.RoleResponse, error)
+	UpdateRole(id uint, req dto.RoleUpdateRequest) (dto.RoleResponse, error)
+	DeleteRole(id uint) error
+	ListPermissions() []dto.PermissionResponse
+	HasPermission(role string, permission constants.Permission) (bool, error)
+}
+
+type RoleService struct {
+	Repo repository.RoleRepositoryInterface
+}
+
+func NewRoleService(repo repository.RoleRepositoryInterface) RoleServiceInterface {
+	return &RoleService{Repo: repo}
+}
+
+// Create Role with the permissions it grants
+func (s *RoleService) CreateRole(req dto.RoleCreateRequest) (dto.RoleResponse, error) {
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if name == "" {
+		return dto.RoleResponse{}, constants.ErrInvalidInput
+	}
+
+	permissions, err := mapRolePermissions(req.Permissions)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	role := &models.Role{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+	}
+	if err := s.Repo.Create(role); err != nil {
+		return dto.RoleResponse{}, err
+	}
+	return mappers.MapRoleToResponse(role), nil
+}
+
+// Get Role by ID
+func (s *RoleService) GetRole(id uint) (dto.RoleResponse, error) {
+	role, err := s.Repo.GetByID(id)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+	return mappers.MapRoleToResponse(role), nil
+}
+
+// Get All Roles
+func (s *RoleService) GetAllRoles() ([]dto.RoleResponse, error) {
+	roles, err := s.Repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	roleResponses := make([]dto.RoleResponse, len(roles))
+	for i := range roles {
+		roleResponses[i] = mappers.MapRoleToResponse(&roles[i])
+	}
+	return roleResponses, nil
+}
+
+// Update the description and permissions of a role. Its name can't change,
+// since users refer to roles by name.
+func (s *RoleService) UpdateRole(id uint, req dto.RoleUpdateRequest) (dto.RoleResponse, error) {
+	role, err := s.Repo.GetByID(id)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	permissions, err := mapRolePermissions(req.Permissions)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+	// Admin is granted everything by definition, so it has nothing to edit
+	if role.Name == string(constants.Admin) && len(permissions) > 0 {
+		return dto.RoleResponse{}, constants.ErrAdminPermissions
+	}
+
+	if req.Description != nil {
+		role.Description = strings.TrimSpace(*req.Description)
+	}
+	role.Permissions = permissions
+	if err := s.Repo.Update(role); err != nil {
+		return dto.RoleResponse{}, err
+	}
+	return mappers.MapRoleToResponse(role), nil
+}
+
+// Delete a role no user has
+func (s *RoleService) DeleteRole(id uint) error {
+	role, err := s.Repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if constants.UserRole(role.Name).IsBuiltIn() {
+		return constants.ErrBuiltInRole
+	}
+
+	users, err := s.Repo.CountUsers(role.Name)
+	if err != nil {
+		return err
+	}
+	if users > 0 {
+		return constants.ErrRoleInUse
+	}
+	return s.Repo.Delete(id)
+}
+
+// ListPermissions lists the permissions roles can grant
+func (s *RoleService) ListPermissions() []dto.PermissionResponse {
+	return mappers.MapPermissionsToResponse()
+}
+
+// HasPermission reports whether a role grants a permission. Admin has
+// every permission.
+func (s *RoleService) HasPermission(role string, permission constants.Permission) (bool, error) {
+	if role == string(constants.Admin) {
+		return true, nil
+	}
+	return s.Repo.HasPermission(role, permission)
+}
+
+// mapRolePermissions checks the permissions are known and drops repeats
+func mapRolePermissions(names []string) ([]models.RolePermission, error) {
+	permissions := make([]models.RolePermission, 0, len(names))
+	seen := make(map[constants.Permission]bool, len(names))
+	for _, name := range names {
+		permission := constants.Permission(strings.TrimSpace(name))
+		if !permission.IsValid() {
+			return nil, fmt.Errorf("%w %q", constants.ErrUnknownPermission, name)
+		}
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+		permissions = append(permissions, models.RolePermission{Permission: string(permission)})
+	}
+	return permissions, nil
+}
@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateRole(t *testing.T) {
+	mockRepo := new(mocks.RoleRepositoryInterface)
+	roleService := services.NewRoleService(mockRepo)
+
+	mockRepo.On("Create", mock.AnythingOfType("*models.Role")).Return(nil)
+
+	response, err := roleService.CreateRole(dto.RoleCreateRequest{
+		Name:        " Volunteer ",
+		Permissions: []string{"loans:checkin", "copies:read", "loans:checkin"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "volunteer", response.Name)
+	assert.False(t, response.BuiltIn)
+	assert.Equal(t, []string{"copies:read", "loans:checkin"}, response.Permissions)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateRole_UnknownPermission(t *testing.T) {
+	mockRepo := new(mocks.RoleRepositoryInterface)
+	roleService := services.NewRoleService(mockRepo)
+
+	_, err := roleService.CreateRole(dto.RoleCreateRequest{Name: "volunteer", Permissions: []string{"books:burn"}})
+
+	assert.ErrorIs(t, err, constants.ErrUnknownPermission)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdateRole_AdminPermissions(t *testing.T) {
+	mockRepo := new(mocks.RoleRepositoryInterface)
+	roleService := services.NewRoleService(mockRepo)
+
+	admin := &models.Role{Name: string(constants.Admin)}
+	admin.ID = 1
+	mockRepo.On("GetByID", uint(1)).Return(admin, nil)
+
+	_, err := roleService.UpdateRole(1, dto.RoleUpdateRequest{Permissions: []string{"books:write"}})
+
+	assert.ErrorIs(t, err, constants.ErrAdminPermissions)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestDeleteRole_Refused(t *testing.T) {
+	testCases := []struct {
+		name        string
+		role        string
+		users       int64
+		expectedErr error
+	}{
+		{name: "Built-in role", role: string(constants.Member), expectedErr: constants.ErrBuiltInRole},
+		{name: "Role in use", role: "librarian", users: 2, expectedErr: constants.ErrRoleInUse},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.RoleRepositoryInterface)
+			roleService := services.NewRoleService(mockRepo)
+
+			mockRepo.On("GetByID", uint(3)).Return(&models.Role{Name: tc.role}, nil)
+			mockRepo.On("CountUsers", tc.role).Return(tc.users, nil).Maybe()
+
+			err := roleService.DeleteRole(3)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+		})
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	mockRepo := new(mocks.RoleRepositoryInterface)
+	roleService := services.NewRoleService(mockRepo)
+
+	mockRepo.On("HasPermission", "librarian", constants.PermLoansCheckin).Return(true, nil)
+	mockRepo.On("HasPermission", "librarian", constants.PermUsersWrite).Return(false, nil)
+
+	// Admin has every permission without asking the database
+	allowed, err := roleService.HasPermission(string(constants.Admin), constants.PermRolesManage)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = roleService.HasPermission("librarian", constants.PermLoansCheckin)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = roleService.HasPermission("librarian", constants.PermUsersWrite)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	mockRepo.AssertExpectations(t)
+}
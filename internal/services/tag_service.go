@@ -0,0 +1,117 @@
+package services
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/repository"
+	"library-management/internal/utils/mappers"
+	"slices"
+	"strings"
+)
+
+type TagServiceInterface interface {
+	CreateTag(req dto.TagRequest) (dto.TagResponse, error)
+	GetAllTags(name string, page, limit int) ([]dto.TagResponse, int64, error)
+	UpdateTag(id uint, req dto.TagRequest) (dto.TagResponse, error)
+	MergeTags(req dto.TagMergeRequest) (dto.TagResponse, error)
+	DeleteTag(id uint) error
+}
+
+type TagService struct {
+	Repo repository.TagRepositoryInterface
+}
+
+func NewTagService(repo repository.TagRepositoryInterface) TagServiceInterface {
+	return &TagService{Repo: repo}
+}
+
+// Create Tag
+func (s *TagService) CreateTag(req dto.TagRequest) (dto.TagResponse, error) {
+	name := strings.Join(strings.Fields(req.Name), " ")
+	if name == "" {
+		return dto.TagResponse{}, constants.ErrInvalidInput
+	}
+
+	// Check if the tag already exists
+	existingTag, _ := s.Repo.GetByName(name)
+	if existingTag != nil {
+		return dto.TagResponse{}, constants.ErrTagExists
+	}
+
+	tag, err := s.Repo.Create(&models.Tag{Name: name})
+	if err != nil {
+		return dto.TagResponse{}, err
+	}
+	return mappers.MapTagToResponse(tag), nil
+}
+
+// Get All Tags with their book counts, optionally only those whose name contains the given one
+func (s *TagService) GetAllTags(name string, page, limit int) ([]dto.TagResponse, int64, error) {
+	tags, total, err := s.Repo.GetAll(strings.TrimSpace(name), page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tagResponses := make([]dto.TagResponse, len(tags))
+	for i, tag := range tags {
+		tagResponses[i] = mappers.MapTagToResponse(&tag)
+		tagResponses[i].BookCount = &tags[i].BookCount
+	}
+	return tagResponses, total, nil
+}
+
+// Rename Tag
+func (s *TagService) UpdateTag(id uint, req dto.TagRequest) (dto.TagResponse, error) {
+	tag, err := s.Repo.GetByID(id)
+	if err != nil {
+		return dto.TagResponse{}, err
+	}
+
+	tag.Name = strings.Join(strings.Fields(req.Name), " ")
+	if tag.Name == "" {
+		return dto.TagResponse{}, constants.ErrInvalidInput
+	}
+
+	// Check if the name is already used by another tag
+	existingTag, _ := s.Repo.GetByName(tag.Name)
+	if existingTag != nil && existingTag.ID != id {
+		return dto.TagResponse{}, constants.ErrTagExists
+	}
+
+	if err := s.Repo.Update(tag); err != nil {
+		return dto.TagResponse{}, err
+	}
+	return mappers.MapTagToResponse(tag), nil
+}
+
+// MergeTags folds duplicate tags into one: their books get the target tag
+// instead, and the duplicates are deleted.
+func (s *TagService) MergeTags(req dto.TagMergeRequest) (dto.TagResponse, error) {
+	if slices.Contains(req.SourceIDs, req.TargetID) {
+		return dto.TagResponse{}, constants.ErrMergeIntoItself
+	}
+
+	target, err := s.Repo.GetByID(req.TargetID)
+	if err != nil {
+		return dto.TagResponse{}, err
+	}
+	for _, sourceID := range req.SourceIDs {
+		if _, err := s.Repo.GetByID(sourceID); err != nil {
+			return dto.TagResponse{}, err
+		}
+	}
+
+	if err := s.Repo.Merge(req.SourceIDs, req.TargetID); err != nil {
+		return dto.TagResponse{}, err
+	}
+	return mappers.MapTagToResponse(target), nil
+}
+
+// Delete Tag, removing it from its books
+func (s *TagService) DeleteTag(id uint) error {
+	if _, err := s.Repo.GetByID(id); err != nil {
+		return err
+	}
+	return s.Repo.Delete(id)
+}
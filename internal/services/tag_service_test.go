@@ -0,0 +1,65 @@
+package services_test
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMergeTags(t *testing.T) {
+	mockRepo := new(mocks.TagRepositoryInterface)
+	tagService := services.NewTagService(mockRepo)
+
+	target := &models.Tag{Name: "sci-fi"}
+	target.ID = 2
+	mockRepo.On("GetByID", uint(2)).Return(target, nil)
+	mockRepo.On("GetByID", uint(3)).Return(&models.Tag{Name: "scifi"}, nil)
+	mockRepo.On("GetByID", uint(4)).Return(&models.Tag{Name: "Science Fiction"}, nil)
+	mockRepo.On("Merge", []uint{3, 4}, uint(2)).Return(nil)
+
+	response, err := tagService.MergeTags(dto.TagMergeRequest{SourceIDs: []uint{3, 4}, TargetID: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.TagResponse{ID: 2, Name: "sci-fi"}, response)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMergeTags_Refused(t *testing.T) {
+	testCases := []struct {
+		name        string
+		req         dto.TagMergeRequest
+		expectedErr error
+	}{
+		{
+			name:        "Into itself",
+			req:         dto.TagMergeRequest{SourceIDs: []uint{3, 2}, TargetID: 2},
+			expectedErr: constants.ErrMergeIntoItself,
+		},
+		{
+			name:        "Unknown source",
+			req:         dto.TagMergeRequest{SourceIDs: []uint{9}, TargetID: 2},
+			expectedErr: constants.ErrTagNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.TagRepositoryInterface)
+			tagService := services.NewTagService(mockRepo)
+
+			mockRepo.On("GetByID", uint(2)).Return(&models.Tag{Name: "sci-fi"}, nil).Maybe()
+			mockRepo.On("GetByID", uint(9)).Return(nil, constants.ErrTagNotFound).Maybe()
+
+			_, err := tagService.MergeTags(tc.req)
+
+			assert.Equal(t, tc.expectedErr, err)
+			mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+		})
+	}
+}
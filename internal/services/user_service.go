@@ -1,33 +1,50 @@
 package services
 
 import (
+	"errors"
 	"library-management/internal/constants"
 	"library-management/internal/dto"
+	"library-management/internal/models"
 	"library-management/internal/repository"
 	"library-management/internal/utils/auth"
 	"library-management/internal/utils/mappers"
+	"log"
 	"strings"
+	"time"
 )
 
 type UserServiceInterface interface {
-	CreateUser(req dto.UserCreateRequest) (dto.UserResponse, error)
+	CreateUser(req dto.UserCreateRequest, actorRole string) (dto.UserResponse, error)
 	GetUser(id uint, fields []string) (dto.UserResponse, error)
+	GetUserByEmail(email string) (dto.UserResponse, error)
 	GetAllUsers(page, limit int, fields []string) ([]dto.UserResponse, int64, error)
-	UpdateUser(id uint, req dto.UserUpdateRequest) (dto.UserResponse, error)
-	DeleteUser(id uint) error
+	UpdateUser(id uint, req dto.UserUpdateRequest, actorRole string) (dto.UserResponse, error)
+	DeleteUser(id uint, actorRole string) error
+	UpdateProfile(id uint, req dto.ProfileUpdateRequest) (dto.UserResponse, error)
+	ChangePassword(id uint, req dto.PasswordChangeRequest) error
+	DeleteAccount(id uint) error
+	UnlockUser(id, staffID uint, actorRole, clientIP string) (dto.UserResponse, error)
 }
 
 type UserService struct {
-	Repo repository.UserRepositoryInterface
+	Repo         repository.UserRepositoryInterface
+	RoleRepo     repository.RoleRepositoryInterface
+	BorrowRepo   repository.BorrowRepositoryInterface
+	AuditRepo    repository.AuditRepositoryInterface
+	Verification EmailVerificationServiceInterface
 }
 
-func NewUserService(repo repository.UserRepositoryInterface) UserServiceInterface {
-	return &UserService{Repo: repo}
+func NewUserService(repo repository.UserRepositoryInterface, roleRepo repository.RoleRepositoryInterface, borrowRepo repository.BorrowRepositoryInterface, auditRepo repository.AuditRepositoryInterface, verification EmailVerificationServiceInterface) UserServiceInterface {
+	return &UserService{Repo: repo, RoleRepo: roleRepo, BorrowRepo: borrowRepo, AuditRepo: auditRepo, Verification: verification}
 }
 
-// Create User (with hashed password)
-func (s *UserService) CreateUser(req dto.UserCreateRequest) (dto.UserResponse, error) {
+// Create User (with hashed password). Staff vouch for the email of the
+// users they create, so it counts as verified. Only staff who can assign
+// roles can create users with a role other than member.
+func (s *UserService) CreateUser(req dto.UserCreateRequest, actorRole string) (dto.UserResponse, error) {
 	user := mappers.MapCreateRequestToUser(req)
+	now := time.Now()
+	user.EmailVerifiedAt = &now
 
 	// Convert email to lowercase
 	user.Email = strings.ToLower(user.Email)
@@ -38,6 +55,13 @@ func (s *UserService) CreateUser(req dto.UserCreateRequest) (dto.UserResponse, e
 		return dto.UserResponse{}, constants.ErrEmailTaken
 	}
 
+	// Users can only be given a defined role
+	if user.Role != "" && user.Role != string(constants.Member) {
+		if err := s.checkRoleAssignment(user.Role, actorRole); err != nil {
+			return dto.UserResponse{}, err
+		}
+	}
+
 	// Hash password before saving
 	hashedPassword, err := auth.HashPassword(user.Password)
 	if err != nil {
@@ -65,6 +89,15 @@ func (s *UserService) GetUser(id uint, fields []string) (dto.UserResponse, error
 	return userResponse, nil
 }
 
+// Get User by email, ignoring case
+func (s *UserService) GetUserByEmail(email string) (dto.UserResponse, error) {
+	user, err := s.Repo.GetByEmail(strings.ToLower(email), []string{})
+	if err != nil {
+		return dto.UserResponse{}, constants.ErrUserNotFound
+	}
+	return mappers.MapUserToResponse(user), nil
+}
+
 // Get All Users
 func (s *UserService) GetAllUsers(page, limit int, fields []string) ([]dto.UserResponse, int64, error) {
 	// Fetch users from the repository
@@ -82,12 +115,43 @@ func (s *UserService) GetAllUsers(page, limit int, fields []string) ([]dto.UserR
 	return userResponses, total, nil
 }
 
-// Update User
-func (s *UserService) UpdateUser(id uint, req dto.UserUpdateRequest) (dto.UserResponse, error) {
+// Update User. Only staff who can assign roles can change the role, or
+// change admins and role managers at all.
+func (s *UserService) UpdateUser(id uint, req dto.UserUpdateRequest, actorRole string) (dto.UserResponse, error) {
+	user, err := s.Repo.GetByID(id, []string{})
+	if err != nil {
+		return dto.UserResponse{}, constants.ErrUserNotFound
+	}
+	if err := s.checkPrivilegedUser(user.Role, actorRole); err != nil {
+		return dto.UserResponse{}, err
+	}
+	return s.updateUser(user, req, actorRole)
+}
+
+// updateOwnAccount saves changes the user makes to their own account
+func (s *UserService) updateOwnAccount(id uint, req dto.UserUpdateRequest) (dto.UserResponse, error) {
 	user, err := s.Repo.GetByID(id, []string{})
 	if err != nil {
 		return dto.UserResponse{}, constants.ErrUserNotFound
 	}
+	// The role can't be changed this way
+	return s.updateUser(user, req, "")
+}
+
+// updateUser saves the changes to a user. A new email has to be verified
+// again, and is sent a link.
+func (s *UserService) updateUser(user *models.User, req dto.UserUpdateRequest, actorRole string) (dto.UserResponse, error) {
+	id := user.ID
+	emailChanged := req.Email != nil && !strings.EqualFold(*req.Email, user.Email)
+
+	// A new password or role ends the user's sessions
+	roleChanged := req.Role != nil && *req.Role != user.Role
+	revokeTokens := req.Password != nil || roleChanged
+	if roleChanged {
+		if err := s.checkRoleAssignment(*req.Role, actorRole); err != nil {
+			return dto.UserResponse{}, err
+		}
+	}
 
 	// Update user fields
 	mappers.UpdateUserFromDTO(user, req)
@@ -112,22 +176,170 @@ func (s *UserService) UpdateUser(id uint, req dto.UserUpdateRequest) (dto.UserRe
 		user.Password = hashedPassword
 	}
 
-	err = s.Repo.Update(user)
-	if err != nil {
+	if err := s.Repo.Update(user); err != nil {
 		return dto.UserResponse{}, err
 	}
+	if revokeTokens {
+		if err := s.Repo.IncrementTokenVersion(id); err != nil {
+			return dto.UserResponse{}, err
+		}
+	}
+	if emailChanged {
+		if err := s.Repo.SetEmailVerifiedAt(id, nil); err != nil {
+			return dto.UserResponse{}, err
+		}
+		user.EmailVerifiedAt = nil
+		if err := s.Verification.SendVerification(id); err != nil {
+			log.Printf("⚠️ Failed to send the verification email to user %d: %v", id, err)
+		}
+	}
 
 	// Map user to response DTO
 	userResponse := mappers.MapUserToResponse(user)
 	return userResponse, nil
 }
 
-// Delete User
-func (s *UserService) DeleteUser(id uint) error {
-	_, err := s.Repo.GetByID(id, []string{"id"})
+// checkRoleAssignment makes sure the actor can assign roles, which only
+// admins and holders of roles:manage can: anyone else could make
+// themselves admin. The role has to be defined.
+func (s *UserService) checkRoleAssignment(role, actorRole string) error {
+	canAssign, err := s.canManageRoles(actorRole)
+	if err != nil {
+		return err
+	}
+	if !canAssign {
+		return constants.ErrRoleAssignment
+	}
+	return s.checkRole(role)
+}
+
+// checkPrivilegedUser makes sure only admins and holders of roles:manage
+// change, delete or unlock users with the same power. Otherwise anyone
+// who can write users could set an admin's password and log in as them.
+func (s *UserService) checkPrivilegedUser(userRole, actorRole string) error {
+	canManage, err := s.canManageRoles(actorRole)
+	if err != nil || canManage {
+		return err
+	}
+	privileged, err := s.canManageRoles(userRole)
+	if err != nil {
+		return err
+	}
+	if privileged {
+		return constants.ErrPrivilegedUser
+	}
+	return nil
+}
+
+// canManageRoles reports whether a role is admin or grants roles:manage
+func (s *UserService) canManageRoles(role string) (bool, error) {
+	if role == string(constants.Admin) {
+		return true, nil
+	}
+	return s.RoleRepo.HasPermission(role, constants.PermRolesManage)
+}
+
+// checkRole makes sure a role is defined
+func (s *UserService) checkRole(role string) error {
+	_, err := s.RoleRepo.GetByName(role)
+	if errors.Is(err, constants.ErrRoleNotFound) {
+		return constants.ErrInvalidRole
+	}
+	return err
+}
+
+// Delete User. Only staff who can assign roles can delete admins and role
+// managers.
+func (s *UserService) DeleteUser(id uint, actorRole string) error {
+	user, err := s.Repo.GetByID(id, []string{"id", "role"})
 	if err != nil {
 		return constants.ErrUserNotFound
 	}
+	if err := s.checkPrivilegedUser(user.Role, actorRole); err != nil {
+		return err
+	}
 
 	return s.Repo.Delete(id)
 }
+
+// UpdateProfile changes the name or email of the user's own account. A new
+// email needs the current password, so a stolen session can't take the
+// account over, and has to be verified again. The role and password can't
+// be changed this way.
+func (s *UserService) UpdateProfile(id uint, req dto.ProfileUpdateRequest) (dto.UserResponse, error) {
+	user, err := s.Repo.GetByID(id, []string{"id", "email", "password"})
+	if err != nil {
+		return dto.UserResponse{}, constants.ErrUserNotFound
+	}
+
+	update := dto.UserUpdateRequest{Name: req.Name}
+	if req.Email != nil && !strings.EqualFold(*req.Email, user.Email) {
+		if !auth.CheckPasswordHash(req.CurrentPassword, user.Password) {
+			return dto.UserResponse{}, constants.ErrWrongPassword
+		}
+		update.Email = req.Email
+	}
+	if update.Name != nil && strings.TrimSpace(*update.Name) == "" {
+		return dto.UserResponse{}, constants.ErrInvalidInput
+	}
+	return s.updateOwnAccount(id, update)
+}
+
+// ChangePassword sets a new password for the user's own account once the
+// current one is confirmed. Like any password change, it ends every session.
+func (s *UserService) ChangePassword(id uint, req dto.PasswordChangeRequest) error {
+	user, err := s.Repo.GetByID(id, []string{"id", "password"})
+	if err != nil {
+		return constants.ErrUserNotFound
+	}
+	if !auth.CheckPasswordHash(req.CurrentPassword, user.Password) {
+		return constants.ErrWrongPassword
+	}
+
+	_, err = s.updateOwnAccount(id, dto.UserUpdateRequest{Password: &req.NewPassword})
+	return err
+}
+
+// DeleteAccount deletes the user's own account, unless they still have
+// books to bring back
+func (s *UserService) DeleteAccount(id uint) error {
+	activeLoans, err := s.BorrowRepo.CountActiveLoans(id)
+	if err != nil {
+		return err
+	}
+	if activeLoans > 0 {
+		return constants.ErrOpenLoans
+	}
+	if _, err := s.Repo.GetByID(id, []string{"id"}); err != nil {
+		return constants.ErrUserNotFound
+	}
+	return s.Repo.Delete(id)
+}
+
+// UnlockUser lifts the lockout of a user who had too many failed logins,
+// and records which staff member did it. Only staff who can assign roles
+// can unlock admins and role managers.
+func (s *UserService) UnlockUser(id, staffID uint, actorRole, clientIP string) (dto.UserResponse, error) {
+	user, err := s.Repo.GetByID(id, []string{})
+	if err != nil {
+		return dto.UserResponse{}, constants.ErrUserNotFound
+	}
+	if err := s.checkPrivilegedUser(user.Role, actorRole); err != nil {
+		return dto.UserResponse{}, err
+	}
+	if err := s.Repo.ClearFailedLogins(id); err != nil {
+		return dto.UserResponse{}, err
+	}
+	err = s.AuditRepo.Create(&models.AuditEntry{
+		Action:  string(constants.AuditAccountUnlocked),
+		UserID:  &user.ID,
+		ActorID: &staffID,
+		IP:      clientIP,
+	})
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	user.LockedUntil = nil
+	return mappers.MapUserToResponse(user), nil
+}
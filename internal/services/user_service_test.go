@@ -0,0 +1,282 @@
+package services_test
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/mocks"
+	"library-management/internal/models"
+	"library-management/internal/services"
+	"library-management/internal/utils/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestUserService() (services.UserServiceInterface, *mocks.UserRepositoryInterface, *mocks.BorrowRepositoryInterface, *mocks.EmailVerificationServiceInterface) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	borrowRepo := new(mocks.BorrowRepositoryInterface)
+	verification := new(mocks.EmailVerificationServiceInterface)
+	return services.NewUserService(userRepo, new(mocks.RoleRepositoryInterface), borrowRepo, new(mocks.AuditRepositoryInterface), verification), userRepo, borrowRepo, verification
+}
+
+func TestUpdateProfile_NewEmailNeedsPassword(t *testing.T) {
+	userService, userRepo, _, _ := newTestUserService()
+
+	hashedPassword, _ := auth.HashPassword("Aa12345@")
+	user := &models.User{Email: "test@example.com", Password: hashedPassword}
+	user.ID = 1
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+
+	email := "new@example.com"
+	_, err := userService.UpdateProfile(1, dto.ProfileUpdateRequest{Email: &email, CurrentPassword: "Bb12789@"})
+
+	assert.ErrorIs(t, err, constants.ErrWrongPassword)
+	userRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUpdateProfile_Name(t *testing.T) {
+	userService, userRepo, _, _ := newTestUserService()
+
+	user := &models.User{Name: "Test", Email: "test@example.com", Role: string(constants.Member)}
+	user.ID = 1
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	userRepo.On("GetByEmail", "test@example.com", []string{"id"}).Return(user, nil)
+	userRepo.On("Update", user).Return(nil)
+
+	// The same email in another case isn't a change, so no password is needed
+	name, email := "Test User", "Test@Example.com"
+	response, err := userService.UpdateProfile(1, dto.ProfileUpdateRequest{Name: &name, Email: &email})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Test User", response.Name)
+	assert.Equal(t, string(constants.Member), response.Role)
+	userRepo.AssertNotCalled(t, "IncrementTokenVersion", mock.Anything)
+}
+
+func TestUpdateProfile_NewEmailIsVerifiedAgain(t *testing.T) {
+	userService, userRepo, _, verification := newTestUserService()
+
+	hashedPassword, _ := auth.HashPassword("Aa12345@")
+	verifiedAt := time.Now().Add(-24 * time.Hour)
+	user := &models.User{Email: "test@example.com", Password: hashedPassword, EmailVerifiedAt: &verifiedAt}
+	user.ID = 1
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	userRepo.On("GetByEmail", "new@example.com", []string{"id"}).Return(nil, constants.ErrUserNotFound)
+	userRepo.On("Update", user).Return(nil)
+	userRepo.On("SetEmailVerifiedAt", uint(1), (*time.Time)(nil)).Return(nil)
+	verification.On("SendVerification", uint(1)).Return(nil)
+
+	email := "New@Example.com"
+	response, err := userService.UpdateProfile(1, dto.ProfileUpdateRequest{Email: &email, CurrentPassword: "Aa12345@"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", response.Email)
+	assert.Nil(t, response.EmailVerifiedAt)
+	userRepo.AssertExpectations(t)
+	verification.AssertExpectations(t)
+}
+
+func TestChangePassword(t *testing.T) {
+	userService, userRepo, _, _ := newTestUserService()
+
+	hashedPassword, _ := auth.HashPassword("Aa12345@")
+	user := &models.User{Email: "test@example.com", Password: hashedPassword}
+	user.ID = 1
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	userRepo.On("GetByEmail", "test@example.com", []string{"id"}).Return(user, nil)
+	userRepo.On("Update", user).Return(nil)
+	userRepo.On("IncrementTokenVersion", uint(1)).Return(nil)
+
+	err := userService.ChangePassword(1, dto.PasswordChangeRequest{CurrentPassword: "Aa12345@", NewPassword: "Bb12789@"})
+
+	assert.NoError(t, err)
+	assert.True(t, auth.CheckPasswordHash("Bb12789@", user.Password))
+	userRepo.AssertExpectations(t)
+}
+
+func TestChangePassword_WrongPassword(t *testing.T) {
+	userService, userRepo, _, _ := newTestUserService()
+
+	hashedPassword, _ := auth.HashPassword("Aa12345@")
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(&models.User{Password: hashedPassword}, nil)
+
+	err := userService.ChangePassword(1, dto.PasswordChangeRequest{CurrentPassword: "Bb12789@", NewPassword: "Cc12345@"})
+
+	assert.ErrorIs(t, err, constants.ErrWrongPassword)
+	userRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestDeleteAccount_OpenLoans(t *testing.T) {
+	userService, userRepo, borrowRepo, _ := newTestUserService()
+
+	borrowRepo.On("CountActiveLoans", uint(1)).Return(int64(2), nil)
+
+	err := userService.DeleteAccount(1)
+
+	assert.ErrorIs(t, err, constants.ErrOpenLoans)
+	userRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestUnlockUser(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	auditRepo := new(mocks.AuditRepositoryInterface)
+	userService := services.NewUserService(userRepo, new(mocks.RoleRepositoryInterface), new(mocks.BorrowRepositoryInterface), auditRepo, new(mocks.EmailVerificationServiceInterface))
+
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	user := &models.User{Email: "test@example.com", LockedUntil: &lockedUntil}
+	user.ID = 1
+	var entry *models.AuditEntry
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	userRepo.On("ClearFailedLogins", uint(1)).Return(nil)
+	auditRepo.On("Create", mock.AnythingOfType("*models.AuditEntry")).
+		Run(func(args mock.Arguments) { entry = args.Get(0).(*models.AuditEntry) }).Return(nil)
+
+	response, err := userService.UnlockUser(1, 2, string(constants.Admin), "10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Nil(t, response.LockedUntil)
+	userRepo.AssertExpectations(t)
+	assert.Equal(t, string(constants.AuditAccountUnlocked), entry.Action)
+	assert.Equal(t, uint(1), *entry.UserID)
+	assert.Equal(t, uint(2), *entry.ActorID)
+}
+
+func TestUpdateUser_RoleAssignment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		actorRole   string
+		canManage   bool
+		expectedErr error
+	}{
+		{name: "Admin", actorRole: string(constants.Admin)},
+		{name: "Role manager", actorRole: "supervisor", canManage: true},
+		{name: "Can only write users", actorRole: "librarian", expectedErr: constants.ErrRoleAssignment},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo := new(mocks.UserRepositoryInterface)
+			roleRepo := new(mocks.RoleRepositoryInterface)
+			userService := services.NewUserService(userRepo, roleRepo, new(mocks.BorrowRepositoryInterface), new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface))
+
+			user := &models.User{Email: "test@example.com", Role: string(constants.Member)}
+			user.ID = 1
+			userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+			roleRepo.On("HasPermission", tc.actorRole, constants.PermRolesManage).Return(tc.canManage, nil)
+			roleRepo.On("HasPermission", string(constants.Member), constants.PermRolesManage).Return(false, nil)
+			roleRepo.On("GetByName", string(constants.Admin)).Return(&models.Role{Name: string(constants.Admin)}, nil)
+			userRepo.On("GetByEmail", "test@example.com", []string{"id"}).Return(user, nil)
+			userRepo.On("Update", user).Return(nil)
+			userRepo.On("IncrementTokenVersion", uint(1)).Return(nil)
+
+			role := string(constants.Admin)
+			response, err := userService.UpdateUser(1, dto.UserUpdateRequest{Role: &role}, tc.actorRole)
+
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedErr != nil {
+				userRepo.AssertNotCalled(t, "Update", mock.Anything)
+				return
+			}
+			assert.Equal(t, string(constants.Admin), response.Role)
+		})
+	}
+}
+
+func TestCreateUser_RoleAssignment(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	roleRepo := new(mocks.RoleRepositoryInterface)
+	userService := services.NewUserService(userRepo, roleRepo, new(mocks.BorrowRepositoryInterface), new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface))
+
+	userRepo.On("GetByEmail", "new@example.com", []string{"id"}).Return(nil, constants.ErrUserNotFound)
+	roleRepo.On("HasPermission", "librarian", constants.PermRolesManage).Return(false, nil)
+
+	_, err := userService.CreateUser(dto.UserCreateRequest{Name: "New", Email: "new@example.com", Password: "Aa12345@", Role: "admin"}, "librarian")
+
+	assert.Equal(t, constants.ErrRoleAssignment, err)
+	userRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdateUser_PrivilegedUser(t *testing.T) {
+	testCases := []struct {
+		name      string
+		userRole  string
+		canManage bool
+	}{
+		{name: "Admin", userRole: string(constants.Admin)},
+		{name: "Role manager", userRole: "supervisor", canManage: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo := new(mocks.UserRepositoryInterface)
+			roleRepo := new(mocks.RoleRepositoryInterface)
+			userService := services.NewUserService(userRepo, roleRepo, new(mocks.BorrowRepositoryInterface), new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface))
+
+			user := &models.User{Email: "admin@example.com", Role: tc.userRole}
+			user.ID = 1
+			userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+			roleRepo.On("HasPermission", "librarian", constants.PermRolesManage).Return(false, nil)
+			roleRepo.On("HasPermission", "supervisor", constants.PermRolesManage).Return(tc.canManage, nil)
+
+			// Someone who can only write users can't take the account over
+			password := "Bb12789@"
+			_, err := userService.UpdateUser(1, dto.UserUpdateRequest{Password: &password}, "librarian")
+
+			assert.Equal(t, constants.ErrPrivilegedUser, err)
+			userRepo.AssertNotCalled(t, "Update", mock.Anything)
+		})
+	}
+}
+
+func TestDeleteUser_PrivilegedUser(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	roleRepo := new(mocks.RoleRepositoryInterface)
+	userService := services.NewUserService(userRepo, roleRepo, new(mocks.BorrowRepositoryInterface), new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface))
+
+	user := &models.User{Role: string(constants.Admin)}
+	user.ID = 1
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	roleRepo.On("HasPermission", "librarian", constants.PermRolesManage).Return(false, nil)
+
+	err := userService.DeleteUser(1, "librarian")
+
+	assert.Equal(t, constants.ErrPrivilegedUser, err)
+	userRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestDeleteUser_ByRoleManager(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	roleRepo := new(mocks.RoleRepositoryInterface)
+	userService := services.NewUserService(userRepo, roleRepo, new(mocks.BorrowRepositoryInterface), new(mocks.AuditRepositoryInterface), new(mocks.EmailVerificationServiceInterface))
+
+	user := &models.User{Role: string(constants.Admin)}
+	user.ID = 1
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	userRepo.On("Delete", uint(1)).Return(nil)
+	roleRepo.On("HasPermission", "supervisor", constants.PermRolesManage).Return(true, nil)
+
+	err := userService.DeleteUser(1, "supervisor")
+
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+}
+
+func TestUnlockUser_PrivilegedUser(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryInterface)
+	roleRepo := new(mocks.RoleRepositoryInterface)
+	auditRepo := new(mocks.AuditRepositoryInterface)
+	userService := services.NewUserService(userRepo, roleRepo, new(mocks.BorrowRepositoryInterface), auditRepo, new(mocks.EmailVerificationServiceInterface))
+
+	user := &models.User{Role: string(constants.Admin)}
+	user.ID = 1
+	userRepo.On("GetByID", uint(1), mock.Anything).Return(user, nil)
+	roleRepo.On("HasPermission", "librarian", constants.PermRolesManage).Return(false, nil)
+
+	_, err := userService.UnlockUser(1, 2, "librarian", "10.0.0.1")
+
+	assert.Equal(t, constants.ErrPrivilegedUser, err)
+	userRepo.AssertNotCalled(t, "ClearFailedLogins", mock.Anything)
+	auditRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
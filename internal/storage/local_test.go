@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"library-management/internal/constants"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := store.Put("covers/1/abc.jpg", strings.NewReader("first")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Put("covers/1/abc.jpg", strings.NewReader("second")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file, err := store.Open("covers/1/abc.jpg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := io.ReadAll(file)
+	file.Close()
+	if err != nil || string(content) != "second" {
+		t.Errorf("expected the file to be replaced, got %q (%v)", content, err)
+	}
+
+	if err := store.Delete("covers/1/abc.jpg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Open("covers/1/abc.jpg"); !errors.Is(err, constants.ErrFileNotFound) {
+		t.Errorf("expected %v, got %v", constants.ErrFileNotFound, err)
+	}
+	if err := store.Delete("covers/1/abc.jpg"); err != nil {
+		t.Errorf("expected deleting a missing file to succeed, got %v", err)
+	}
+}
+
+func TestLocalStorage_InvalidKeys(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "covers/../../outside", "covers//1", `covers\1`, ".."} {
+		if err := store.Put(key, strings.NewReader("x")); !errors.Is(err, constants.ErrInvalidStorageKey) {
+			t.Errorf("Put(%q): expected %v, got %v", key, constants.ErrInvalidStorageKey, err)
+		}
+		if _, err := store.Open(key); !errors.Is(err, constants.ErrInvalidStorageKey) {
+			t.Errorf("Open(%q): expected %v, got %v", key, constants.ErrInvalidStorageKey, err)
+		}
+	}
+}
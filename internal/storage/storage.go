@@ -0,0 +1,31 @@
+// Package storage keeps the files the catalog refers to, such as cover
+// images, outside the database.
+package storage
+
+import (
+	"io"
+	"library-management/internal/constants"
+	"path"
+	"strings"
+)
+
+// Storage stores files under keys like "covers/12/3f2a9c.jpg". A key is a
+// slash-separated path relative to the root of the storage.
+type Storage interface {
+	// Put stores the content of r under the key, replacing any file there
+	Put(key string, r io.Reader) error
+	// Open returns the file stored under the key, or ErrFileNotFound
+	Open(key string) (io.ReadSeekCloser, error)
+	// Delete removes the file stored under the key. Deleting a file that
+	// doesn't exist is not an error.
+	Delete(key string) error
+}
+
+// checkKey rejects keys that are empty, absolute or reach outside the
+// root of the storage
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return constants.ErrInvalidStorageKey
+	}
+	return nil
+}
@@ -12,20 +12,28 @@ import (
 type Claims struct {
 	UserID uint   `json:"userID"`
 	Role   string `json:"role"`
+	// The user's token version when the token was issued; bumping it revokes the token
+	TokenVersion int `json:"ver"`
 	jwt.RegisteredClaims
 }
 
-// Generate JWT Token
-func GenerateToken(userID uint, role string) (string, error) {
+// Generate JWT Token, valid for ttl
+func GenerateToken(userID uint, role string, tokenVersion int, ttl time.Duration) (string, error) {
 	jwtSecret := []byte(os.Getenv("SECRET_KEY")) // Fetch dynamically
 
-	expirationTime := time.Now().Add(24 * time.Hour) // Token expires in 24 hours
-
+	id, err := NewRandomToken()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
 	claims := &Claims{
-		UserID: userID,
-		Role:   role,
+		UserID:       userID,
+		Role:         role,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			ID:        id,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 		},
 	}
 
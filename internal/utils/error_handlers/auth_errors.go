@@ -13,8 +13,12 @@ func HandleAuthError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, constants.ErrEmailTaken):
 		handlers.RespondWithError(c, http.StatusConflict, err)
-	case errors.Is(err, constants.ErrInvalidCredentials):
+	case errors.Is(err, constants.ErrInvalidCredentials),
+		errors.Is(err, constants.ErrInvalidRefreshToken):
 		handlers.RespondWithError(c, http.StatusUnauthorized, err)
+	case errors.Is(err, constants.ErrInvalidResetToken),
+		errors.Is(err, constants.ErrInvalidVerificationToken):
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
 	default:
 		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
 	}
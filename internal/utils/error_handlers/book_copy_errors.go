@@ -0,0 +1,31 @@
+package error_handlers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/utils/handlers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleBookCopyError handles errors specific to the BookCopyHandler
+func HandleBookCopyError(c *gin.Context, err error) {
+	var validationErr *handlers.ValidationError
+	if errors.As(err, &validationErr) {
+		handlers.RespondWithError(c, http.StatusBadRequest, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, constants.ErrCopyNotFound),
+		errors.Is(err, constants.ErrBookNotFound):
+		handlers.RespondWithError(c, http.StatusNotFound, err)
+	case errors.Is(err, constants.ErrBarcodeExists),
+		errors.Is(err, constants.ErrCopyInCirculation),
+		errors.Is(err, constants.ErrCopyStatusChanged):
+		handlers.RespondWithError(c, http.StatusConflict, err)
+	default:
+		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
+	}
+}
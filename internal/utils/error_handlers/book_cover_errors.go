@@ -0,0 +1,27 @@
+package error_handlers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/utils/handlers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleBookCoverError handles errors specific to the BookCoverHandler
+func HandleBookCoverError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, constants.ErrBookNotFound),
+		errors.Is(err, constants.ErrCoverNotFound):
+		handlers.RespondWithError(c, http.StatusNotFound, err)
+	case errors.Is(err, constants.ErrCoverTooLarge):
+		handlers.RespondWithError(c, http.StatusRequestEntityTooLarge, err)
+	case errors.Is(err, constants.ErrUnsupportedCoverType):
+		handlers.RespondWithError(c, http.StatusUnsupportedMediaType, err)
+	case errors.Is(err, constants.ErrInvalidCoverImage):
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+	default:
+		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
+	}
+}
@@ -11,10 +11,22 @@ import (
 
 func HandleBookError(c *gin.Context, err error) {
 	switch {
-	case errors.Is(err, constants.ErrISBNExists):
+	case errors.Is(err, constants.ErrISBNExists),
+		errors.Is(err, constants.ErrBarcodeExists):
 		handlers.RespondWithError(c, http.StatusConflict, err)
-	case errors.Is(err, constants.ErrBookNotFound):
+	case errors.Is(err, constants.ErrBookNotFound),
+		errors.Is(err, constants.ErrCategoryNotFound):
 		handlers.RespondWithError(c, http.StatusNotFound, err)
+	case errors.Is(err, constants.ErrAuthorRequired),
+		errors.Is(err, constants.ErrInvalidISBN),
+		errors.Is(err, constants.ErrInvalidCSV),
+		errors.Is(err, constants.ErrImportColumnMissing),
+		errors.Is(err, constants.ErrUnknownImportColumn),
+		errors.Is(err, constants.ErrDuplicateImportColumn),
+		errors.Is(err, constants.ErrImportFileEmpty),
+		errors.Is(err, constants.ErrInvalidMARC),
+		errors.Is(err, constants.ErrInvalidExportFormat):
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
 	default:
 		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
 	}
@@ -0,0 +1,27 @@
+package error_handlers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/utils/handlers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleBookLookupError handles errors specific to the BookLookupHandler
+func HandleBookLookupError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, constants.ErrInvalidISBN):
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+	case errors.Is(err, constants.ErrMetadataNotFound):
+		handlers.RespondWithError(c, http.StatusNotFound, err)
+	case errors.Is(err, constants.ErrISBNExists):
+		handlers.RespondWithError(c, http.StatusConflict, err)
+	case errors.Is(err, constants.ErrMetadataUnavailable):
+		// Only our message, the provider's details are for the logs
+		handlers.RespondWithError(c, http.StatusBadGateway, constants.ErrMetadataUnavailable)
+	default:
+		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
+	}
+}
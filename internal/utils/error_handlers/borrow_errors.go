@@ -18,10 +18,24 @@ func HandleBorrowError(c *gin.Context, err error) {
 	}
 
 	switch {
+	case errors.Is(err, constants.ErrDueDateOverride),
+		errors.Is(err, constants.ErrEmailNotVerified):
+		handlers.RespondWithError(c, http.StatusForbidden, err)
+	case errors.Is(err, constants.ErrLoanChanged):
+		handlers.RespondWithError(c, http.StatusConflict, err)
 	case errors.Is(err, constants.ErrUserNotFound),
 		errors.Is(err, constants.ErrBookNotFound),
 		errors.Is(err, constants.ErrBorrowNotFound),
-		errors.Is(err, constants.ErrBookNotAvailable):
+		errors.Is(err, constants.ErrBookNotAvailable),
+		errors.Is(err, constants.ErrAlreadyReturned),
+		errors.Is(err, constants.ErrInvalidStatus),
+		errors.Is(err, constants.ErrRenewalLimitReached),
+		errors.Is(err, constants.ErrLoanTooOverdue),
+		errors.Is(err, constants.ErrBookOnHold),
+		errors.Is(err, constants.ErrOutstandingFines),
+		errors.Is(err, constants.ErrItemNotLoanable),
+		errors.Is(err, constants.ErrLoanLimitReached),
+		errors.Is(err, constants.ErrInvalidDueDate):
 		handlers.RespondWithError(c, http.StatusBadRequest, err)
 	default:
 		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
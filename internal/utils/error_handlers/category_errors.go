@@ -0,0 +1,32 @@
+package error_handlers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/utils/handlers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleCategoryError handles errors specific to the CategoryHandler
+func HandleCategoryError(c *gin.Context, err error) {
+	var validationErr *handlers.ValidationError
+	if errors.As(err, &validationErr) {
+		handlers.RespondWithError(c, http.StatusBadRequest, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, constants.ErrCategoryExists),
+		errors.Is(err, constants.ErrCategoryInUse):
+		handlers.RespondWithError(c, http.StatusConflict, err)
+	case errors.Is(err, constants.ErrCategoryNotFound):
+		handlers.RespondWithError(c, http.StatusNotFound, err)
+	case errors.Is(err, constants.ErrCategoryCycle),
+		errors.Is(err, constants.ErrInvalidInput):
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+	default:
+		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
+	}
+}
@@ -0,0 +1,30 @@
+package error_handlers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/utils/handlers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleFineError handles errors specific to the FineHandler
+func HandleFineError(c *gin.Context, err error) {
+	var validationErr *handlers.ValidationError
+	if errors.As(err, &validationErr) {
+		handlers.RespondWithError(c, http.StatusBadRequest, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, constants.ErrFineNotFound):
+		handlers.RespondWithError(c, http.StatusNotFound, err)
+	case errors.Is(err, constants.ErrFineSettled):
+		handlers.RespondWithError(c, http.StatusConflict, err)
+	case errors.Is(err, constants.ErrPaymentExceedsBalance):
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+	default:
+		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
+	}
+}
@@ -0,0 +1,32 @@
+package error_handlers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/utils/handlers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleHoldError handles errors specific to the HoldHandler
+func HandleHoldError(c *gin.Context, err error) {
+	var validationErr *handlers.ValidationError
+	if errors.As(err, &validationErr) {
+		handlers.RespondWithError(c, http.StatusBadRequest, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, constants.ErrHoldNotFound),
+		errors.Is(err, constants.ErrBookNotFound):
+		handlers.RespondWithError(c, http.StatusNotFound, err)
+	case errors.Is(err, constants.ErrHoldExists),
+		errors.Is(err, constants.ErrHoldNotActive),
+		errors.Is(err, constants.ErrBookAvailable),
+		errors.Is(err, constants.ErrAlreadyBorrowed):
+		handlers.RespondWithError(c, http.StatusConflict, err)
+	default:
+		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
+	}
+}
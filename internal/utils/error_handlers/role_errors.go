@@ -0,0 +1,34 @@
+package error_handlers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/utils/handlers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleRoleError handles errors specific to the RoleHandler
+func HandleRoleError(c *gin.Context, err error) {
+	var validationErr *handlers.ValidationError
+	if errors.As(err, &validationErr) {
+		handlers.RespondWithError(c, http.StatusBadRequest, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, constants.ErrRoleExists),
+		errors.Is(err, constants.ErrRoleInUse):
+		handlers.RespondWithError(c, http.StatusConflict, err)
+	case errors.Is(err, constants.ErrRoleNotFound):
+		handlers.RespondWithError(c, http.StatusNotFound, err)
+	case errors.Is(err, constants.ErrBuiltInRole),
+		errors.Is(err, constants.ErrAdminPermissions),
+		errors.Is(err, constants.ErrUnknownPermission),
+		errors.Is(err, constants.ErrInvalidInput):
+		handlers.RespondWithError(c, http.StatusBadRequest, err)
+	default:
+		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
+	}
+}
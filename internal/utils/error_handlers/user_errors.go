@@ -18,12 +18,21 @@ func HandleUserError(c *gin.Context, err error) {
 	}
 
 	switch {
-	case errors.Is(err, constants.ErrEmailTaken):
+	case errors.Is(err, constants.ErrEmailTaken),
+		errors.Is(err, constants.ErrOpenLoans),
+		errors.Is(err, constants.ErrEmailAlreadyVerified):
 		handlers.RespondWithError(c, http.StatusConflict, err)
+	case errors.Is(err, constants.ErrRoleAssignment),
+		errors.Is(err, constants.ErrPrivilegedUser):
+		handlers.RespondWithError(c, http.StatusForbidden, err)
 	case errors.Is(err, constants.ErrUserNotFound):
 		handlers.RespondWithError(c, http.StatusNotFound, err)
-	case errors.Is(err, constants.ErrInvalidInput):
+	case errors.Is(err, constants.ErrInvalidRole),
+		errors.Is(err, constants.ErrWrongPassword),
+		errors.Is(err, constants.ErrInvalidInput):
 		handlers.RespondWithError(c, http.StatusBadRequest, err)
+	case errors.Is(err, constants.ErrTooManyRequests):
+		handlers.RespondWithError(c, http.StatusTooManyRequests, err)
 	default:
 		handlers.RespondWithError(c, http.StatusInternalServerError, constants.ErrInternalServer)
 	}
@@ -19,8 +19,15 @@ func BindAndValidate(c *gin.Context, req interface{}) error {
 		return constants.ErrInvalidInput
 	}
 
+	return Validate(req)
+}
+
+// Validate checks a request against the rules in its validate tags. Requests
+// that don't come in as JSON, e.g. rows of an import file, go through it too.
+func Validate(req interface{}) error {
 	validate := validator.New()
 	validate.RegisterValidation("password", validation.ValidatePassword)
+	validate.RegisterValidation("isbn", validation.ValidateISBN)
 
 	if err := validate.Struct(req); err != nil {
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
@@ -48,14 +48,18 @@ func FormatValidationErrors(errs validator.ValidationErrors, structInstance inte
 		switch e.Tag() {
 		case "type":
 			return &ValidationError{Message: jsonTag + " must be one of " + e.Param()} // Handle `oneof` tag
-		case "required":
+		case "required", "required_without":
 			return &ValidationError{Message: jsonTag + " is required"}
+		case "excluded_with":
+			return &ValidationError{Message: jsonTag + " can't be sent together with " + jsonName(reflected.Elem(), e.Param())}
 		case "email":
 			return &ValidationError{Message: jsonTag + " must be a valid email"}
 		case "min":
 			return &ValidationError{Message: jsonTag + " must be at least " + e.Param() + " characters long"}
 		case "oneof":
 			return &ValidationError{Message: jsonTag + " must be one of " + e.Param()} // Handle `oneof` tag
+		case "isbn":
+			return &ValidationError{Message: jsonTag + " must be a valid ISBN-10 or ISBN-13"}
 		case "password":
 			return &ValidationError{Message: jsonTag + " must be at least 8 characters long, contain 1 uppercase, 1 lowercase, 1 number, and 1 special character"}
 		default:
@@ -66,3 +70,15 @@ func FormatValidationErrors(errs validator.ValidationErrors, structInstance inte
 	// Fallback error
 	return constants.ErrInvalidInput
 }
+
+// jsonName returns the name a struct field has in JSON
+func jsonName(structType reflect.Type, fieldName string) string {
+	field, found := structType.FieldByName(fieldName)
+	if !found {
+		return fieldName
+	}
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return fieldName
+}
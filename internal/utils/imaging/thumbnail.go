@@ -0,0 +1,65 @@
+// Package imaging makes thumbnails of images using only the standard library.
+package imaging
+
+import (
+	"image"
+	"image/draw"
+	"math"
+)
+
+// Thumbnail scales an image down to fit within width x height, keeping its
+// aspect ratio, and flattens it onto a white background so it can be
+// encoded as JPEG. An image that already fits is only flattened. Each pixel
+// of the thumbnail is the average of the pixels it covers.
+func Thumbnail(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+
+	src := image.NewRGBA(image.Rect(0, 0, srcWidth, srcHeight))
+	draw.Draw(src, src.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Over)
+
+	scale := math.Min(1, math.Min(float64(width)/float64(srcWidth), float64(height)/float64(srcHeight)))
+	dstWidth := max(1, int(math.Round(float64(srcWidth)*scale)))
+	dstHeight := max(1, int(math.Round(float64(srcHeight)*scale)))
+	if dstWidth == srcWidth && dstHeight == srcHeight {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := span(y, srcHeight, dstHeight)
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := span(x, srcWidth, dstWidth)
+
+			var r, g, b, count int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					b += int(row[sx*4+2])
+					count++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / count)
+			dst.Pix[i+1] = uint8(g / count)
+			dst.Pix[i+2] = uint8(b / count)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+	return dst
+}
+
+// span returns the source pixels, from inclusive to exclusive, covered by
+// pixel i of a destination that is size long instead of srcSize
+func span(i, srcSize, size int) (int, int) {
+	from := i * srcSize / size
+	to := (i + 1) * srcSize / size
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
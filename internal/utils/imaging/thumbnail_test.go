@@ -0,0 +1,64 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestThumbnail_ScalesDownToFit(t *testing.T) {
+	// Left half red, right half blue
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			if x < 200 {
+				img.Set(x, y, color.RGBA{R: 0xff, A: 0xff})
+			} else {
+				img.Set(x, y, color.RGBA{B: 0xff, A: 0xff})
+			}
+		}
+	}
+
+	thumbnail := Thumbnail(img, 200, 300)
+
+	if thumbnail.Bounds().Dx() != 200 || thumbnail.Bounds().Dy() != 100 {
+		t.Fatalf("expected a 200x100 thumbnail, got %v", thumbnail.Bounds())
+	}
+	if got := thumbnail.RGBAAt(10, 50); got != (color.RGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("expected red on the left, got %v", got)
+	}
+	if got := thumbnail.RGBAAt(190, 50); got != (color.RGBA{B: 0xff, A: 0xff}) {
+		t.Errorf("expected blue on the right, got %v", got)
+	}
+}
+
+func TestThumbnail_AveragesPixels(t *testing.T) {
+	// A checkerboard of black and white pixels turns grey
+	img := image.NewGray(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if (x+y)%2 == 0 {
+				img.SetGray(x, y, color.Gray{Y: 0xff})
+			}
+		}
+	}
+
+	thumbnail := Thumbnail(img, 2, 2)
+
+	if got := thumbnail.RGBAAt(1, 1); got != (color.RGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff}) {
+		t.Errorf("expected grey, got %v", got)
+	}
+}
+
+func TestThumbnail_KeepsSmallImagesAndFlattensTransparency(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(10, 10, 30, 40))
+
+	thumbnail := Thumbnail(img, 200, 300)
+
+	if thumbnail.Bounds() != image.Rect(0, 0, 20, 30) {
+		t.Fatalf("expected a 20x30 image, got %v", thumbnail.Bounds())
+	}
+	if got := thumbnail.RGBAAt(0, 0); got != (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}) {
+		t.Errorf("expected transparent pixels to turn white, got %v", got)
+	}
+}
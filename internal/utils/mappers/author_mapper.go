@@ -0,0 +1,89 @@
+package mappers
+
+import (
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"regexp"
+	"strings"
+)
+
+// authorSeparator splits "A; B", "A and B" and "A & B" into separate authors
+var authorSeparator = regexp.MustCompile(`(?i)\s*;\s*|\s+(?:and|&)\s+`)
+
+// Convert AuthorCreateRequest to Author model
+func MapCreateRequestToAuthor(req dto.AuthorCreateRequest) *models.Author {
+	return &models.Author{
+		Name: NormalizeAuthorName(req.Name),
+		Bio:  req.Bio,
+	}
+}
+
+// Update Author model from DTO
+func UpdateAuthorFromDTO(author *models.Author, req dto.AuthorUpdateRequest) {
+	if req.Name != nil {
+		author.Name = NormalizeAuthorName(*req.Name)
+	}
+	if req.Bio != nil {
+		author.Bio = *req.Bio
+	}
+}
+
+// MapAuthorToResponse maps a models.Author to an AuthorResponse.
+func MapAuthorToResponse(author *models.Author) dto.AuthorResponse {
+	return dto.AuthorResponse{
+		ID:   author.ID,
+		Name: author.Name,
+		Bio:  author.Bio,
+	}
+}
+
+// NormalizeAuthorName trims an author name and collapses its inner whitespace
+func NormalizeAuthorName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// NormalizeAuthorNames normalizes a list of author names, dropping blank
+// names and repeats of the same name in another case. The order is kept.
+func NormalizeAuthorNames(names []string) []string {
+	return normalizeNames(names)
+}
+
+// normalizeNames trims names and collapses their inner whitespace, dropping
+// blank names and repeats of the same name in another case
+func normalizeNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
+
+// SplitAuthorNames splits a free-text author credit such as
+// "Alan A. A. Donovan, Brian W. Kernighan" or "Kernighan; Ritchie" into
+// author names. Commas only separate authors when every part is a full
+// name, so "Tolkien, J. R. R." stays a single author.
+func SplitAuthorNames(credit string) []string {
+	var names []string
+	for _, part := range authorSeparator.Split(credit, -1) {
+		pieces := strings.Split(part, ",")
+		fullNames := len(pieces) > 1
+		for _, piece := range pieces {
+			if len(strings.Fields(piece)) < 2 {
+				fullNames = false
+			}
+		}
+		if fullNames {
+			names = append(names, pieces...)
+		} else {
+			names = append(names, part)
+		}
+	}
+	return NormalizeAuthorNames(names)
+}
@@ -0,0 +1,54 @@
+package mappers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitAuthorNames(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Single author",
+			input:    "Robert C. Martin",
+			expected: []string{"Robert C. Martin"},
+		},
+		{
+			name:     "Comma separated full names",
+			input:    "Alan A. A. Donovan, Brian W. Kernighan",
+			expected: []string{"Alan A. A. Donovan", "Brian W. Kernighan"},
+		},
+		{
+			name:     "Last name first",
+			input:    "Tolkien, J. R. R.",
+			expected: []string{"Tolkien, J. R. R."},
+		},
+		{
+			name:     "Semicolons, and, ampersands",
+			input:    "Kernighan, Brian; Ritchie, Dennis & Pike, Rob AND Thompson, Ken",
+			expected: []string{"Kernighan, Brian", "Ritchie, Dennis", "Pike, Rob", "Thompson, Ken"},
+		},
+		{
+			name:     "Repeated and blank names",
+			input:    "Jane  Doe; jane doe;  ; John Smith",
+			expected: []string{"Jane Doe", "John Smith"},
+		},
+		{
+			name:     "And inside a name",
+			input:    "Hans Christian Andersen",
+			expected: []string{"Hans Christian Andersen"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := SplitAuthorNames(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Test case %s failed: expected %q, got %q", tc.name, tc.expected, result)
+			}
+		})
+	}
+}
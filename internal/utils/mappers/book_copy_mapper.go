@@ -0,0 +1,63 @@
+package mappers
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"time"
+)
+
+// Convert BookCopyCreateRequest to BookCopy model. New copies go straight on the shelf.
+func MapCopyCreateRequestToCopy(req dto.BookCopyCreateRequest, bookID uint) *models.BookCopy {
+	bookCopy := &models.BookCopy{
+		BookID:    bookID,
+		Barcode:   req.Barcode,
+		Location:  req.Location,
+		Condition: req.Condition,
+		Status:    string(constants.CopyAvailable),
+	}
+	if bookCopy.Condition == "" {
+		bookCopy.Condition = string(constants.ConditionGood)
+	}
+	if req.AcquiredAt != nil {
+		bookCopy.AcquiredAt = *req.AcquiredAt
+	} else {
+		bookCopy.AcquiredAt = time.Now()
+	}
+	return bookCopy
+}
+
+// Update BookCopy model from DTO. The status is changed separately.
+func UpdateCopyFromDTO(bookCopy *models.BookCopy, req dto.BookCopyUpdateRequest) {
+	if req.Barcode != nil {
+		bookCopy.Barcode = *req.Barcode
+	}
+	if req.Location != nil {
+		bookCopy.Location = *req.Location
+	}
+	if req.Condition != nil {
+		bookCopy.Condition = *req.Condition
+	}
+	if req.AcquiredAt != nil {
+		bookCopy.AcquiredAt = *req.AcquiredAt
+	}
+}
+
+// MapCopyToResponse maps a models.BookCopy to a BookCopyResponse.
+// Book details are only included when they were preloaded.
+func MapCopyToResponse(bookCopy *models.BookCopy) dto.BookCopyResponse {
+	response := dto.BookCopyResponse{
+		ID:         bookCopy.ID,
+		BookID:     bookCopy.BookID,
+		Barcode:    bookCopy.Barcode,
+		Location:   bookCopy.Location,
+		Condition:  bookCopy.Condition,
+		AcquiredAt: bookCopy.AcquiredAt,
+		Status:     bookCopy.Status,
+	}
+	if bookCopy.Book.ID != 0 {
+		book := MapBookToResponse(&bookCopy.Book)
+		response.Book = &book
+	}
+	return response
+}
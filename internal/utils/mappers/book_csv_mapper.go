@@ -0,0 +1,137 @@
+package mappers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bookCSVColumns are the columns a catalog import file can have, in any order
+var bookCSVColumns = []string{"title", "authors", "isbn", "published_at", "item_type", "category_ids", "tags", "barcodes"}
+
+// requiredBookCSVColumns must be in every import file
+var requiredBookCSVColumns = []string{"title", "authors", "isbn", "published_at"}
+
+// csvListSeparator separates the values of list columns. Author names often
+// have commas in them, so commas can't be used.
+const csvListSeparator = ";"
+
+// MapCSVToBookImportRows reads the books of a catalog import file. The first
+// record is a header naming the columns. Each following record is a book,
+// with one copy registered per barcode. A record that can't be read as a
+// book is returned with Err set; a file that can't be read as CSV at all is
+// an error.
+func MapCSVToBookImportRows(r io.Reader) ([]dto.BookImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, constants.ErrImportFileEmpty
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", constants.ErrInvalidCSV, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheets like to start the file with a byte order mark
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(bookCSVColumns, name) {
+			return nil, fmt.Errorf("%w %q", constants.ErrUnknownImportColumn, name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w %q", constants.ErrDuplicateImportColumn, name)
+		}
+		columns[name] = i
+	}
+	for _, name := range requiredBookCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w %q", constants.ErrImportColumnMissing, name)
+		}
+	}
+
+	var rows []dto.BookImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %v", constants.ErrInvalidCSV, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := dto.BookImportRow{Line: line}
+		if err != nil {
+			row.Err = constants.ErrImportFieldCount
+		} else {
+			row.Book, row.Err = mapCSVRecordToBook(record, columns)
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, constants.ErrImportFileEmpty
+	}
+	return rows, nil
+}
+
+// mapCSVRecordToBook converts a record of an import file to a create request
+func mapCSVRecordToBook(record []string, columns map[string]int) (dto.BookCreateRequest, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	req := dto.BookCreateRequest{
+		Title:    field("title"),
+		Authors:  splitCSVList(field("authors")),
+		ISBN:     field("isbn"),
+		ItemType: field("item_type"),
+		Tags:     splitCSVList(field("tags")),
+	}
+	for _, barcode := range splitCSVList(field("barcodes")) {
+		req.Copies = append(req.Copies, dto.BookCopyCreateRequest{Barcode: barcode})
+	}
+
+	// A missing date is left for validation to report
+	if publishedAt := field("published_at"); publishedAt != "" {
+		date, err := time.Parse(time.DateOnly, publishedAt)
+		if err != nil {
+			return req, constants.ErrInvalidDate
+		}
+		req.PublishedAt = date
+	}
+	for _, value := range splitCSVList(field("category_ids")) {
+		id, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			return req, constants.ErrInvalidCategoryID
+		}
+		req.CategoryIDs = append(req.CategoryIDs, uint(id))
+	}
+	return req, nil
+}
+
+// splitCSVList splits a list column into its values, dropping blank ones
+func splitCSVList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, csvListSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
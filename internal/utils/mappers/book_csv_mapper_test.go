@@ -0,0 +1,77 @@
+package mappers
+
+import (
+	"errors"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMapCSVToBookImportRows(t *testing.T) {
+	file := "\ufeffTitle,Authors,ISBN,Published_At,Tags,Barcodes,Category_IDs\n" +
+		"The Go Programming Language,\"Donovan, Alan A. A.; Kernighan, Brian W.\",978-0-13-419044-0,2015-10-26,go; programming,GO-1;GO-2,3\n" +
+		"\"A title\nover two lines\",Jane Doe,0134190440,26/10/2015,,,\n" +
+		"Too short,Jane Doe\n" +
+		"Bad category,Jane Doe,0134190440,2015-10-26,,,fiction\n"
+
+	rows, err := MapCSVToBookImportRows(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []dto.BookImportRow{
+		{
+			Line: 2,
+			Book: dto.BookCreateRequest{
+				Title:       "The Go Programming Language",
+				Authors:     []string{"Donovan, Alan A. A.", "Kernighan, Brian W."},
+				ISBN:        "978-0-13-419044-0",
+				PublishedAt: time.Date(2015, 10, 26, 0, 0, 0, 0, time.UTC),
+				CategoryIDs: []uint{3},
+				Tags:        []string{"go", "programming"},
+				Copies:      []dto.BookCopyCreateRequest{{Barcode: "GO-1"}, {Barcode: "GO-2"}},
+			},
+		},
+		{Line: 3, Err: constants.ErrInvalidDate},
+		{Line: 5, Err: constants.ErrImportFieldCount},
+		{Line: 6, Err: constants.ErrInvalidCategoryID},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("expected %d rows, got %d", len(expected), len(rows))
+	}
+	for i, row := range rows {
+		if row.Line != expected[i].Line || !errors.Is(row.Err, expected[i].Err) {
+			t.Errorf("row %d: expected line %d and error %v, got line %d and error %v", i, expected[i].Line, expected[i].Err, row.Line, row.Err)
+		}
+	}
+	if !reflect.DeepEqual(rows[0].Book, expected[0].Book) {
+		t.Errorf("expected %+v, got %+v", expected[0].Book, rows[0].Book)
+	}
+}
+
+func TestMapCSVToBookImportRows_BadHeader(t *testing.T) {
+	testCases := []struct {
+		name     string
+		file     string
+		expected error
+	}{
+		{name: "Empty file", file: "", expected: constants.ErrImportFileEmpty},
+		{name: "Header only", file: "title,authors,isbn,published_at\n", expected: constants.ErrImportFileEmpty},
+		{name: "Missing column", file: "title,authors,published_at\nA,B,2020-01-01\n", expected: constants.ErrImportColumnMissing},
+		{name: "Unknown column", file: "title,authors,isbn,published_at,price\n", expected: constants.ErrUnknownImportColumn},
+		{name: "Repeated column", file: "title,authors,isbn,published_at,ISBN\n", expected: constants.ErrDuplicateImportColumn},
+		{name: "Not CSV", file: "title,authors,isbn,published_at\n\"A,B,C,D\n", expected: constants.ErrInvalidCSV},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := MapCSVToBookImportRows(strings.NewReader(tc.file))
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("Test case %s failed: expected %v, got %v", tc.name, tc.expected, err)
+			}
+		})
+	}
+}
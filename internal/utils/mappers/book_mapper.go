@@ -1,36 +1,63 @@
 package mappers
 
 import (
+	"library-management/internal/constants"
 	"library-management/internal/dto"
 	"library-management/internal/models"
+	"strings"
 )
 
-// Convert BookCreateRequest to Book model
+// Convert BookCreateRequest to Book model. The authors are linked by the
+// service, which looks them up by name.
 func MapCreateRequestToBook(req dto.BookCreateRequest) *models.Book {
-	return &models.Book{
-		Title:           req.Title,
-		Author:          req.Author,
-		ISBN:            req.ISBN,
-		CopiesAvailable: req.CopiesAvailable,
-		PublishedAt:     req.PublishedAt,
+	book := &models.Book{
+		Title:       req.Title,
+		Author:      strings.Join(NormalizeAuthorNames(req.Authors), ", "),
+		ISBN:        req.ISBN,
+		PublishedAt: req.PublishedAt,
+		ItemType:    req.ItemType,
 	}
+	if book.ItemType == "" {
+		book.ItemType = string(constants.ItemBook)
+	}
+	// The book ID is filled in when the copies are saved along with the book
+	for _, copyReq := range req.Copies {
+		book.Copies = append(book.Copies, *MapCopyCreateRequestToCopy(copyReq, 0))
+	}
+	return book
 }
 
-// Update Book model from DTO
+// Update Book model from DTO. Authors are replaced by the service.
 func UpdateBookFromDTO(book *models.Book, req dto.BookUpdateRequest) {
 	if req.Title != nil {
 		book.Title = *req.Title
 	}
-	if req.Author != nil {
-		book.Author = *req.Author
-	}
 	if req.ISBN != nil {
 		book.ISBN = *req.ISBN
 	}
-	if req.CopiesAvailable != nil {
-		book.CopiesAvailable = *req.CopiesAvailable
-	}
 	if req.PublishedAt != nil {
 		book.PublishedAt = *req.PublishedAt
 	}
+	if req.ItemType != nil {
+		book.ItemType = *req.ItemType
+	}
+}
+
+// MapBookToCreateRequest converts a book read from a catalog record back to
+// a create request, so it goes through the same checks as any new book.
+// Authors and tags are taken by name.
+func MapBookToCreateRequest(book *models.Book) dto.BookCreateRequest {
+	req := dto.BookCreateRequest{
+		Title:       book.Title,
+		ISBN:        book.ISBN,
+		PublishedAt: book.PublishedAt,
+		ItemType:    book.ItemType,
+	}
+	for _, bookAuthor := range book.BookAuthors {
+		req.Authors = append(req.Authors, bookAuthor.Author.Name)
+	}
+	for _, tag := range book.Tags {
+		req.Tags = append(req.Tags, tag.Name)
+	}
+	return req
 }
@@ -17,18 +17,24 @@ func TestMapCreateRequestToBook(t *testing.T) {
 		{
 			name: "Valid BookCreateRequest",
 			input: dto.BookCreateRequest{
-				Title:           "The Go Programming Language",
-				Author:          "Alan A. A. Donovan",
-				ISBN:            "978-0134190440",
-				CopiesAvailable: 5,
-				PublishedAt:     time.Date(2015, 11, 1, 0, 0, 0, 0, time.UTC),
+				Title:       "The Go Programming Language",
+				Authors:     []string{"Alan A. A. Donovan", " Brian  W. Kernighan"},
+				ISBN:        "978-0134190440",
+				PublishedAt: time.Date(2015, 11, 1, 0, 0, 0, 0, time.UTC),
+				Copies: []dto.BookCopyCreateRequest{
+					{Barcode: "LIB-000001", Location: "A1"},
+					{Barcode: "LIB-000002", Condition: "new"},
+				},
 			},
 			expected: &models.Book{
-				Title:           "The Go Programming Language",
-				Author:          "Alan A. A. Donovan",
-				ISBN:            "978-0134190440",
-				CopiesAvailable: 5,
-				PublishedAt:     time.Date(2015, 11, 1, 0, 0, 0, 0, time.UTC),
+				Title:       "The Go Programming Language",
+				Author:      "Alan A. A. Donovan, Brian W. Kernighan",
+				ISBN:        "978-0134190440",
+				PublishedAt: time.Date(2015, 11, 1, 0, 0, 0, 0, time.UTC),
+				Copies: []models.BookCopy{
+					{Barcode: "LIB-000001", Location: "A1", Condition: "good", Status: "available"},
+					{Barcode: "LIB-000002", Condition: "new", Status: "available"},
+				},
 			},
 		},
 	}
@@ -43,10 +49,36 @@ func TestMapCreateRequestToBook(t *testing.T) {
 			if result.Title != tc.expected.Title ||
 				result.Author != tc.expected.Author ||
 				result.ISBN != tc.expected.ISBN ||
-				result.CopiesAvailable != tc.expected.CopiesAvailable ||
-				!result.PublishedAt.Equal(tc.expected.PublishedAt) {
-				t.Errorf("Test case %s failed: expected %+v, got %+v", tc.name, tc.expected, result)
+				!result.PublishedAt.Equal(tc.expected.PublishedAt) ||
+				len(result.Copies) != len(tc.expected.Copies) {
+				t.Fatalf("Test case %s failed: expected %+v, got %+v", tc.name, tc.expected, result)
+			}
+			for i, expected := range tc.expected.Copies {
+				got := result.Copies[i]
+				if got.Barcode != expected.Barcode ||
+					got.Location != expected.Location ||
+					got.Condition != expected.Condition ||
+					got.Status != expected.Status ||
+					got.AcquiredAt.IsZero() {
+					t.Errorf("Test case %s failed: expected copy %+v, got %+v", tc.name, expected, got)
+				}
 			}
 		})
 	}
 }
+
+func TestMapBookSearchResultToResponse_EscapesHighlights(t *testing.T) {
+	result := &models.BookSearchResult{
+		TitleHighlight:  "<script>alert(1)</script> " + models.HighlightStart + "Go" + models.HighlightStop + " & Rust",
+		AuthorHighlight: "O'Brien " + models.HighlightStart + "<b>" + models.HighlightStop,
+	}
+
+	response := MapBookSearchResultToResponse(result)
+
+	if want := "&lt;script&gt;alert(1)&lt;/script&gt; <mark>Go</mark> &amp; Rust"; response.Highlights.Title != want {
+		t.Errorf("title highlight: got %q, want %q", response.Highlights.Title, want)
+	}
+	if want := "O&#39;Brien <mark>&lt;b&gt;</mark>"; response.Highlights.Author != want {
+		t.Errorf("author highlight: got %q, want %q", response.Highlights.Author, want)
+	}
+}
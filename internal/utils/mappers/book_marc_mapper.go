@@ -0,0 +1,25 @@
+package mappers
+
+import (
+	"io"
+	"library-management/internal/dto"
+	"library-management/internal/marc"
+)
+
+// MapMARCToBookImportRows reads the books of a MARC 21 or MARCXML file. The
+// rows are numbered by record rather than by line.
+func MapMARCToBookImportRows(r io.Reader) ([]dto.BookImportRow, error) {
+	records, err := marc.Read(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dto.BookImportRow, len(records))
+	for i, record := range records {
+		rows[i] = dto.BookImportRow{
+			Line: i + 1,
+			Book: MapBookToCreateRequest(marc.BookFromRecord(record)),
+		}
+	}
+	return rows, nil
+}
@@ -1,18 +1,69 @@
 package mappers
 
 import (
+	"fmt"
+	"html"
 	"library-management/internal/dto"
 	"library-management/internal/models"
+	"strings"
 )
 
 // MapBookToResponse maps a models.Book to a BookResponse.
 func MapBookToResponse(book *models.Book) dto.BookResponse {
-	return dto.BookResponse{
+	response := dto.BookResponse{
 		ID:              book.ID,
 		Title:           book.Title,
 		Author:          book.Author,
 		ISBN:            book.ISBN,
 		CopiesAvailable: book.CopiesAvailable,
 		PublishedAt:     book.PublishedAt,
+		ItemType:        book.ItemType,
+		Authors:         make([]dto.AuthorResponse, 0, len(book.BookAuthors)),
+		Categories:      make([]dto.CategoryResponse, 0, len(book.Categories)),
+		Tags:            make([]dto.TagResponse, 0, len(book.Tags)),
 	}
+	for _, bookAuthor := range book.BookAuthors {
+		response.Authors = append(response.Authors, MapAuthorToResponse(&bookAuthor.Author))
+	}
+	for _, category := range book.Categories {
+		response.Categories = append(response.Categories, MapCategoryToResponse(&category))
+	}
+	for _, tag := range book.Tags {
+		response.Tags = append(response.Tags, MapTagToResponse(&tag))
+	}
+	for _, bookCopy := range book.Copies {
+		response.Copies = append(response.Copies, MapCopyToResponse(&bookCopy))
+	}
+	if book.CoverVersion != "" {
+		response.Cover = MapBookToCoverResponse(book)
+	}
+	return response
+}
+
+// MapBookToCoverResponse gives the URLs of a book's cover. They carry the
+// cover's version, so a new cover gets new URLs.
+func MapBookToCoverResponse(book *models.Book) *dto.BookCoverResponse {
+	return &dto.BookCoverResponse{
+		URL:          fmt.Sprintf("/books/%d/cover?v=%s", book.ID, book.CoverVersion),
+		ThumbnailURL: fmt.Sprintf("/books/%d/cover/thumbnail?v=%s", book.ID, book.CoverVersion),
+	}
+}
+
+// MapBookSearchResultToResponse maps a search hit to a BookResponse with highlights.
+func MapBookSearchResultToResponse(result *models.BookSearchResult) dto.BookResponse {
+	response := MapBookToResponse(&result.Book)
+	response.Highlights = &dto.BookHighlights{
+		Title:  highlightHTML(result.TitleHighlight),
+		Author: highlightHTML(result.AuthorHighlight),
+	}
+	return response
+}
+
+// highlightReplacer turns the markers around matched terms into <mark> tags
+var highlightReplacer = strings.NewReplacer(models.HighlightStart, "<mark>", models.HighlightStop, "</mark>")
+
+// highlightHTML escapes a highlighted field for HTML, so the <mark> tags
+// around its matched terms are its only markup
+func highlightHTML(highlight string) string {
+	return highlightReplacer.Replace(html.EscapeString(highlight))
 }
@@ -0,0 +1,45 @@
+package mappers
+
+import (
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"time"
+)
+
+// MapBorrowToResponse maps a models.Borrow to a BorrowResponse.
+// User and book details are only included when they were preloaded.
+func MapBorrowToResponse(borrow *models.Borrow) dto.BorrowResponse {
+	response := dto.BorrowResponse{
+		ID:         borrow.ID,
+		UserID:     borrow.UserID,
+		BookID:     borrow.BookID,
+		CopyID:     borrow.CopyID,
+		BorrowedAt: borrow.CreatedAt,
+		DueDate:    borrow.DueDate,
+		Returned:   borrow.Returned,
+		ReturnedAt: borrow.ReturnedAt,
+		ReturnedBy: borrow.ReturnedBy,
+		Overdue:    borrow.IsOverdue(time.Now()),
+
+		RenewalCount: borrow.RenewalCount,
+	}
+	for _, renewal := range borrow.Renewals {
+		response.Renewals = append(response.Renewals, dto.RenewalResponse{
+			RenewedAt:       renewal.RenewedAt,
+			PreviousDueDate: renewal.PreviousDueDate,
+			NewDueDate:      renewal.NewDueDate,
+		})
+	}
+	if borrow.Copy != nil {
+		response.Barcode = borrow.Copy.Barcode
+	}
+	if borrow.User.ID != 0 {
+		user := MapUserToResponse(&borrow.User)
+		response.User = &user
+	}
+	if borrow.Book.ID != 0 {
+		book := MapBookToResponse(&borrow.Book)
+		response.Book = &book
+	}
+	return response
+}
@@ -0,0 +1,74 @@
+package mappers
+
+import (
+	"library-management/internal/models"
+	"testing"
+	"time"
+)
+
+func TestMapBorrowToResponse(t *testing.T) {
+	returnedAt := time.Now().Add(-time.Hour)
+	returnedBy := uint(7)
+
+	// Define test cases
+	testCases := []struct {
+		name            string
+		input           *models.Borrow
+		expectedOverdue bool
+		expectUser      bool
+	}{
+		{
+			name: "Active loan past its due date is overdue",
+			input: &models.Borrow{
+				UserID:  1,
+				BookID:  2,
+				DueDate: time.Now().Add(-24 * time.Hour),
+			},
+			expectedOverdue: true,
+		},
+		{
+			name: "Returned loan is never overdue",
+			input: &models.Borrow{
+				UserID:     1,
+				BookID:     2,
+				DueDate:    time.Now().Add(-24 * time.Hour),
+				Returned:   true,
+				ReturnedAt: &returnedAt,
+				ReturnedBy: &returnedBy,
+			},
+			expectedOverdue: false,
+		},
+		{
+			name: "Preloaded user is included",
+			input: &models.Borrow{
+				UserID:  1,
+				BookID:  2,
+				DueDate: time.Now().Add(24 * time.Hour),
+				User:    models.User{Name: "John Doe"},
+			},
+			expectUser: true,
+		},
+	}
+
+	// Run the test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectUser {
+				tc.input.User.ID = tc.input.UserID
+			}
+
+			// Call the function being tested
+			result := MapBorrowToResponse(tc.input)
+
+			// Compare the result with the expected output
+			if result.Overdue != tc.expectedOverdue ||
+				result.Returned != tc.input.Returned ||
+				result.ReturnedAt != tc.input.ReturnedAt ||
+				result.ReturnedBy != tc.input.ReturnedBy ||
+				(result.User != nil) != tc.expectUser ||
+				result.Book != nil {
+				t.Errorf("Test case %s failed: got %+v", tc.name, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package mappers
+
+import (
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"strings"
+)
+
+// Convert CategoryCreateRequest to Category model
+func MapCreateRequestToCategory(req dto.CategoryCreateRequest) *models.Category {
+	return &models.Category{
+		Name:     strings.Join(strings.Fields(req.Name), " "),
+		ParentID: req.ParentID,
+	}
+}
+
+// MapCategoryToResponse maps a models.Category to a CategoryResponse.
+func MapCategoryToResponse(category *models.Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:       category.ID,
+		Name:     category.Name,
+		ParentID: category.ParentID,
+	}
+}
+
+// BuildCategoryTree nests a flat list of categories under their parents.
+// Categories whose parent isn't in the list end up at the top level.
+func BuildCategoryTree(categories []models.Category) []dto.CategoryResponse {
+	known := make(map[uint]bool, len(categories))
+	children := make(map[uint][]models.Category, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+	var roots []models.Category
+	for _, category := range categories {
+		if category.ParentID != nil && known[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var build func(categories []models.Category) []dto.CategoryResponse
+	build = func(categories []models.Category) []dto.CategoryResponse {
+		responses := make([]dto.CategoryResponse, len(categories))
+		for i, category := range categories {
+			responses[i] = MapCategoryToResponse(&category)
+			responses[i].Children = build(children[category.ID])
+		}
+		return responses
+	}
+	return build(roots)
+}
@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"library-management/internal/models"
+	"testing"
+)
+
+func TestBuildCategoryTree(t *testing.T) {
+	category := func(id uint, name string, parentID *uint) models.Category {
+		c := models.Category{Name: name, ParentID: parentID}
+		c.ID = id
+		return c
+	}
+	science, physics, missing := uint(1), uint(2), uint(99)
+	categories := []models.Category{
+		category(4, "Astrophysics", &physics),
+		category(2, "Physics", &science),
+		category(1, "Science", nil),
+		category(3, "Orphan", &missing),
+	}
+
+	tree := BuildCategoryTree(categories)
+
+	if len(tree) != 2 || tree[0].Name != "Science" || tree[1].Name != "Orphan" {
+		t.Fatalf("unexpected roots: %+v", tree)
+	}
+	if len(tree[0].Children) != 1 || tree[0].Children[0].Name != "Physics" {
+		t.Fatalf("unexpected children of Science: %+v", tree[0].Children)
+	}
+	if len(tree[0].Children[0].Children) != 1 || tree[0].Children[0].Children[0].ID != 4 {
+		t.Fatalf("unexpected children of Physics: %+v", tree[0].Children[0].Children)
+	}
+}
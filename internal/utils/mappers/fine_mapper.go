@@ -0,0 +1,38 @@
+package mappers
+
+import (
+	"library-management/internal/dto"
+	"library-management/internal/models"
+)
+
+// MapFineToResponse maps a models.Fine to a FineResponse.
+// The user and the ledger are only included when they were preloaded.
+func MapFineToResponse(fine *models.Fine) dto.FineResponse {
+	response := dto.FineResponse{
+		ID:           fine.ID,
+		UserID:       fine.UserID,
+		BorrowID:     fine.BorrowID,
+		DaysOverdue:  fine.DaysOverdue,
+		Amount:       fine.Amount,
+		AmountPaid:   fine.AmountPaid,
+		AmountWaived: fine.AmountWaived,
+		Balance:      fine.Balance(),
+		Status:       fine.Status,
+		CreatedAt:    fine.CreatedAt,
+	}
+	for _, transaction := range fine.Transactions {
+		response.Transactions = append(response.Transactions, dto.FineTransactionResponse{
+			ID:         transaction.ID,
+			Type:       transaction.Type,
+			Amount:     transaction.Amount,
+			RecordedBy: transaction.RecordedBy,
+			Note:       transaction.Note,
+			CreatedAt:  transaction.CreatedAt,
+		})
+	}
+	if fine.User.ID != 0 {
+		user := MapUserToResponse(&fine.User)
+		response.User = &user
+	}
+	return response
+}
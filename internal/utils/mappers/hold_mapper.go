@@ -0,0 +1,31 @@
+package mappers
+
+import (
+	"library-management/internal/dto"
+	"library-management/internal/models"
+)
+
+// MapHoldToResponse maps a models.Hold to a HoldResponse.
+// User and book details are only included when they were preloaded.
+func MapHoldToResponse(hold *models.Hold) dto.HoldResponse {
+	response := dto.HoldResponse{
+		ID:        hold.ID,
+		UserID:    hold.UserID,
+		BookID:    hold.BookID,
+		Status:    hold.Status,
+		CopyID:    hold.CopyID,
+		PlacedAt:  hold.CreatedAt,
+		ReadyAt:   hold.ReadyAt,
+		ExpiresAt: hold.ExpiresAt,
+		ClosedAt:  hold.ClosedAt,
+	}
+	if hold.User.ID != 0 {
+		user := MapUserToResponse(&hold.User)
+		response.User = &user
+	}
+	if hold.Book.ID != 0 {
+		book := MapBookToResponse(&hold.Book)
+		response.Book = &book
+	}
+	return response
+}
@@ -0,0 +1,42 @@
+package mappers
+
+import (
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+)
+
+// MapRoleToResponse maps a models.Role to a RoleResponse, with the
+// permissions in the order they are listed in. The admin role is shown
+// with every permission, since that is what it grants.
+func MapRoleToResponse(role *models.Role) dto.RoleResponse {
+	granted := make(map[string]bool, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		granted[permission.Permission] = true
+	}
+	isAdmin := role.Name == string(constants.Admin)
+
+	permissions := make([]string, 0, len(role.Permissions))
+	for _, known := range constants.Permissions {
+		if isAdmin || granted[string(known.Permission)] {
+			permissions = append(permissions, string(known.Permission))
+		}
+	}
+
+	return dto.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		BuiltIn:     constants.UserRole(role.Name).IsBuiltIn(),
+		Permissions: permissions,
+	}
+}
+
+// MapPermissionsToResponse lists the permissions roles can grant.
+func MapPermissionsToResponse() []dto.PermissionResponse {
+	permissions := make([]dto.PermissionResponse, len(constants.Permissions))
+	for i, known := range constants.Permissions {
+		permissions[i] = dto.PermissionResponse{Name: string(known.Permission), Description: known.Description}
+	}
+	return permissions
+}
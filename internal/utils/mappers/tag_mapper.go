@@ -0,0 +1,20 @@
+package mappers
+
+import (
+	"library-management/internal/dto"
+	"library-management/internal/models"
+)
+
+// MapTagToResponse maps a models.Tag to a TagResponse.
+func MapTagToResponse(tag *models.Tag) dto.TagResponse {
+	return dto.TagResponse{
+		ID:   tag.ID,
+		Name: tag.Name,
+	}
+}
+
+// NormalizeTagNames normalizes a list of tag names, dropping blank names
+// and repeats of the same name in another case. The order is kept.
+func NormalizeTagNames(names []string) []string {
+	return normalizeNames(names)
+}
@@ -3,15 +3,27 @@ package mappers
 import (
 	"library-management/internal/dto"
 	"library-management/internal/models"
+	"time"
 )
 
 // MapUserToResponse maps a models.User to a UserResponse
 func MapUserToResponse(user *models.User) dto.UserResponse {
 	return dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Role:            user.Role,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		LockedUntil:     lockedUntil(user),
+		CreatedAt:       user.CreatedAt,
 	}
 }
+
+// lockedUntil returns when the lockout of a user ends, or nil if they
+// aren't locked out
+func lockedUntil(user *models.User) *time.Time {
+	if user.LockedUntil == nil || !time.Now().Before(*user.LockedUntil) {
+		return nil
+	}
+	return user.LockedUntil
+}
@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"library-management/internal/constants"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateISBN accepts ISBN-10 and ISBN-13 with a correct check digit.
+// Hyphens and spaces are allowed anywhere.
+func ValidateISBN(fl validator.FieldLevel) bool {
+	_, err := NormalizeISBN(fl.Field().String())
+	return err == nil
+}
+
+// CleanISBN strips the hyphens and spaces out of an ISBN and upper-cases
+// the X an ISBN-10 check digit can be
+func CleanISBN(isbn string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+}
+
+// NormalizeISBN checks an ISBN-10 or ISBN-13 and returns it as a bare
+// ISBN-13, e.g. "0-13-419044-0" becomes "9780134190440".
+func NormalizeISBN(isbn string) (string, error) {
+	isbn = CleanISBN(isbn)
+	switch len(isbn) {
+	case 10:
+		if !validISBN10(isbn) {
+			return "", constants.ErrInvalidISBN
+		}
+		// ISBN-10s live on as the 978 prefix of ISBN-13, with a new check digit
+		isbn13 := "978" + isbn[:9]
+		return isbn13 + string(rune('0'+isbn13CheckDigit(isbn13))), nil
+	case 13:
+		if !validISBN13(isbn) {
+			return "", constants.ErrInvalidISBN
+		}
+		return isbn, nil
+	default:
+		return "", constants.ErrInvalidISBN
+	}
+}
+
+// validISBN10 checks the digits of an ISBN-10 and its mod 11 check digit,
+// where X stands for 10
+func validISBN10(isbn string) bool {
+	sum := 0
+	for i, r := range isbn {
+		digit := int(r - '0')
+		if r == 'X' && i == 9 {
+			digit = 10
+		} else if r < '0' || r > '9' {
+			return false
+		}
+		sum += (10 - i) * digit
+	}
+	return sum%11 == 0
+}
+
+// validISBN13 checks the digits of an ISBN-13 and its check digit
+func validISBN13(isbn string) bool {
+	for _, r := range isbn {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return int(isbn[12]-'0') == isbn13CheckDigit(isbn[:12])
+}
+
+// isbn13CheckDigit computes the check digit of the first 12 digits of an
+// ISBN-13, weighting them alternately by 1 and 3
+func isbn13CheckDigit(digits string) int {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(digits[i]-'0')
+	}
+	return (10 - sum%10) % 10
+}
@@ -0,0 +1,37 @@
+package validation
+
+import "testing"
+
+func TestNormalizeISBN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		valid    bool
+	}{
+		{name: "Hyphenated ISBN-13", input: "978-0-13-419044-0", expected: "9780134190440", valid: true},
+		{name: "Bare ISBN-13", input: "9780132350884", expected: "9780132350884", valid: true},
+		{name: "ISBN-10", input: "0-13-419044-0", expected: "9780134190440", valid: true},
+		{name: "ISBN-10 with lowercase X", input: "0-8044-2957-x", expected: "9780804429573", valid: true},
+		{name: "ISBN-10 with spaces", input: "0 306 40615 2", expected: "9780306406157", valid: true},
+		{name: "Wrong ISBN-13 check digit", input: "9780134190441", valid: false},
+		{name: "Wrong ISBN-10 check digit", input: "0134190441", valid: false},
+		{name: "X outside the check digit", input: "01341904X0", valid: false},
+		{name: "X in an ISBN-13", input: "978013419044X", valid: false},
+		{name: "Wrong length", input: "978013419044", valid: false},
+		{name: "Letters", input: "97801341904AB", valid: false},
+		{name: "Empty", input: "", valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := NormalizeISBN(tc.input)
+			if tc.valid && (err != nil || result != tc.expected) {
+				t.Errorf("expected %q, got %q (%v)", tc.expected, result, err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("expected %q to be rejected, got %q", tc.input, result)
+			}
+		})
+	}
+}
@@ -3,68 +3,30 @@ package main
 import (
 	"fmt"
 	"log"
-	"os"
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-)
-
-type User struct {
-	ID        uint      `gorm:"primaryKey"`
-	Name      string    `gorm:"not null"`
-	Email     string    `gorm:"unique;not null"`
-	Password  string    `gorm:"not null"`
-	Role      string    `gorm:"not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-}
 
-type Book struct {
-	ID              uint      `gorm:"primaryKey"`
-	Title           string    `gorm:"not null"`
-	Author          string    `gorm:"not null"`
-	ISBN            string    `gorm:"unique;not null"`
-	CopiesAvailable int       `gorm:"not null"`
-	PublishedAt     time.Time `gorm:"not null"`
-}
+	"library-management/config"
+	"library-management/internal/constants"
+	"library-management/internal/dto"
+	"library-management/internal/models"
+	"library-management/internal/repository"
+	"library-management/internal/services"
+	"library-management/internal/utils/auth"
 
-// HashPassword hashes a given password
-func HashPassword(password string) (string, error) {
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", err
-	}
-	return string(hashed), nil
-}
+	"github.com/joho/godotenv"
+)
 
 func main() {
-	// Load .env file
-	// err := godotenv.Load()
-	// if err != nil {
-	// 	log.Fatalf("Error loading .env file: %v", err)
-	// }
 	// Load environment variables
-	dbHost := os.Getenv("DB_HOST")
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-	dbPort := os.Getenv("DB_PORT")
-
-	// Database connection string
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		log.Fatalf("❌ Failed to connect to database: %v", err)
+	if err := godotenv.Load(); err != nil {
+		log.Println("⚠️  Warning: No .env file found, using default values.")
 	}
 
-	// Auto-migrate tables
-	err = db.AutoMigrate(&User{}, &Book{})
-	if err != nil {
-		log.Fatalf("❌ Failed to auto-migrate: %v", err)
-	}
+	// The schema is created by the migrate command; this refuses to seed
+	// a database that isn't at the latest version
+	db := config.ConnectDatabase()
 
 	seedUsers(db)
 	seedBooks(db)
@@ -73,21 +35,23 @@ func main() {
 // Seed Users
 func seedUsers(db *gorm.DB) {
 	var count int64
-	db.Model(&User{}).Count(&count)
+	db.Model(&models.User{}).Count(&count)
 
 	if count > 0 {
 		fmt.Println("⚠️ Users table already has data, skipping seeding.")
 		return
 	}
 
-	hashedPassword, err := HashPassword("Aa12345@")
+	hashedPassword, err := auth.HashPassword("Aa12345@")
 	if err != nil {
 		log.Fatalf("❌ Error hashing password: %v", err)
 	}
 
-	users := []User{
-		{Name: "Admin User", Email: "admin@example.com", Password: hashedPassword, Role: "admin"},
-		{Name: "Regular User", Email: "user@example.com", Password: hashedPassword, Role: "user"},
+	// The demo accounts can borrow without verifying their email
+	verifiedAt := time.Now()
+	users := []models.User{
+		{Name: "Admin User", Email: "admin@example.com", Password: hashedPassword, Role: string(constants.Admin), EmailVerifiedAt: &verifiedAt},
+		{Name: "Regular User", Email: "user@example.com", Password: hashedPassword, Role: string(constants.Member), EmailVerifiedAt: &verifiedAt},
 	}
 
 	if err := db.Create(&users).Error; err != nil {
@@ -96,23 +60,58 @@ func seedUsers(db *gorm.DB) {
 	fmt.Println("✅ Seeded users successfully!")
 }
 
-// Seed Books
+// Seed Books, through the book service so they get their authors, a
+// normalized ISBN and copies like any book added through the API
 func seedBooks(db *gorm.DB) {
 	var count int64
-	db.Model(&Book{}).Count(&count)
+	db.Model(&models.Book{}).Count(&count)
 
 	if count > 0 {
 		fmt.Println("⚠️ Books table already has data, skipping seeding.")
 		return
 	}
 
-	books := []Book{
-		{Title: "The Go Programming Language", Author: "Alan A. A. Donovan", ISBN: "978-0134190440", CopiesAvailable: 5, PublishedAt: time.Now()},
-		{Title: "Clean Code", Author: "Robert C. Martin", ISBN: "978-0132350884", CopiesAvailable: 3, PublishedAt: time.Now()},
+	bookService := services.NewBookService(
+		repository.NewBookRepository(db),
+		repository.NewBookCopyRepository(db),
+		repository.NewAuthorRepository(db),
+		repository.NewCategoryRepository(db),
+		repository.NewTagRepository(db),
+	)
+
+	books := []dto.BookCreateRequest{
+		{
+			Title:       "The Go Programming Language",
+			Authors:     []string{"Alan A. A. Donovan", "Brian W. Kernighan"},
+			ISBN:        "978-0134190440",
+			PublishedAt: time.Date(2015, 10, 26, 0, 0, 0, 0, time.UTC),
+			Copies:      seedCopies("GOPL", 5),
+		},
+		{
+			Title:       "Clean Code",
+			Authors:     []string{"Robert C. Martin"},
+			ISBN:        "978-0132350884",
+			PublishedAt: time.Date(2008, 8, 1, 0, 0, 0, 0, time.UTC),
+			Copies:      seedCopies("CC", 3),
+		},
 	}
 
-	if err := db.Create(&books).Error; err != nil {
-		log.Fatalf("❌ Failed to seed books: %v", err)
+	for _, book := range books {
+		if _, err := bookService.CreateBook(book); err != nil {
+			log.Fatalf("❌ Failed to seed books: %v", err)
+		}
 	}
 	fmt.Println("✅ Seeded books successfully!")
 }
+
+// seedCopies returns count copies with barcodes like GOPL-001
+func seedCopies(prefix string, count int) []dto.BookCopyCreateRequest {
+	copies := make([]dto.BookCopyCreateRequest, count)
+	for i := range copies {
+		copies[i] = dto.BookCopyCreateRequest{
+			Barcode:   fmt.Sprintf("%s-%03d", prefix, i+1),
+			Condition: string(constants.ConditionNew),
+		}
+	}
+	return copies
+}